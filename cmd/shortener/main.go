@@ -76,5 +76,32 @@ func init() {
 // @contact.email  a.y.oleynik@gmail.com
 func main() {
 	ctx := context.Background()
+	if flag.Arg(0) == "migrate-data" {
+		migrateData(ctx, flag.Args()[1:])
+		return
+	}
 	app.Run(ctx)
 }
+
+// migrateData runs the command copying short URLs between the storages, the usage is printed
+// when a storage is not set.
+func migrateData(ctx context.Context, args []string) {
+	var (
+		from      string
+		to        string
+		batchSize int
+	)
+
+	fs := flag.NewFlagSet("migrate-data", flag.ExitOnError)
+	fs.StringVar(&from, "from", from,
+		"source storage url: file:///path, bolt:///path, postgres://... or sqlite://...")
+	fs.StringVar(&to, "to", to,
+		"destination storage url: file:///path, bolt:///path, postgres://... or sqlite://...")
+	fs.IntVar(&batchSize, "batch-size", 1000, "number of urls imported at once")
+	_ = fs.Parse(args)
+
+	if len(from) == 0 || len(to) == 0 {
+		fs.Usage()
+	}
+	app.MigrateData(ctx, from, to, batchSize)
+}
@@ -72,30 +72,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/shorten": {
-            "post": {
-                "description": "create short URL",
+        "/api/internal/admin/reports": {
+            "get": {
+                "description": "get reported short URLs with open reports, the most reported first",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "create short URL",
-                "operationId": "shortURL",
+                "summary": "get moderation queue",
+                "operationId": "reports",
                 "parameters": [
                     {
-                        "description": "long URL to shorten",
-                        "name": "longURL",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.shortURLRequest"
-                        }
+                        "type": "integer",
+                        "description": "maximum number of short URLs",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of short URLs to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.shortURLResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.reportResponse"
+                            }
                         }
                     },
                     "400": {
@@ -104,8 +110,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/http.errResponse"
                         }
@@ -125,37 +131,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/shorten/batch": {
+        "/api/internal/admin/reports/{id}/resolve": {
             "post": {
-                "description": "create of several short URLs",
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "create of several short URLs",
-                "operationId": "batchURL",
+                "description": "close open reports of the short URL and disable or enable it according to the moderator decision",
+                "summary": "resolve reports of short URL",
+                "operationId": "resolveReports",
                 "parameters": [
                     {
-                        "description": "several long URLs to shorten",
-                        "name": "bathURL",
+                        "type": "string",
+                        "description": "short URL id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "moderator decision",
+                        "name": "decision",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/http.batchURLRequest"
-                            }
+                            "$ref": "#/definitions/http.resolveRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/http.batchURLResponse"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -163,8 +164,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/http.errResponse"
                         }
@@ -184,21 +191,77 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/shorten/user/urls": {
+        "/api/internal/admin/storage/promote": {
+            "post": {
+                "description": "make the secondary storage of the dual-write primary, short URLs are read from it then",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "promote secondary storage",
+                "operationId": "promoteStorage",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.promoteStorageResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/internal/admin/urls": {
             "get": {
-                "description": "get short URLs for user ID",
+                "description": "search short URLs of all users by the long URL substring",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "get short URLs for user ID",
-                "operationId": "userURL",
+                "summary": "search short URLs of all users",
+                "operationId": "adminURL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "long URL substring",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of short URLs",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of short URLs to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/http.userURLResponse"
+                                "$ref": "#/definitions/http.adminURLResponse"
                             }
                         }
                     },
@@ -208,8 +271,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/http.errResponse"
                         }
@@ -229,12 +292,9 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "remove multiple short URLs",
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "remove multiple short URLs",
-                "operationId": "deleteURL",
+                "description": "remove short URLs of any user immediately",
+                "summary": "force delete short URLs",
+                "operationId": "adminDeleteURL",
                 "parameters": [
                     {
                         "description": "short URL ids to delete",
@@ -250,8 +310,8 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Accepted"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -259,8 +319,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errResponse"
                         }
                     },
-                    "410": {
-                        "description": "Gone",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/http.errResponse"
                         }
@@ -280,17 +340,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/internal/stats": {
-            "get": {
-                "description": "shorturl statistics",
-                "produces": [
-                    "application/json"
+        "/api/internal/admin/urls/{id}/disable": {
+            "post": {
+                "description": "disable short URL, it responds with 451 instead of redirect",
+                "summary": "disable short URL",
+                "operationId": "disableURL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "short URL id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "shorturl statistics",
-                "operationId": "stats",
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -304,8 +370,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
                         "schema": {
                             "$ref": "#/definitions/http.errResponse"
                         }
@@ -313,14 +385,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/ping": {
-            "get": {
-                "description": "health check shortener storage",
-                "summary": "health check shortener storage",
-                "operationId": "ping",
+        "/api/internal/admin/urls/{id}/enable": {
+            "post": {
+                "description": "enable previously disabled short URL",
+                "summary": "enable short URL",
+                "operationId": "enableURL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "short URL id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -328,8 +409,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
                         "schema": {
                             "$ref": "#/definitions/http.errResponse"
                         }
@@ -337,29 +430,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/{id}": {
+        "/api/internal/admin/users/{userID}/ban": {
             "post": {
-                "description": "get short URL",
-                "produces": [
-                    "text/plain"
-                ],
-                "summary": "get short URL",
-                "operationId": "getURL",
+                "description": "ban user ID, banned users can't create short URLs",
+                "summary": "ban user",
+                "operationId": "banUser",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "short URL id",
-                        "name": "id",
+                        "description": "user id",
+                        "name": "userID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -367,14 +454,47 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/http.errResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/internal/admin/users/{userID}/unban": {
+            "post": {
+                "description": "lift the ban of the user ID",
+                "summary": "unban user",
+                "operationId": "unbanUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/http.errResponse"
                         }
@@ -387,28 +507,1398 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "http.batchURLRequest": {
-            "type": "object",
-            "properties": {
-                "correlation_id": {
-                    "type": "string"
-                },
-                "original_url": {
-                    "type": "string"
-                }
-            }
         },
-        "http.batchURLResponse": {
-            "type": "object",
-            "properties": {
-                "correlation_id": {
-                    "type": "string"
-                },
-                "short_url": {
-                    "type": "string"
+        "/api/internal/audit": {
+            "get": {
+                "description": "get audit log events of mutating operations ordered by creation time",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "get audit log",
+                "operationId": "audit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "period start, RFC 3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "period end (exclusive), RFC 3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of events",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of events to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.auditResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shorten": {
+            "post": {
+                "description": "create short URL",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "create short URL",
+                "operationId": "shortURL",
+                "parameters": [
+                    {
+                        "description": "long URL to shorten",
+                        "name": "longURL",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.shortURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.shortURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shorten/batch": {
+            "post": {
+                "description": "create of several short URLs",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "create of several short URLs",
+                "operationId": "batchURL",
+                "parameters": [
+                    {
+                        "description": "several long URLs to shorten",
+                        "name": "bathURL",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.batchURLRequest"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.batchURLResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "the long URLs already shortened with the existing short URLs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.batchURLResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shorten/user/urls": {
+            "get": {
+                "description": "get short URLs for user ID",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "get short URLs for user ID",
+                "operationId": "userURL",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.userURLResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove multiple short URLs",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "remove multiple short URLs",
+                "operationId": "deleteURL",
+                "parameters": [
+                    {
+                        "description": "short URL ids to delete",
+                        "name": "ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/http.deleteURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/jobs/{id}": {
+            "get": {
+                "description": "get the state of the asynchronous job of the user with the outcome for every short URL\n(pending, deleted, not_found, not_owned)",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "get job status",
+                "operationId": "job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.jobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/login": {
+            "post": {
+                "description": "login user account, short URLs of the current cookie identity are moved to the account",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "login user account",
+                "operationId": "login",
+                "parameters": [
+                    {
+                        "description": "account credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.credentialsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.accountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/logout": {
+            "post": {
+                "description": "logout user account",
+                "summary": "logout user account",
+                "operationId": "logout",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/api/user/oidc/callback": {
+            "get": {
+                "description": "exchange the authorization code and start the session, short URLs of the current cookie identity are moved to the user",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "complete single sign-on",
+                "operationId": "oidcCallback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "authorization state",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.accountResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/oidc/login": {
+            "get": {
+                "description": "redirect to the OpenID Connect provider",
+                "summary": "start single sign-on",
+                "operationId": "oidcLogin",
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/register": {
+            "post": {
+                "description": "register user account, short URLs of the current cookie identity are moved to the account",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "register user account",
+                "operationId": "register",
+                "parameters": [
+                    {
+                        "description": "account credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.credentialsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.accountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/urls/{id}": {
+            "patch": {
+                "description": "change the long URL of the short URL or move it to another workspace, requires the editor role",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "update short URL",
+                "operationId": "updateURL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "short URL id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "short URL changes",
+                        "name": "changes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.updateURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.workspaceURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/webhooks": {
+            "get": {
+                "description": "get webhook endpoints of the user, secrets are not returned",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "get webhooks of the user",
+                "operationId": "webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.webhookResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "register the webhook endpoint for the event types (url.created, url.deleted, url.clicked),\npayloads are signed with HMAC-SHA256 of the returned secret in the X-Shorturl-Signature header",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "create webhook",
+                "operationId": "createWebhook",
+                "parameters": [
+                    {
+                        "description": "webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.webhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.webhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/webhooks/dead-letters": {
+            "get": {
+                "description": "get deliveries to the webhooks of the user that exhausted their retry attempts",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "get failed webhook deliveries",
+                "operationId": "deadLetters",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.deadLetterResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/webhooks/{webhookID}": {
+            "delete": {
+                "description": "remove the webhook endpoint of the user",
+                "summary": "delete webhook",
+                "operationId": "deleteWebhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/workspaces": {
+            "get": {
+                "description": "get workspaces the user is a member of with the user role",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "get workspaces of the user",
+                "operationId": "workspaces",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.workspaceResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create workspace administered by the user",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "create workspace",
+                "operationId": "createWorkspace",
+                "parameters": [
+                    {
+                        "description": "workspace",
+                        "name": "workspace",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.workspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.workspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/workspaces/{workspaceID}/members/{memberID}": {
+            "put": {
+                "description": "add the workspace member or change the member role, requires the admin role",
+                "summary": "set workspace member",
+                "operationId": "setMember",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "workspace id",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "member user id",
+                        "name": "memberID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "member role",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.memberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove the workspace member, requires the admin role",
+                "summary": "delete workspace member",
+                "operationId": "deleteMember",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "workspace id",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "member user id",
+                        "name": "memberID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/workspaces/{workspaceID}/urls": {
+            "get": {
+                "description": "get short URLs of the workspace, requires the viewer role",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "get short URLs of the workspace",
+                "operationId": "workspaceURL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "workspace id",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.workspaceURLResponse"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "health status of the shortener storage with the active storage driver and the leadership of the replica,\nthe replica is always the leader when the leader election is not used",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "health status of the replica",
+                "operationId": "health",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.healthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.healthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internal/metrics": {
+            "get": {
+                "description": "published expvar variables, the jobs variable holds the task queue depth\nand the counters of processed, failed, retried and dead tasks and of job runs",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "runtime and background jobs metrics",
+                "operationId": "metrics",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internal/stats": {
+            "get": {
+                "description": "shorturl statistics",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "shorturl statistics",
+                "operationId": "stats",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ping": {
+            "get": {
+                "description": "health check shortener storage",
+                "summary": "health check shortener storage",
+                "operationId": "ping",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{id}": {
+            "post": {
+                "description": "get short URL",
+                "produces": [
+                    "text/plain"
+                ],
+                "summary": "get short URL",
+                "operationId": "getURL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "short URL id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "451": {
+                        "description": "Unavailable For Legal Reasons",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{id}/report": {
+            "post": {
+                "description": "report the short URL abuse, the short URL is disabled when the report threshold is crossed",
+                "summary": "report short URL",
+                "operationId": "reportURL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "short URL id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "report reason",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.reportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/http.errResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "http.accountResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.adminURLResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "original_url": {
+                    "type": "string"
+                },
+                "short_url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.auditResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "transport": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.batchURLRequest": {
+            "type": "object",
+            "properties": {
+                "correlation_id": {
+                    "type": "string"
+                },
+                "original_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.batchURLResponse": {
+            "type": "object",
+            "properties": {
+                "correlation_id": {
+                    "type": "string"
+                },
+                "short_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.credentialsRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.deadLetterResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.deleteURLResponse": {
+            "type": "object",
+            "properties": {
+                "job_id": {
+                    "type": "string"
                 }
             }
         },
@@ -420,6 +1910,130 @@ const docTemplate = `{
                 }
             }
         },
+        "http.healthResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "type": "string"
+                },
+                "election": {
+                    "type": "boolean"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "leader": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "storage": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.jobResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.jobURLResponse"
+                    }
+                }
+            }
+        },
+        "http.jobURLResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "short_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.memberRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.promoteStorageResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.reportRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.reportResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "flagged": {
+                    "type": "boolean"
+                },
+                "last_reported_at": {
+                    "type": "string"
+                },
+                "original_url": {
+                    "type": "string"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "short_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.resolveRequest": {
+            "type": "object",
+            "properties": {
+                "disable": {
+                    "type": "boolean"
+                }
+            }
+        },
         "http.shortURLRequest": {
             "type": "object",
             "properties": {
@@ -436,6 +2050,17 @@ const docTemplate = `{
                 }
             }
         },
+        "http.updateURLRequest": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
         "http.userURLResponse": {
             "type": "object",
             "properties": {
@@ -446,6 +2071,76 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "http.webhookRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.webhookResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.workspaceRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.workspaceResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.workspaceURLResponse": {
+            "type": "object",
+            "properties": {
+                "original_url": {
+                    "type": "string"
+                },
+                "short_url": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
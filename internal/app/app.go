@@ -12,9 +12,21 @@ import (
 	"github.com/sreway/shorturl/internal/config"
 	"github.com/sreway/shorturl/internal/delivery/grpc"
 	"github.com/sreway/shorturl/internal/delivery/http"
+	"github.com/sreway/shorturl/internal/domain/task"
+	_ "github.com/sreway/shorturl/internal/repository/storage/bolt"
 	"github.com/sreway/shorturl/internal/repository/storage/cache"
-	"github.com/sreway/shorturl/internal/repository/storage/postgres"
+	"github.com/sreway/shorturl/internal/repository/storage/driver"
+	"github.com/sreway/shorturl/internal/repository/storage/filter"
+	"github.com/sreway/shorturl/internal/repository/storage/lru"
+	_ "github.com/sreway/shorturl/internal/repository/storage/postgres"
+	_ "github.com/sreway/shorturl/internal/repository/storage/sqlite"
+	"github.com/sreway/shorturl/internal/usecases"
 	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+	"github.com/sreway/shorturl/internal/usecases/election"
+	"github.com/sreway/shorturl/internal/usecases/invalidation"
+	"github.com/sreway/shorturl/internal/usecases/notifier"
+	"github.com/sreway/shorturl/internal/usecases/relay"
+	"github.com/sreway/shorturl/internal/usecases/scheduler"
 	"github.com/sreway/shorturl/internal/usecases/shortener"
 )
 
@@ -46,10 +58,10 @@ func Run(ctx context.Context) {
 
 		var (
 			cfg            config.Config
-			configCache    config.Cache
-			configPostgres config.Postgres
 			configShortURL config.ShortURL
 			repo           storage.URL
+			queueRepo      storage.Queue
+			leader         usecases.Leader
 		)
 
 		cfg, err := config.NewConfig()
@@ -60,40 +72,198 @@ func Run(ctx context.Context) {
 			return
 		}
 
-		configCache = cfg.GetStorage().GetCache()
-		configPostgres = cfg.GetStorage().GetPostgres()
 		configShortURL = cfg.GetShortURL()
+		configStorage := cfg.GetStorage()
 
-		switch {
-		case len(configPostgres.GetDSN()) > 0:
-			repo, err = postgres.New(ctx, configPostgres)
+		var driverName string
+		for _, name := range driver.Candidates(configStorage) {
+			repo, err = driver.Open(ctx, name, configStorage)
 			if err == nil {
-				log.Info("use postgres repository")
+				driverName = name
 				break
 			}
-			log.Error("failed initialize postgres repository", err)
-			fallthrough
-		case len(configCache.GetFilePath()) > 0:
-			repo = cache.New(cache.File(configCache.GetFilePath()))
-			log.Info("use cache repository with specific file")
-		default:
-			repo = cache.New()
-			log.Info("use default cache repository")
+			log.Error("failed initialize repository", err, slog.String("driver", name))
+			if configStorage.GetStrict() {
+				stop()
+				exit <- 1
+				return
+			}
+		}
+
+		if repo == nil {
+			driverName = cache.DriverMemory
+			repo, err = driver.Open(ctx, driverName, configStorage)
+			if err != nil {
+				log.Error("failed initialize repository", err, slog.String("driver", driverName))
+				stop()
+				exit <- 1
+				return
+			}
+		}
+		log.Info("use repository", slog.String("driver", driverName))
+
+		urls := repo
+		if configDualWrite := configStorage.GetDualWrite(); len(configDualWrite.GetSecondaryURL()) > 0 {
+			var secondaryName string
+			urls, secondaryName, err = openDualWrite(ctx, repo, driverName, configDualWrite)
+			if err == nil {
+				log.Info("use dual-write repository", slog.String("primary", driverName),
+					slog.String("secondary", secondaryName))
+			} else {
+				log.Error("failed initialize dual-write repository", err)
+				if configStorage.GetStrict() {
+					_ = repo.Close()
+					stop()
+					exit <- 1
+					return
+				}
+				urls = repo
+			}
 		}
 
+		closer := urls
 		defer func() {
-			err = repo.Close()
+			err = closer.Close()
 			if err != nil {
 				log.Error("failed close url repository", err)
 			}
 		}()
 
-		service := shortener.New(repo, configShortURL)
+		opts := []shortener.Option{shortener.StorageDriver(driverName)}
+		if switchover, ok := urls.(storage.Switchover); ok {
+			opts = append(opts, shortener.Switchover(switchover))
+		}
+		bound := newStores(repo, urls)
+		if accounts, ok := bound.Accounts(); ok {
+			opts = append(opts, shortener.Accounts(accounts))
+		}
+		if workspaces, ok := bound.Workspaces(); ok {
+			opts = append(opts, shortener.Workspaces(workspaces))
+		}
+		if reports, ok := bound.Reports(); ok {
+			opts = append(opts, shortener.Reports(reports))
+		}
+		if queue, ok := bound.Queue(); ok {
+			queueRepo = queue
+			opts = append(opts, shortener.Queue(queue))
+		}
+		if events, ok := bound.Audit(); ok {
+			opts = append(opts, shortener.Audit(events))
+		}
+		if webhooks, ok := bound.Webhooks(); ok {
+			n := notifier.New(webhooks, cfg.GetWebhook())
+			opts = append(opts, shortener.Webhooks(webhooks), shortener.Notifier(n))
+
+			go func() {
+				if err := n.Run(ctx); err != nil {
+					log.Error("failed run webhook notifier", err)
+				}
+			}()
+		}
+
+		if locker, ok := bound.Locker(); ok {
+			e := election.New(locker, cfg.GetLeader())
+			leader = e
+			opts = append(opts, shortener.Leader(e))
+
+			electionDone := make(chan struct{})
+			defer func() {
+				<-electionDone
+			}()
+
+			go func() {
+				defer close(electionDone)
+				if err := e.Run(ctx); err != nil {
+					log.Error("failed run leader election", err)
+				}
+			}()
+		}
+
+		var evicters []usecases.Evicter
+		invalidations, listened := bound.Invalidation()
+		configURLFilter := cfg.GetStorage().GetURLFilter()
+		scanner, scanned := bound.IDScanner()
+		if configURLFilter.GetExpectedItems() > 0 && !listened {
+			// the IDs created by other replicas would be rejected until the next rebuild
+			log.Warn("unknown url filter is not used, the storage does not publish invalidations")
+		}
+		if scanned && listened && configURLFilter.GetExpectedItems() > 0 {
+			urlFilter := filter.New(urls, scanner, configURLFilter)
+			if err = urlFilter.Build(ctx); err != nil {
+				log.Error("failed build url filter", err)
+			}
+			urls = urlFilter
+			evicters = append(evicters, urlFilter)
+			log.Info("use unknown url filter", slog.Int("expected_items", configURLFilter.GetExpectedItems()))
+		}
+
+		if configURLCache := cfg.GetStorage().GetURLCache(); configURLCache.GetSize() > 0 {
+			urlCache := lru.New(urls, configURLCache)
+			urls = urlCache
+			evicters = append(evicters, urlCache)
+			log.Info("use read-through url cache", slog.Int("size", configURLCache.GetSize()))
+		}
+
+		if listened {
+			l := invalidation.New(invalidations, evicters...)
+			go func() {
+				if err := l.Run(ctx); err != nil {
+					log.Error("failed run invalidation listener", err)
+				}
+			}()
+		}
+
+		outboxRepo, useOutbox := bound.Outbox()
+		if useOutbox {
+			opts = append(opts, shortener.OutboxEvents())
+		}
+
+		service := shortener.New(urls, configShortURL, opts...)
+
+		var purgeOutbox scheduler.Job
+		if useOutbox {
+			r := relay.New(outboxRepo, cfg.GetOutbox(), service)
+			purgeOutbox = r.Purge
+			go func() {
+				if err := r.Run(ctx); err != nil {
+					log.Error("failed run outbox relay", err)
+				}
+			}()
+		}
+
+		sched := scheduler.New(queueRepo, configShortURL, leader)
+		sched.Handle(task.ActionDelete, service.DeleteTask)
+		if len(configShortURL.GetTaskPurgeSchedule()) > 0 {
+			purgeSchedule, err := scheduler.ParseSchedule(configShortURL.GetTaskPurgeSchedule())
+			if err != nil {
+				log.Error("failed parse task purge schedule", err)
+				stop()
+				exit <- 1
+				return
+			}
+			sched.Schedule("purge_tasks", purgeSchedule, service.PurgeTasks, scheduler.Singleton())
+		}
+		if purgeOutbox != nil && len(cfg.GetOutbox().GetPurgeSchedule()) > 0 {
+			purgeSchedule, err := scheduler.ParseSchedule(cfg.GetOutbox().GetPurgeSchedule())
+			if err != nil {
+				log.Error("failed parse outbox purge schedule", err)
+				stop()
+				exit <- 1
+				return
+			}
+			sched.Schedule("purge_outbox", purgeSchedule, purgeOutbox, scheduler.Singleton())
+		}
+
+		schedDone := make(chan struct{})
+		defer func() {
+			<-schedDone
+		}()
 
 		go func() {
-			err = service.ProcQueue(ctx, cfg.GetShortURL().GetCheckTaskInterval())
+			defer close(schedDone)
+			err := sched.Run(ctx)
 			if err != nil {
-				log.Error("failed processed task queue", err)
+				log.Error("failed run scheduler", err)
 				stop()
 				exit <- 1
 				return
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/usecases/migration"
+)
+
+// MigrateData copies short URLs between the storages set by the URLs, the process exits with the non-zero code
+// when the migration fails.
+func MigrateData(ctx context.Context, from, to string, batchSize int) {
+	var code int
+
+	log := slog.New(slog.NewJSONHandler(os.Stdout).
+		WithAttrs([]slog.Attr{slog.String("application", "shorturl")}))
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer func() {
+		stop()
+		if code != 0 {
+			os.Exit(code)
+		}
+	}()
+
+	code = migrateData(ctx, log, from, to, batchSize)
+}
+
+// migrateData implements copying short URLs between the storages and returns the exit code,
+// the storages are closed before returning.
+func migrateData(ctx context.Context, log *slog.Logger, from, to string, batchSize int) int {
+	if len(from) == 0 || len(to) == 0 || from == to {
+		log.Error("failed migrate data", config.ErrStorageURL, slog.String("from", from), slog.String("to", to))
+		return 1
+	}
+
+	src, _, err := openStorage(ctx, from)
+	if err != nil {
+		log.Error("failed initialize source repository", err)
+		return 1
+	}
+	defer func() {
+		if err = src.Close(); err != nil {
+			log.Error("failed close source repository", err)
+		}
+	}()
+
+	dst, _, err := openStorage(ctx, to)
+	if err != nil {
+		log.Error("failed initialize destination repository", err)
+		return 1
+	}
+	defer func() {
+		if err = dst.Close(); err != nil {
+			log.Error("failed close destination repository", err)
+		}
+	}()
+
+	m, err := migration.New(src, dst, batchSize)
+	if err != nil {
+		log.Error("failed initialize migration", err)
+		return 1
+	}
+
+	result, err := m.Run(ctx)
+	attrs := []any{
+		slog.Int("scanned", result.Scanned), slog.Int("copied", result.Copied),
+		slog.Int("unchanged", result.Unchanged), slog.Int("failed", result.Failed),
+		slog.Int("mismatched", result.Mismatched),
+	}
+	if err != nil {
+		log.Error("failed migrate data", err, attrs...)
+		return 1
+	}
+
+	log.Info("success migrate data", attrs...)
+	return 0
+}
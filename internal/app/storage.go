@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/repository/storage/driver"
+	"github.com/sreway/shorturl/internal/repository/storage/dual"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// openStorage implements the creation of the storage by the driver selected by the URL.
+func openStorage(ctx context.Context, rawURL string) (storage.URL, string, error) {
+	cfg, err := config.NewStorageConfig(rawURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s, err := driver.Open(ctx, cfg.GetDriver(), cfg)
+	if err != nil {
+		return nil, "", err
+	}
+	return s, cfg.GetDriver(), nil
+}
+
+// openDualWrite implements the creation of the storage writing short URLs to the primary storage
+// and the secondary storage set by the URL.
+func openDualWrite(ctx context.Context, primary storage.URL, primaryName string,
+	cfg config.DualWrite,
+) (storage.URL, string, error) {
+	secondary, secondaryName, err := openStorage(ctx, cfg.GetSecondaryURL())
+	if err != nil {
+		return nil, "", err
+	}
+
+	s, err := dual.New(primary, primaryName, secondary, secondaryName, cfg)
+	if err != nil {
+		_ = secondary.Close()
+		return nil, "", err
+	}
+	return s, secondaryName, nil
+}
+
+// stores describes the storage providing the stores other than short URLs, false is returned
+// for the store that is not provided.
+type stores interface {
+	Accounts() (storage.Account, bool)
+	Workspaces() (storage.Workspace, bool)
+	Reports() (storage.Report, bool)
+	Audit() (storage.Audit, bool)
+	Webhooks() (storage.Webhook, bool)
+	Outbox() (storage.Outbox, bool)
+	Queue() (storage.Queue, bool)
+	Locker() (storage.Locker, bool)
+	Invalidation() (storage.Invalidation, bool)
+	IDScanner() (storage.IDScanner, bool)
+}
+
+// storageStores implements the stores implemented by the single storage.
+type storageStores struct {
+	repo storage.URL
+}
+
+// newStores implements getting the stores of the storage, the dual-write storage provides the stores
+// kept by its storages.
+func newStores(repo, urls storage.URL) stores {
+	if s, ok := urls.(stores); ok {
+		return s
+	}
+	return storageStores{repo: repo}
+}
+
+// Accounts implements getting the accounts storage.
+func (s storageStores) Accounts() (storage.Account, bool) {
+	v, ok := s.repo.(storage.Account)
+	return v, ok
+}
+
+// Workspaces implements getting the workspaces storage.
+func (s storageStores) Workspaces() (storage.Workspace, bool) {
+	v, ok := s.repo.(storage.Workspace)
+	return v, ok
+}
+
+// Reports implements getting the abuse reports storage.
+func (s storageStores) Reports() (storage.Report, bool) {
+	v, ok := s.repo.(storage.Report)
+	return v, ok
+}
+
+// Audit implements getting the audit log storage.
+func (s storageStores) Audit() (storage.Audit, bool) {
+	v, ok := s.repo.(storage.Audit)
+	return v, ok
+}
+
+// Webhooks implements getting the webhooks storage.
+func (s storageStores) Webhooks() (storage.Webhook, bool) {
+	v, ok := s.repo.(storage.Webhook)
+	return v, ok
+}
+
+// Outbox implements getting the outbox storage.
+func (s storageStores) Outbox() (storage.Outbox, bool) {
+	v, ok := s.repo.(storage.Outbox)
+	return v, ok
+}
+
+// Queue implements getting the deferred tasks storage.
+func (s storageStores) Queue() (storage.Queue, bool) {
+	v, ok := s.repo.(storage.Queue)
+	return v, ok
+}
+
+// Locker implements getting the session locks storage.
+func (s storageStores) Locker() (storage.Locker, bool) {
+	v, ok := s.repo.(storage.Locker)
+	return v, ok
+}
+
+// Invalidation implements getting the storage publishing the changes of short URLs.
+func (s storageStores) Invalidation() (storage.Invalidation, bool) {
+	v, ok := s.repo.(storage.Invalidation)
+	return v, ok
+}
+
+// IDScanner implements getting the storage listing the IDs of the short URLs.
+func (s storageStores) IDScanner() (storage.IDScanner, bool) {
+	v, ok := s.repo.(storage.IDScanner)
+	return v, ok
+}
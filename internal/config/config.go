@@ -7,6 +7,8 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v7"
@@ -18,6 +20,9 @@ type Config interface {
 	GetShortURL() *shortURL
 	GetStorage() *storage
 	GetGRPC() *grpc
+	GetWebhook() *webhook
+	GetOutbox() *outbox
+	GetLeader() *leader
 }
 
 // HTTP describes the implementation of the http server configuration.
@@ -30,6 +35,17 @@ type HTTP interface {
 	GetSwagger() *swagger
 	GetTLS() *tls
 	GetTrustedSubnet() *net.IPNet
+	GetOIDC() *oidc
+}
+
+// OIDC describes the implementation of the OpenID Connect single sign-on configuration.
+type OIDC interface {
+	Enabled() bool
+	GetIssuer() string
+	GetClientID() string
+	GetClientSecret() string
+	GetRedirectURL() string
+	GetScopes() []string
 }
 
 // GRPC describes the implementation of the grpc server configuration.
@@ -38,6 +54,7 @@ type GRPC interface {
 	UseTLS() bool
 	Enabled() bool
 	GetAddress() string
+	GetTrustedSubnet() *net.IPNet
 }
 
 // ShortURL describes the implementation of the URL shortening service configuration.
@@ -45,12 +62,63 @@ type ShortURL interface {
 	GetBaseURL() *url.URL
 	GetCheckTaskInterval() time.Duration
 	GetMaxTaskQueue() int
+	GetReportThreshold() int
+	GetTaskMaxAttempts() int
+	GetTaskBackoff() time.Duration
+	GetTaskMaxBackoff() time.Duration
+	GetTaskDrainTimeout() time.Duration
+	GetTaskWorkers() int
+	GetTaskRetention() time.Duration
+	GetTaskPurgeSchedule() string
+}
+
+// TaskQueue describes the implementation of the deferred tasks processing configuration.
+type TaskQueue interface {
+	GetCheckTaskInterval() time.Duration
+	GetMaxTaskQueue() int
+	GetTaskWorkers() int
+	GetTaskMaxAttempts() int
+	GetTaskBackoff() time.Duration
+	GetTaskMaxBackoff() time.Duration
+	GetTaskDrainTimeout() time.Duration
+}
+
+// Webhook describes the implementation of the outbound webhooks delivery configuration.
+type Webhook interface {
+	GetWorkers() int
+	GetQueueSize() int
+	GetTimeout() time.Duration
+	GetMaxAttempts() int
+	GetBackoff() time.Duration
+	GetMaxBackoff() time.Duration
+	GetAllowPrivate() bool
+}
+
+// Outbox describes the implementation of the transactional outbox relay configuration.
+type Outbox interface {
+	GetPollInterval() time.Duration
+	GetBatchSize() int
+	GetRetention() time.Duration
+	GetPurgeSchedule() string
+}
+
+// Leader describes the implementation of the leader election configuration.
+type Leader interface {
+	GetLockKey() int64
+	GetCheckInterval() time.Duration
 }
 
 // Storage describes the implementation of the application storage configuration.
 type Storage interface {
+	GetDriver() string
+	GetStrict() bool
 	GetPostgres() *postgres
+	GetBolt() *bolt
+	GetSQLite() *sqlite
 	GetCache() *cache
+	GetURLCache() *urlCache
+	GetURLFilter() *urlFilter
+	GetDualWrite() *dualWrite
 }
 
 // Postgres describes the implementation of the PostgreSQL storage configuration.
@@ -59,9 +127,49 @@ type Postgres interface {
 	GetMigrateURL() string
 }
 
+// SQLite describes the implementation of the SQLite storage configuration.
+type SQLite interface {
+	GetDSN() string
+	GetMigrateURL() string
+}
+
+// Bolt describes the implementation of the embedded bbolt storage configuration.
+type Bolt interface {
+	GetPath() string
+	GetTimeout() time.Duration
+}
+
+// URLCache describes the implementation of the read-through short URLs cache configuration.
+type URLCache interface {
+	GetSize() int
+	GetTTL() time.Duration
+}
+
+// DualWrite describes the implementation of the dual-write configuration, short URLs are written to the storage
+// set by the secondary URL as well when it is set.
+type DualWrite interface {
+	GetSecondaryURL() string
+	GetShadowReads() bool
+	GetShadowTimeout() time.Duration
+	GetShadowConcurrency() int
+}
+
+// URLFilter describes the implementation of the unknown short URLs filter configuration.
+type URLFilter interface {
+	GetExpectedItems() int
+	GetFalsePositiveRate() float64
+	GetNegativeCacheSize() int
+	GetNegativeCacheTTL() time.Duration
+}
+
 // Cache describes the implementation of the in-memory storage configuration.
 type Cache interface {
 	GetFilePath() string
+	GetFileFormat() string
+	GetJournalPath() string
+	GetCompactInterval() time.Duration
+	GetAuditFilePath() string
+	GetTaskJournalPath() string
 }
 
 // Swagger describes the implementation of th Swagger configuration.
@@ -79,6 +187,9 @@ type config struct {
 	GRPC     *grpc     `json:"grpc"`
 	ShortURL *shortURL `json:"short_url"`
 	Storage  *storage  `json:"storage"`
+	Webhook  *webhook  `json:"webhook"`
+	Outbox   *outbox   `json:"outbox"`
+	Leader   *leader   `json:"leader"`
 }
 
 // http implements http server configuration.
@@ -92,14 +203,16 @@ type http struct {
 	TLS           *tls     `json:"tls"`
 	Swagger       *swagger `json:"swagger"`
 	TrustedSubnet *subnet  `json:"trusted_subnet" env:"TRUSTED_SUBNET"`
+	OIDC          *oidc    `json:"oidc"`
 }
 
 // grpc implements grpc server configuration.
 type grpc struct {
-	Enable    bool   `json:"enable"`
-	Address   string `json:"server_address" env:"SERVER_ADDRESS"`
-	EnableTLS bool   `json:"enable_tls"`
-	TLS       *tls   `json:"tls"`
+	Enable        bool    `json:"enable"`
+	Address       string  `json:"server_address" env:"SERVER_ADDRESS"`
+	EnableTLS     bool    `json:"enable_tls"`
+	TLS           *tls    `json:"tls"`
+	TrustedSubnet *subnet `json:"trusted_subnet" env:"TRUSTED_SUBNET"`
 }
 
 // subnet describes ip subnet type.
@@ -124,8 +237,25 @@ func (s *subnet) UnmarshalText(text []byte) error {
 
 // cookie implements http server cookies configuration.
 type cookie struct {
-	SignID    string `json:"sign_id" env:"COOKIE_SIGN_ID"`
-	SecretKey string `json:"secret_key" env:"COOKIE_SECRET_KEY"`
+	SignID     string        `json:"sign_id" env:"COOKIE_SIGN_ID"`
+	SecretKey  string        `json:"secret_key" env:"COOKIE_SECRET_KEY"`
+	SecretKeys []string      `json:"secret_keys" env:"COOKIE_SECRET_KEYS" envSeparator:","`
+	Secure     bool          `json:"secure" env:"COOKIE_SECURE"`
+	HTTPOnly   bool          `json:"http_only" env:"COOKIE_HTTP_ONLY"`
+	SameSite   string        `json:"same_site" env:"COOKIE_SAME_SITE"`
+	MaxAge     time.Duration `json:"max_age" env:"COOKIE_MAX_AGE"`
+
+	SessionName   string        `json:"session_name" env:"COOKIE_SESSION_NAME"`
+	SessionMaxAge time.Duration `json:"session_max_age" env:"COOKIE_SESSION_MAX_AGE"`
+}
+
+// oidc implements OpenID Connect single sign-on configuration.
+type oidc struct {
+	Issuer       string   `json:"issuer" env:"OIDC_ISSUER"`
+	ClientID     string   `json:"client_id" env:"OIDC_CLIENT_ID"`
+	ClientSecret string   `json:"client_secret" env:"OIDC_CLIENT_SECRET"`
+	RedirectURL  string   `json:"redirect_url" env:"OIDC_REDIRECT_URL"`
+	Scopes       []string `json:"scopes" env:"OIDC_SCOPES" envSeparator:","`
 }
 
 // tls implements http/grpc server tls configuration.
@@ -139,17 +269,96 @@ type shortURL struct {
 	BaseURL           *url.URL      `json:"base_url" env:"BASE_URL"`
 	CheckTaskInterval time.Duration `json:"check_task_interval" env:"CHECK_TASK_INTERVAL"`
 	MaxTaskQueue      int           `json:"max_task_queue" env:"MAX_TASK_QUEUE"`
+	ReportThreshold   int           `json:"report_threshold" env:"REPORT_THRESHOLD"`
+	TaskMaxAttempts   int           `json:"task_max_attempts" env:"TASK_MAX_ATTEMPTS"`
+	TaskBackoff       time.Duration `json:"task_backoff" env:"TASK_BACKOFF"`
+	TaskMaxBackoff    time.Duration `json:"task_max_backoff" env:"TASK_MAX_BACKOFF"`
+	TaskDrainTimeout  time.Duration `json:"task_drain_timeout" env:"TASK_DRAIN_TIMEOUT"`
+	TaskWorkers       int           `json:"task_workers" env:"TASK_WORKERS"`
+	TaskRetention     time.Duration `json:"task_retention" env:"TASK_RETENTION"`
+	TaskPurgeSchedule string        `json:"task_purge_schedule" env:"TASK_PURGE_SCHEDULE"`
+}
+
+// webhook implements outbound webhooks delivery configuration.
+type webhook struct {
+	Workers      int           `json:"workers" env:"WEBHOOK_WORKERS"`
+	QueueSize    int           `json:"queue_size" env:"WEBHOOK_QUEUE_SIZE"`
+	Timeout      time.Duration `json:"timeout" env:"WEBHOOK_TIMEOUT"`
+	MaxAttempts  int           `json:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+	Backoff      time.Duration `json:"backoff" env:"WEBHOOK_BACKOFF"`
+	MaxBackoff   time.Duration `json:"max_backoff" env:"WEBHOOK_MAX_BACKOFF"`
+	AllowPrivate bool          `json:"allow_private" env:"WEBHOOK_ALLOW_PRIVATE"`
+}
+
+// outbox implements transactional outbox relay configuration.
+type outbox struct {
+	PollInterval  time.Duration `json:"poll_interval" env:"OUTBOX_POLL_INTERVAL"`
+	BatchSize     int           `json:"batch_size" env:"OUTBOX_BATCH_SIZE"`
+	Retention     time.Duration `json:"retention" env:"OUTBOX_RETENTION"`
+	PurgeSchedule string        `json:"purge_schedule" env:"OUTBOX_PURGE_SCHEDULE"`
+}
+
+// leader implements leader election configuration.
+type leader struct {
+	LockKey       int64         `json:"lock_key" env:"LEADER_LOCK_KEY"`
+	CheckInterval time.Duration `json:"check_interval" env:"LEADER_CHECK_INTERVAL"`
 }
 
 // storage implements storage configuration.
 type storage struct {
-	Cache    *cache    `json:"cache"`
-	Postgres *postgres `json:"postgres"`
+	Driver    string     `json:"driver" env:"STORAGE_DRIVER"`
+	Strict    bool       `json:"strict" env:"STORAGE_STRICT"`
+	Cache     *cache     `json:"cache"`
+	Postgres  *postgres  `json:"postgres"`
+	Bolt      *bolt      `json:"bolt"`
+	SQLite    *sqlite    `json:"sqlite"`
+	URLCache  *urlCache  `json:"url_cache"`
+	URLFilter *urlFilter `json:"url_filter"`
+	DualWrite *dualWrite `json:"dual_write"`
+}
+
+// dualWrite implements writing short URLs to the primary and the secondary storage configuration.
+type dualWrite struct {
+	SecondaryURL      string        `json:"secondary_url" env:"STORAGE_SECONDARY_URL"`
+	ShadowReads       bool          `json:"shadow_reads" env:"STORAGE_SHADOW_READS"`
+	ShadowTimeout     time.Duration `json:"shadow_timeout" env:"STORAGE_SHADOW_TIMEOUT"`
+	ShadowConcurrency int           `json:"shadow_concurrency" env:"STORAGE_SHADOW_CONCURRENCY"`
+}
+
+// urlCache implements read-through short URLs cache configuration.
+type urlCache struct {
+	Size int           `json:"size" env:"URL_CACHE_SIZE"`
+	TTL  time.Duration `json:"ttl" env:"URL_CACHE_TTL"`
+}
+
+// urlFilter implements unknown short URLs filter configuration.
+type urlFilter struct {
+	ExpectedItems     int           `json:"expected_items" env:"URL_FILTER_EXPECTED_ITEMS"`
+	FalsePositiveRate float64       `json:"false_positive_rate" env:"URL_FILTER_FALSE_POSITIVE_RATE"`
+	NegativeCacheSize int           `json:"negative_cache_size" env:"URL_FILTER_NEGATIVE_CACHE_SIZE"`
+	NegativeCacheTTL  time.Duration `json:"negative_cache_ttl" env:"URL_FILTER_NEGATIVE_CACHE_TTL"`
 }
 
 // cache implements in-memory storage configuration.
 type cache struct {
-	FilePath string `json:"file_path" env:"FILE_STORAGE_PATH"`
+	FilePath        string        `json:"file_path" env:"FILE_STORAGE_PATH"`
+	FileFormat      string        `json:"file_format" env:"FILE_STORAGE_FORMAT"`
+	JournalPath     string        `json:"journal_path" env:"FILE_STORAGE_JOURNAL_PATH"`
+	CompactInterval time.Duration `json:"compact_interval" env:"FILE_STORAGE_COMPACT_INTERVAL"`
+	AuditFilePath   string        `json:"audit_file_path" env:"AUDIT_FILE_PATH"`
+	TaskJournalPath string        `json:"task_journal_path" env:"TASK_JOURNAL_PATH"`
+}
+
+// sqlite implements SQLite storage configuration.
+type sqlite struct {
+	DSN        string `json:"dsn" env:"SQLITE_DSN"`
+	MigrateURL string `json:"migrate_url" env:"SQLITE_MIGRATE_URL"`
+}
+
+// bolt implements embedded bbolt storage configuration.
+type bolt struct {
+	Path    string        `json:"path" env:"BOLT_STORAGE_PATH"`
+	Timeout time.Duration `json:"timeout" env:"BOLT_STORAGE_TIMEOUT"`
 }
 
 // postgres implements postgres configuration.
@@ -187,6 +396,21 @@ func (c *config) GetGRPC() *grpc {
 	return c.GRPC
 }
 
+// GetWebhook implements getting outbound webhooks delivery configuration.
+func (c *config) GetWebhook() *webhook {
+	return c.Webhook
+}
+
+// GetOutbox implements getting transactional outbox relay configuration.
+func (c *config) GetOutbox() *outbox {
+	return c.Outbox
+}
+
+// GetLeader implements getting leader election configuration.
+func (c *config) GetLeader() *leader {
+	return c.Leader
+}
+
 // GetScheme implements getting http server scheme (http/https).
 func (h *http) GetScheme() string {
 	return h.Scheme
@@ -227,6 +451,50 @@ func (h *http) GetTrustedSubnet() *net.IPNet {
 	return (*net.IPNet)(h.TrustedSubnet)
 }
 
+// GetSecretKeys implements getting cookie secret keys ordered from the newest to the oldest.
+// The single secret key is used when the key list is not set.
+func (c *cookie) GetSecretKeys() []string {
+	if len(c.SecretKeys) > 0 {
+		return c.SecretKeys
+	}
+	return []string{c.SecretKey}
+}
+
+// GetOIDC implements getting OpenID Connect single sign-on configuration.
+func (h *http) GetOIDC() *oidc {
+	return h.OIDC
+}
+
+// Enabled implements getting information about the need to use OpenID Connect single sign-on.
+func (o *oidc) Enabled() bool {
+	return len(o.Issuer) > 0
+}
+
+// GetIssuer implements getting OpenID Connect issuer URL.
+func (o *oidc) GetIssuer() string {
+	return o.Issuer
+}
+
+// GetClientID implements getting OpenID Connect client ID.
+func (o *oidc) GetClientID() string {
+	return o.ClientID
+}
+
+// GetClientSecret implements getting OpenID Connect client secret.
+func (o *oidc) GetClientSecret() string {
+	return o.ClientSecret
+}
+
+// GetRedirectURL implements getting OpenID Connect redirect URL.
+func (o *oidc) GetRedirectURL() string {
+	return o.RedirectURL
+}
+
+// GetScopes implements getting OpenID Connect scopes.
+func (o *oidc) GetScopes() []string {
+	return o.Scopes
+}
+
 // GetBaseURL implements getting the base URL for the URL shortening service.
 func (s *shortURL) GetBaseURL() *url.URL {
 	return s.BaseURL
@@ -242,11 +510,234 @@ func (s *shortURL) GetCheckTaskInterval() time.Duration {
 	return s.CheckTaskInterval
 }
 
+// GetReportThreshold implements getting the number of open abuse reports disabling the short URL
+// until the moderator review, zero value turns automatic disabling off.
+func (s *shortURL) GetReportThreshold() int {
+	return s.ReportThreshold
+}
+
+// GetTaskMaxAttempts implements getting the number of attempts before the deferred task is moved to the dead state.
+func (s *shortURL) GetTaskMaxAttempts() int {
+	return s.TaskMaxAttempts
+}
+
+// GetTaskBackoff implements getting the delay before the first retry of the deferred task,
+// the delay doubles with every retry.
+func (s *shortURL) GetTaskBackoff() time.Duration {
+	return s.TaskBackoff
+}
+
+// GetTaskMaxBackoff implements getting the maximum delay between retries of the deferred task.
+func (s *shortURL) GetTaskMaxBackoff() time.Duration {
+	return s.TaskMaxBackoff
+}
+
+// GetTaskDrainTimeout implements getting the time given to processing due deferred tasks on shutdown.
+func (s *shortURL) GetTaskDrainTimeout() time.Duration {
+	return s.TaskDrainTimeout
+}
+
+// GetTaskWorkers implements getting the default number of the deferred tasks of one action processed at once.
+func (s *shortURL) GetTaskWorkers() int {
+	return s.TaskWorkers
+}
+
+// GetTaskRetention implements getting the time the processed deferred task is kept for the job status,
+// zero value keeps processed tasks forever.
+func (s *shortURL) GetTaskRetention() time.Duration {
+	return s.TaskRetention
+}
+
+// GetTaskPurgeSchedule implements getting the schedule of purging the processed deferred tasks,
+// either the cron expression or the interval (@every 1h).
+func (s *shortURL) GetTaskPurgeSchedule() string {
+	return s.TaskPurgeSchedule
+}
+
+// GetWorkers implements getting the number of concurrent webhook deliveries.
+func (w *webhook) GetWorkers() int {
+	return w.Workers
+}
+
+// GetQueueSize implements getting the number of events waiting for delivery, lifecycle events
+// and clicks are queued apart with the size each, events published to the full queue are dropped.
+func (w *webhook) GetQueueSize() int {
+	return w.QueueSize
+}
+
+// GetTimeout implements getting the timeout of the single webhook delivery attempt.
+func (w *webhook) GetTimeout() time.Duration {
+	return w.Timeout
+}
+
+// GetMaxAttempts implements getting the number of delivery attempts before the delivery is dead-lettered.
+func (w *webhook) GetMaxAttempts() int {
+	return w.MaxAttempts
+}
+
+// GetBackoff implements getting the delay before the first retry, the delay doubles with every retry.
+func (w *webhook) GetBackoff() time.Duration {
+	return w.Backoff
+}
+
+// GetMaxBackoff implements getting the maximum delay between retries.
+func (w *webhook) GetMaxBackoff() time.Duration {
+	return w.MaxBackoff
+}
+
+// GetAllowPrivate implements getting the permission to deliver webhooks to loopback, private
+// and link-local addresses, meant for development only.
+func (w *webhook) GetAllowPrivate() bool {
+	return w.AllowPrivate
+}
+
+// GetPollInterval implements getting the interval of polling the outbox for undispatched events.
+func (o *outbox) GetPollInterval() time.Duration {
+	return o.PollInterval
+}
+
+// GetBatchSize implements getting the maximum number of events dispatched at once.
+func (o *outbox) GetBatchSize() int {
+	return o.BatchSize
+}
+
+// GetRetention implements getting the time the dispatched event is kept in the outbox,
+// zero value keeps dispatched events forever.
+func (o *outbox) GetRetention() time.Duration {
+	return o.Retention
+}
+
+// GetPurgeSchedule implements getting the schedule of purging the dispatched events from the outbox,
+// empty value turns purging off.
+func (o *outbox) GetPurgeSchedule() string {
+	return o.PurgeSchedule
+}
+
+// GetLockKey implements getting the key of the advisory lock held by the leader replica.
+func (l *leader) GetLockKey() int64 {
+	return l.LockKey
+}
+
+// GetCheckInterval implements getting the interval of acquiring the leadership by followers
+// and of checking it by the leader.
+func (l *leader) GetCheckInterval() time.Duration {
+	return l.CheckInterval
+}
+
+// GetURLCache implements getting read-through short URLs cache configuration.
+func (store *storage) GetURLCache() *urlCache {
+	return store.URLCache
+}
+
+// GetSize implements getting the maximum number of cached short URLs, zero value turns the cache off.
+func (c *urlCache) GetSize() int {
+	return c.Size
+}
+
+// GetTTL implements getting the time the short URL stays cached.
+func (c *urlCache) GetTTL() time.Duration {
+	return c.TTL
+}
+
+// GetDualWrite implements getting the dual-write configuration.
+func (store *storage) GetDualWrite() *dualWrite {
+	return store.DualWrite
+}
+
+// GetSecondaryURL implements getting the URL of the secondary storage, empty value disables the dual-write.
+func (d *dualWrite) GetSecondaryURL() string {
+	return d.SecondaryURL
+}
+
+// GetShadowReads implements getting the comparison of the short URLs read from the primary storage
+// with the secondary storage.
+func (d *dualWrite) GetShadowReads() bool {
+	return d.ShadowReads
+}
+
+// GetShadowTimeout implements getting the timeout of the read from the secondary storage.
+func (d *dualWrite) GetShadowTimeout() time.Duration {
+	return d.ShadowTimeout
+}
+
+// GetShadowConcurrency implements getting the maximum number of the concurrent reads from the secondary storage,
+// the comparison is skipped when it is reached.
+func (d *dualWrite) GetShadowConcurrency() int {
+	return d.ShadowConcurrency
+}
+
+// GetURLFilter implements getting unknown short URLs filter configuration.
+func (store *storage) GetURLFilter() *urlFilter {
+	return store.URLFilter
+}
+
+// GetExpectedItems implements getting the number of short URLs the Bloom filter is sized for,
+// zero value turns the filter off. The filter is used with storages publishing invalidations only.
+func (f *urlFilter) GetExpectedItems() int {
+	return f.ExpectedItems
+}
+
+// GetFalsePositiveRate implements getting the rate of unknown short URLs passed by the Bloom filter.
+func (f *urlFilter) GetFalsePositiveRate() float64 {
+	return f.FalsePositiveRate
+}
+
+// GetNegativeCacheSize implements getting the maximum number of remembered unknown short URLs.
+func (f *urlFilter) GetNegativeCacheSize() int {
+	return f.NegativeCacheSize
+}
+
+// GetNegativeCacheTTL implements getting the time the unknown short URL stays remembered.
+func (f *urlFilter) GetNegativeCacheTTL() time.Duration {
+	return f.NegativeCacheTTL
+}
+
 // GetCache implements getting in-memory storage configuration.
 func (store *storage) GetCache() *cache {
 	return store.Cache
 }
 
+// GetDriver implements getting the name of the storage driver, empty value selects the configured driver.
+func (store *storage) GetDriver() string {
+	return store.Driver
+}
+
+// GetStrict implements getting the strict mode refusing to start without the selected storage
+// instead of falling back to the next configured one.
+func (store *storage) GetStrict() bool {
+	return store.Strict
+}
+
+// GetSQLite implements getting SQLite storage configuration.
+func (store *storage) GetSQLite() *sqlite {
+	return store.SQLite
+}
+
+// GetDSN implements getting the DSN URL with the sqlite scheme for SQLite storage, empty value turns the storage off.
+func (s *sqlite) GetDSN() string {
+	return s.DSN
+}
+
+// GetMigrateURL implements getting the URL of the SQLite storage migrations.
+func (s *sqlite) GetMigrateURL() string {
+	return s.MigrateURL
+}
+
+// GetBolt implements getting embedded bbolt storage configuration.
+func (store *storage) GetBolt() *bolt {
+	return store.Bolt
+}
+
+// GetPath implements getting the file path of the embedded bbolt storage, empty value turns the storage off.
+func (b *bolt) GetPath() string {
+	return b.Path
+}
+
+// GetTimeout implements getting the time of waiting for the lock of the storage file held by another process.
+func (b *bolt) GetTimeout() time.Duration {
+	return b.Timeout
+}
+
 // GetPostgres implements getting PostgreSQL storage configuration.
 func (store *storage) GetPostgres() *postgres {
 	return store.Postgres
@@ -257,6 +748,36 @@ func (c *cache) GetFilePath() string {
 	return c.FilePath
 }
 
+// GetFileFormat implements getting the encoding of the file for the in-memory storage, json or binary.
+func (c *cache) GetFileFormat() string {
+	return c.FileFormat
+}
+
+// GetJournalPath implements getting the JSON lines journal file path of the short URL changes
+// for the in-memory storage, empty value turns the journal off. Only the short URL changes are journaled,
+// the accounts, workspaces, bans, reports and webhooks changed since the last compaction are lost
+// when the process is killed.
+func (c *cache) GetJournalPath() string {
+	return c.JournalPath
+}
+
+// GetCompactInterval implements getting the interval of compacting the journal file into the storage file.
+func (c *cache) GetCompactInterval() time.Duration {
+	return c.CompactInterval
+}
+
+// GetAuditFilePath implements getting the JSON lines file path of the audit log for the in-memory storage,
+// empty value keeps the audit log in memory.
+func (c *cache) GetAuditFilePath() string {
+	return c.AuditFilePath
+}
+
+// GetTaskJournalPath implements getting the JSON lines journal file path of the deferred tasks
+// for the in-memory storage, empty value keeps the tasks in memory.
+func (c *cache) GetTaskJournalPath() string {
+	return c.TaskJournalPath
+}
+
 // GetDSN implements getting the DSN URL for PostgreSQL storage.
 func (p *postgres) GetDSN() string {
 	return p.DSN
@@ -312,6 +833,11 @@ func (g *grpc) GetAddress() string {
 	return g.Address
 }
 
+// GetTrustedSubnet implements getting trusted subnet of the admin service.
+func (g *grpc) GetTrustedSubnet() *net.IPNet {
+	return (*net.IPNet)(g.TrustedSubnet)
+}
+
 // NewConfig implements the creation of the application configuration.
 func NewConfig() (*config, error) {
 	cfg := defaultConfig()
@@ -337,11 +863,62 @@ func NewConfig() (*config, error) {
 	}
 
 	cfg.ShortURL.BaseURL = &url.URL{Scheme: cfg.HTTP.Scheme, Host: cfg.HTTP.Address}
+	if len(cfg.HTTP.OIDC.RedirectURL) == 0 {
+		cfg.HTTP.OIDC.RedirectURL = cfg.ShortURL.BaseURL.JoinPath("/api/user/oidc/callback").String()
+	}
 	cfg.HTTP.Swagger.Host = fmt.Sprintf("%s://%s", cfg.HTTP.Scheme, cfg.HTTP.Address)
 	cfg.HTTP.Swagger.Schemes = append(cfg.HTTP.Swagger.Schemes, cfg.HTTP.Scheme)
 	return cfg, nil
 }
 
+// NewStorageConfig implements the creation of the storage configuration selecting the storage by the URL,
+// the rest of the storage configuration keeps the default values.
+//
+// The file storage is set by the file URL with the optional format and journal query parameters,
+// the journal next to the file with the wal extension is used by default. The bolt storage is set by the bolt URL,
+// the PostgreSQL and SQLite storages are set by their DSN.
+func NewStorageConfig(rawURL string) (*storage, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	store := defaultConfig().Storage
+	store.Cache.AuditFilePath = ""
+	store.Cache.TaskJournalPath = ""
+
+	switch u.Scheme {
+	case "file":
+		if len(u.Path) == 0 {
+			return nil, ErrStorageURL
+		}
+		store.Driver = "file"
+		store.Cache.FilePath = u.Path
+		store.Cache.JournalPath = strings.TrimSuffix(u.Path, filepath.Ext(u.Path)) + ".wal"
+		if journal := u.Query().Get("journal"); len(journal) > 0 {
+			store.Cache.JournalPath = journal
+		}
+		if format := u.Query().Get("format"); len(format) > 0 {
+			store.Cache.FileFormat = format
+		}
+	case "bolt":
+		if len(u.Path) == 0 {
+			return nil, ErrStorageURL
+		}
+		store.Driver = "bolt"
+		store.Bolt.Path = u.Path
+	case "postgres", "postgresql":
+		store.Driver = "postgres"
+		store.Postgres.DSN = rawURL
+	case "sqlite":
+		store.Driver = "sqlite"
+		store.SQLite.DSN = rawURL
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrStorageURL, rawURL)
+	}
+	return store, nil
+}
+
 // defaultConfig implements create application configuration with default values.
 func defaultConfig() *config {
 	return &config{
@@ -360,10 +937,19 @@ func defaultConfig() *config {
 			Cookie: &cookie{
 				SignID:    "user_id",
 				SecretKey: "secret_key",
+				HTTPOnly:  true,
+				SameSite:  "lax",
+				MaxAge:    365 * 24 * time.Hour,
+
+				SessionName:   "session",
+				SessionMaxAge: 30 * 24 * time.Hour,
 			},
 			Swagger: &swagger{
 				Title: "Shortener API",
 			},
+			OIDC: &oidc{
+				Scopes: []string{"openid", "profile", "email"},
+			},
 		},
 		GRPC: &grpc{
 			Address:   "127.0.0.1:8080",
@@ -375,15 +961,66 @@ func defaultConfig() *config {
 		},
 		Storage: &storage{
 			Cache: &cache{
-				FilePath: "./storage.json",
+				FilePath:        "./storage.json",
+				FileFormat:      "json",
+				JournalPath:     "./storage.wal",
+				CompactInterval: 5 * time.Minute,
+				AuditFilePath:   "./audit.jsonl",
+				TaskJournalPath: "./tasks.jsonl",
 			},
 			Postgres: &postgres{
 				MigrateURL: "file://migrations/postgres",
 			},
+			SQLite: &sqlite{
+				MigrateURL: "file://migrations/sqlite",
+			},
+			Bolt: &bolt{
+				Timeout: time.Second,
+			},
+			URLCache: &urlCache{
+				Size: 10000,
+				TTL:  time.Minute,
+			},
+			URLFilter: &urlFilter{
+				ExpectedItems:     1000000,
+				FalsePositiveRate: 0.01,
+				NegativeCacheSize: 10000,
+				NegativeCacheTTL:  10 * time.Second,
+			},
+			DualWrite: &dualWrite{
+				ShadowTimeout:     time.Second,
+				ShadowConcurrency: 100,
+			},
 		},
 		ShortURL: &shortURL{
 			CheckTaskInterval: 5 * time.Second,
 			MaxTaskQueue:      100,
+			ReportThreshold:   5,
+			TaskMaxAttempts:   5,
+			TaskBackoff:       time.Second,
+			TaskMaxBackoff:    time.Minute,
+			TaskDrainTimeout:  10 * time.Second,
+			TaskWorkers:       4,
+			TaskRetention:     7 * 24 * time.Hour,
+			TaskPurgeSchedule: "@hourly",
+		},
+		Webhook: &webhook{
+			Workers:     4,
+			QueueSize:   1000,
+			Timeout:     5 * time.Second,
+			MaxAttempts: 5,
+			Backoff:     time.Second,
+			MaxBackoff:  5 * time.Minute,
+		},
+		Outbox: &outbox{
+			PollInterval:  time.Second,
+			BatchSize:     100,
+			Retention:     24 * time.Hour,
+			PurgeSchedule: "@hourly",
+		},
+		Leader: &leader{
+			LockKey:       0x73686f727475726c,
+			CheckInterval: 5 * time.Second,
 		},
 	}
 }
@@ -0,0 +1,8 @@
+package config
+
+import (
+	"errors"
+)
+
+// ErrStorageURL implements the storage URL not supported error.
+var ErrStorageURL = errors.New("unsupported storage url")
@@ -0,0 +1,156 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/sreway/shorturl/internal/domain/audit"
+	pb "github.com/sreway/shorturl/proto/shorturl/v1"
+)
+
+// adminServicePrefix defines the full method name prefix of the admin service RPCs.
+const adminServicePrefix = "/shorturl.AdminService/"
+
+// admin implements the admin grpc service.
+type admin struct {
+	*delivery
+	pb.UnimplementedAdminServiceServer
+}
+
+// SearchURLs implements the RPC method for searching short URLs of all users.
+func (a *admin) SearchURLs(ctx context.Context, in *pb.SearchURLRequest) (*pb.SearchURLResponse, error) {
+	response := new(pb.SearchURLResponse)
+
+	urls, err := a.shortener.SearchURLs(ctx, in.Query, in.UserID, int(in.Limit), int(in.Offset))
+	if err != nil {
+		a.logger.Error("failed search urls", err, slog.String("handler", "SearchURLs"))
+		return nil, a.handelErrURL(err)
+	}
+
+	pbURLs := make([]*pb.URL, len(urls))
+	for idx, url := range urls {
+		pbURLs[idx] = newProtobufURL(url)
+	}
+	response.Url = pbURLs
+	return response, nil
+}
+
+// DisableURL implements the RPC method for setting the short URL moderation attribute.
+func (a *admin) DisableURL(ctx context.Context, in *pb.DisableURLRequest) (*pb.DisableURLResponse, error) {
+	response := new(pb.DisableURLResponse)
+
+	if err := a.shortener.DisableURL(ctx, in.UrlID, in.Disabled); err != nil {
+		a.logger.Error("failed moderate url", err, slog.String("handler", "DisableURL"))
+		return nil, a.handelErrURL(err)
+	}
+	return response, nil
+}
+
+// BanUser implements the RPC method for setting the user ban.
+func (a *admin) BanUser(ctx context.Context, in *pb.BanUserRequest) (*pb.BanUserResponse, error) {
+	response := new(pb.BanUserResponse)
+
+	if err := a.shortener.BanUser(ctx, in.UserID, in.Banned); err != nil {
+		a.logger.Error("failed moderate user", err, slog.String("handler", "BanUser"))
+		return nil, a.handelErrURL(err)
+	}
+	return response, nil
+}
+
+// ForceDeleteURL implements the RPC method for removing short URLs of any user.
+func (a *admin) ForceDeleteURL(ctx context.Context, in *pb.ForceDeleteURLRequest) (*pb.ForceDeleteURLResponse, error) {
+	response := new(pb.ForceDeleteURLResponse)
+
+	if err := a.shortener.ForceDeleteURL(ctx, in.UrlID); err != nil {
+		a.logger.Error("failed force delete urls", err, slog.String("handler", "ForceDeleteURL"))
+		return nil, a.handelErrURL(err)
+	}
+	return response, nil
+}
+
+// GetReportQueue implements the RPC method for getting the moderation queue of reported short URLs.
+func (a *admin) GetReportQueue(ctx context.Context, in *pb.GetReportQueueRequest) (*pb.GetReportQueueResponse, error) {
+	response := new(pb.GetReportQueueResponse)
+
+	queue, err := a.shortener.GetReportQueue(ctx, int(in.Limit), int(in.Offset))
+	if err != nil {
+		a.logger.Error("failed get report queue", err, slog.String("handler", "GetReportQueue"))
+		return nil, a.handelErrURL(err)
+	}
+
+	pbReports := make([]*pb.Report, len(queue))
+	for idx, item := range queue {
+		pbReports[idx] = &pb.Report{
+			ShortURL:       item.ShortURL,
+			LongURL:        item.LongURL,
+			Disabled:       item.Disabled,
+			Flagged:        item.Flagged,
+			Count:          int32(item.Count),
+			Reasons:        item.Reasons,
+			LastReportedAt: item.LastReportedAt.Format(time.RFC3339),
+		}
+	}
+	response.Report = pbReports
+	return response, nil
+}
+
+// ResolveReports implements the RPC method for the moderator review of the reported short URL.
+func (a *admin) ResolveReports(ctx context.Context, in *pb.ResolveReportsRequest) (*pb.ResolveReportsResponse, error) {
+	response := new(pb.ResolveReportsResponse)
+
+	if err := a.shortener.ResolveReports(ctx, in.UrlID, in.Disable); err != nil {
+		a.logger.Error("failed resolve reports", err, slog.String("handler", "ResolveReports"))
+		return nil, a.handelErrURL(err)
+	}
+	return response, nil
+}
+
+// PromoteStorage implements the RPC method for making the secondary storage of the dual-write primary.
+func (a *admin) PromoteStorage(ctx context.Context, _ *pb.PromoteStorageRequest) (*pb.PromoteStorageResponse, error) {
+	name, err := a.shortener.PromoteStorage(ctx)
+	if err != nil {
+		a.logger.Error("failed promote storage", err, slog.String("handler", "PromoteStorage"))
+		return nil, a.handelErrURL(err)
+	}
+	return &pb.PromoteStorageResponse{Driver: name}, nil
+}
+
+// trustedSubnet implements validate trusted subnet interceptor for the admin service RPCs.
+func trustedSubnet(subnet *net.IPNet) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, adminServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		if subnet == nil {
+			return nil, status.Error(codes.PermissionDenied, ErrTrustedSubnetNotSetup.Error())
+		}
+
+		var rip string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("x-real-ip"); len(values) > 0 {
+				rip = values[0]
+			}
+		}
+
+		if len(rip) == 0 {
+			return nil, status.Error(codes.PermissionDenied, ErrEmptyRealIP.Error())
+		}
+
+		if !subnet.Contains(net.ParseIP(rip)) {
+			return nil, status.Error(codes.PermissionDenied, ErrIPNotAllowed.Error())
+		}
+
+		return handler(audit.WithActor(ctx, rip), req)
+	}
+}
@@ -0,0 +1,174 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sreway/shorturl/internal/domain/report"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+	"github.com/sreway/shorturl/internal/usecases/shortener"
+	pb "github.com/sreway/shorturl/proto/shorturl/v1"
+)
+
+func Test_admin(t *testing.T) {
+	type fields struct {
+		useCaseErr error
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		rpc      string
+		wantCode codes.Code
+	}{
+		{
+			name:     "positive search urls",
+			rpc:      "SearchURLs",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "negative search urls (invalid user id)",
+			rpc:      "SearchURLs",
+			fields:   fields{useCaseErr: shortener.ErrParseUUID},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "positive disable url",
+			rpc:      "DisableURL",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "negative disable url (not found)",
+			rpc:      "DisableURL",
+			fields:   fields{useCaseErr: entity.ErrNotFound},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "positive ban user",
+			rpc:      "BanUser",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "negative ban user (invalid user id)",
+			rpc:      "BanUser",
+			fields:   fields{useCaseErr: shortener.ErrParseUUID},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "positive force delete urls",
+			rpc:      "ForceDeleteURL",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "negative force delete urls (invalid short url)",
+			rpc:      "ForceDeleteURL",
+			fields:   fields{useCaseErr: shortener.ErrDecodeURL},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "positive get report queue",
+			rpc:      "GetReportQueue",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "negative get report queue (storage check)",
+			rpc:      "GetReportQueue",
+			fields:   fields{useCaseErr: ErrStorageCheck},
+			wantCode: codes.Unavailable,
+		},
+		{
+			name:     "positive resolve reports",
+			rpc:      "ResolveReports",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "negative resolve reports (not found)",
+			rpc:      "ResolveReports",
+			fields:   fields{useCaseErr: entity.ErrNotFound},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "positive promote storage",
+			rpc:      "PromoteStorage",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "negative promote storage (dual-write not used)",
+			rpc:      "PromoteStorage",
+			fields:   fields{useCaseErr: shortener.ErrSwitchoverNotSupported},
+			wantCode: codes.Unimplemented,
+		},
+	}
+
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := usecasesMock.NewMockShortener(ctl)
+			d, err := New(uc)
+			assert.NoError(t, err)
+			a := &admin{delivery: d}
+
+			var resp interface{}
+			switch tt.rpc {
+			case "SearchURLs":
+				uc.EXPECT().SearchURLs(anyMock, "example", "", 10, 0).Return(
+					[]entity.URL{entity.NewURL(uuid.New(), uuid.New())}, tt.fields.useCaseErr)
+				var out *pb.SearchURLResponse
+				out, err = a.SearchURLs(ctx, &pb.SearchURLRequest{Query: "example", Limit: 10})
+				if err == nil {
+					assert.Len(t, out.Url, 1)
+				}
+				resp = out
+			case "DisableURL":
+				uc.EXPECT().DisableURL(anyMock, "2ZrI5IHFnvPscPYKlxFtRQ", true).Return(tt.fields.useCaseErr)
+				resp, err = a.DisableURL(ctx, &pb.DisableURLRequest{UrlID: "2ZrI5IHFnvPscPYKlxFtRQ", Disabled: true})
+			case "BanUser":
+				uc.EXPECT().BanUser(anyMock, "624708fa-d258-4b99-b09a-49d95f294626", true).Return(tt.fields.useCaseErr)
+				resp, err = a.BanUser(ctx, &pb.BanUserRequest{UserID: "624708fa-d258-4b99-b09a-49d95f294626", Banned: true})
+			case "ForceDeleteURL":
+				uc.EXPECT().ForceDeleteURL(anyMock, []string{"2ZrI5IHFnvPscPYKlxFtRQ"}).Return(tt.fields.useCaseErr)
+				resp, err = a.ForceDeleteURL(ctx, &pb.ForceDeleteURLRequest{UrlID: []string{"2ZrI5IHFnvPscPYKlxFtRQ"}})
+			case "GetReportQueue":
+				uc.EXPECT().GetReportQueue(anyMock, 10, 5).Return(
+					[]report.Summary{{LongURL: "https://example.com", Count: 3, Flagged: true}}, tt.fields.useCaseErr)
+				var out *pb.GetReportQueueResponse
+				out, err = a.GetReportQueue(ctx, &pb.GetReportQueueRequest{Limit: 10, Offset: 5})
+				if err == nil {
+					assert.Len(t, out.Report, 1)
+					assert.Equal(t, int32(3), out.Report[0].Count)
+					assert.True(t, out.Report[0].Flagged)
+				}
+				resp = out
+			case "ResolveReports":
+				uc.EXPECT().ResolveReports(anyMock, "2ZrI5IHFnvPscPYKlxFtRQ", true).Return(tt.fields.useCaseErr)
+				resp, err = a.ResolveReports(ctx, &pb.ResolveReportsRequest{UrlID: "2ZrI5IHFnvPscPYKlxFtRQ", Disable: true})
+			case "PromoteStorage":
+				uc.EXPECT().PromoteStorage(anyMock).Return("postgres", tt.fields.useCaseErr)
+				var out *pb.PromoteStorageResponse
+				out, err = a.PromoteStorage(ctx, &pb.PromoteStorageRequest{})
+				if err == nil {
+					assert.Equal(t, "postgres", out.Driver)
+				}
+				resp = out
+			}
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				assert.Error(t, err)
+				return
+			}
+			assert.NotNil(t, resp)
+		})
+	}
+}
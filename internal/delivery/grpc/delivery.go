@@ -9,8 +9,10 @@ import (
 	"golang.org/x/exp/slog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 
 	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/audit"
 	"github.com/sreway/shorturl/internal/usecases"
 	pb "github.com/sreway/shorturl/proto/shorturl/v1"
 )
@@ -48,9 +50,12 @@ func (d *delivery) Run(ctx context.Context, config config.GRPC) error {
 		serverOptions = append(serverOptions, grpc.Creds(tls))
 	}
 
+	serverOptions = append(serverOptions,
+		grpc.ChainUnaryInterceptor(auditTransport, trustedSubnet(config.GetTrustedSubnet())))
 	server := grpc.NewServer(serverOptions...)
 
 	pb.RegisterShortURLServiceServer(server, d)
+	pb.RegisterAdminServiceServer(server, &admin{delivery: d})
 
 	ctxServer, stopServer := context.WithCancel(context.Background())
 	defer stopServer()
@@ -77,3 +82,19 @@ func (d *delivery) Run(ctx context.Context, config config.GRPC) error {
 	<-ctxServer.Done()
 	return nil
 }
+
+// auditTransport implements the interceptor marking the operations as received over grpc for the audit log,
+// the peer address identifies the operation until the trusted subnet interceptor checks the real IP.
+func auditTransport(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx = audit.WithTransport(ctx, audit.TransportGRPC)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		actor, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			actor = p.Addr.String()
+		}
+		ctx = audit.WithActor(ctx, actor)
+	}
+	return handler(ctx, req)
+}
@@ -0,0 +1,141 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/sreway/shorturl/internal/domain/audit"
+)
+
+func Test_trustedSubnet(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("192.168.88.0/24")
+
+	type args struct {
+		subnet *net.IPNet
+		method string
+		realIP string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		wantCode codes.Code
+		wantErr  error
+	}{
+		{
+			name: "positive admin method (trusted ip)",
+			args: args{
+				subnet: subnet,
+				method: "/shorturl.AdminService/SearchURLs",
+				realIP: "192.168.88.1",
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "positive not admin method (no trusted subnet)",
+			args: args{
+				method: "/shorturl.ShortURLService/CreateURL",
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "positive not admin method (ip outside subnet)",
+			args: args{
+				subnet: subnet,
+				method: "/shorturl.ShortURLService/GetURL",
+				realIP: "192.168.89.1",
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "negative admin method (trusted subnet not setup)",
+			args: args{
+				method: "/shorturl.AdminService/BanUser",
+				realIP: "192.168.88.1",
+			},
+			wantCode: codes.PermissionDenied,
+			wantErr:  ErrTrustedSubnetNotSetup,
+		},
+		{
+			name: "negative admin method (missing x-real-ip)",
+			args: args{
+				subnet: subnet,
+				method: "/shorturl.AdminService/DisableURL",
+			},
+			wantCode: codes.PermissionDenied,
+			wantErr:  ErrEmptyRealIP,
+		},
+		{
+			name: "negative admin method (ip outside subnet)",
+			args: args{
+				subnet: subnet,
+				method: "/shorturl.AdminService/ForceDeleteURL",
+				realIP: "192.168.89.1",
+			},
+			wantCode: codes.PermissionDenied,
+			wantErr:  ErrIPNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if len(tt.args.realIP) > 0 {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-real-ip", tt.args.realIP))
+			}
+
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return req, nil
+			}
+
+			interceptor := trustedSubnet(tt.args.subnet)
+			resp, err := interceptor(ctx, "request", &grpc.UnaryServerInfo{FullMethod: tt.args.method}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCode == codes.OK, called)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), status.Convert(err).Message())
+				return
+			}
+			assert.Equal(t, "request", resp)
+		})
+	}
+}
+
+func Test_auditTransport(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("192.168.88.0/24")
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 41234},
+	})
+
+	var got context.Context
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = ctx
+		return req, nil
+	}
+
+	// the peer address identifies the operation
+	info := &grpc.UnaryServerInfo{FullMethod: "/shorturl.ShortURLService/CreateURL"}
+	_, err := auditTransport(ctx, "request", info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, audit.TransportGRPC, audit.TransportFromContext(got))
+	assert.Equal(t, "10.0.0.5", audit.ActorFromContext(got))
+
+	// the checked real IP identifies the admin operation received through the proxy
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-real-ip", "192.168.88.1"))
+	info = &grpc.UnaryServerInfo{FullMethod: "/shorturl.AdminService/BanUser"}
+	_, err = auditTransport(ctx, "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return trustedSubnet(subnet)(ctx, req, info, handler)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.88.1", audit.ActorFromContext(got))
+}
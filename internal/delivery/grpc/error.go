@@ -12,3 +12,12 @@ var ErrInvalidUserID = errors.New("invalid user id")
 
 // ErrStorageCheck implements storage check error.
 var ErrStorageCheck = errors.New("failed storage check")
+
+// ErrIPNotAllowed implements not allowed error.
+var ErrIPNotAllowed = errors.New("ip not allowed")
+
+// ErrTrustedSubnetNotSetup implements trusted subnet not setup error.
+var ErrTrustedSubnetNotSetup = errors.New("trusted subnet not setup")
+
+// ErrEmptyRealIP implements missing x-real-ip metadata error.
+var ErrEmptyRealIP = errors.New("missing x-real-ip metadata")
@@ -3,11 +3,13 @@ package grpc
 import (
 	"context"
 	"errors"
+	"time"
 
 	"golang.org/x/exp/slog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/sreway/shorturl/internal/domain/task"
 	entity "github.com/sreway/shorturl/internal/domain/url"
 	"github.com/sreway/shorturl/internal/usecases/shortener"
 	pb "github.com/sreway/shorturl/proto/shorturl/v1"
@@ -22,6 +24,7 @@ func newProtobufURL(url entity.URL) *pb.URL {
 		ShortURL:      url.ShortURL(),
 		CorrelationID: url.CorrelationID(),
 		Deleted:       url.Deleted(),
+		Disabled:      url.Disabled(),
 	}
 }
 
@@ -133,12 +136,47 @@ func (d *delivery) DeleteURL(ctx context.Context, in *pb.DeleteURLRequest) (*pb.
 		return nil, d.handelErrURL(ErrInvalidUserID)
 	}
 
-	err := d.shortener.DeleteURL(ctx, in.UserID, in.UrlID)
+	jobID, err := d.shortener.DeleteURL(ctx, in.UserID, in.UrlID)
 	if err != nil {
 		d.logger.Error("failed delete urls", err, slog.String("handler", "DeleteURL"))
 		return nil, d.handelErrURL(err)
 	}
 
+	response.JobID = jobID
+	return response, nil
+}
+
+// GetJob implements the RPC method for getting the state of the asynchronous job of the user.
+func (d *delivery) GetJob(ctx context.Context, in *pb.GetJobRequest) (*pb.GetJobResponse, error) {
+	response := new(pb.GetJobResponse)
+	if len(in.UserID) == 0 {
+		d.logger.Error("invalid user id", ErrInvalidUserID, slog.String("userID", in.UserID),
+			slog.String("handler", "GetJob"))
+		return nil, d.handelErrURL(ErrInvalidUserID)
+	}
+
+	item, err := d.shortener.GetJob(ctx, in.UserID, in.JobID)
+	if err != nil {
+		d.logger.Error("failed get job", err, slog.String("handler", "GetJob"))
+		return nil, d.handelErrURL(err)
+	}
+
+	pbURLs := make([]*pb.JobURL, len(item.URLs))
+	for idx, u := range item.URLs {
+		pbURLs[idx] = &pb.JobURL{
+			Id:       u.ID,
+			ShortURL: u.ShortURL,
+			Outcome:  string(u.Outcome),
+		}
+	}
+	response.Job = &pb.Job{
+		Id:        item.ID.String(),
+		Action:    string(item.Action),
+		Status:    string(item.Status),
+		Attempts:  int32(item.Attempts),
+		CreatedAt: item.CreatedAt.Format(time.RFC3339),
+		Urls:      pbURLs,
+	}
 	return response, nil
 }
 
@@ -153,6 +191,27 @@ func (d *delivery) StorageCheck(ctx context.Context, _ *pb.StorageCheckRequest)
 	return response, nil
 }
 
+// Health implements the RPC method for the health status of the replica with the leadership.
+// The unhealthy status is returned in the response, so the leadership stays observable.
+func (d *delivery) Health(ctx context.Context, _ *pb.HealthRequest) (*pb.HealthResponse, error) {
+	status := d.shortener.Health(ctx)
+	response := &pb.HealthResponse{
+		Status:   "ok",
+		Storage:  "ok",
+		Driver:   status.Driver,
+		Instance: status.Instance,
+		Election: status.Election,
+		Leader:   status.Leader,
+	}
+
+	if !status.Healthy() {
+		d.logger.Error("failed check storage", status.Storage, slog.String("handler", "health"))
+		response.Status = "unavailable"
+		response.Storage = "unavailable"
+	}
+	return response, nil
+}
+
 func (d *delivery) handelErrURL(err error) error {
 	switch {
 	case errors.Is(err, ErrInvalidUserID):
@@ -167,8 +226,18 @@ func (d *delivery) handelErrURL(err error) error {
 		return status.Error(codes.AlreadyExists, err.Error())
 	case errors.Is(err, entity.ErrNotFound):
 		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, task.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, shortener.ErrJobsNotSupported):
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Is(err, shortener.ErrSwitchoverNotSupported):
+		return status.Error(codes.Unimplemented, err.Error())
 	case errors.Is(err, entity.ErrDeleted):
 		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, entity.ErrDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, shortener.ErrUserBanned):
+		return status.Error(codes.PermissionDenied, err.Error())
 	case errors.Is(err, ErrStorageCheck):
 		return status.Error(codes.Unavailable, err.Error())
 	default:
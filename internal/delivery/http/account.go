@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/delivery/http/cookies"
+	"github.com/sreway/shorturl/internal/domain/account"
+)
+
+// register godoc
+// @Summary register user account
+// @Description register user account, short URLs of the current cookie identity are moved to the account
+// @ID register
+// @Produce application/json
+// @Param credentials body credentialsRequest true "account credentials"
+// @Success 201 {object} accountResponse
+// @Failure 400 {object} errResponse
+// @Failure 409 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/register [post]
+func (d *delivery) register(w http.ResponseWriter, r *http.Request) {
+	d.authenticate(w, r, "register", http.StatusCreated, d.shortener.Register)
+}
+
+// login godoc
+// @Summary login user account
+// @Description login user account, short URLs of the current cookie identity are moved to the account
+// @ID login
+// @Produce application/json
+// @Param credentials body credentialsRequest true "account credentials"
+// @Success 200 {object} accountResponse
+// @Failure 400 {object} errResponse
+// @Failure 401 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/login [post]
+func (d *delivery) login(w http.ResponseWriter, r *http.Request) {
+	d.authenticate(w, r, "login", http.StatusOK, d.shortener.Login)
+}
+
+// logout godoc
+// @Summary logout user account
+// @Description logout user account
+// @ID logout
+// @Success 204
+// @Router /api/user/logout [post]
+func (d *delivery) logout(w http.ResponseWriter, _ *http.Request) {
+	cookie := d.session
+	cookie.MaxAge = -1
+	http.SetCookie(w, &cookie)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticator describes the account authentication use case.
+type authenticator func(ctx context.Context, email, password, userID string) (account.Account, error)
+
+// authenticate implements account authentication and starting the account session.
+func (d *delivery) authenticate(w http.ResponseWriter, r *http.Request, handler string, statusCode int,
+	auth authenticator,
+) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	identityID, ok := r.Context().Value(ctxKeyIdentityID{}).(string)
+	if !ok {
+		d.logger.Error("invalid identity id", ErrInvalidRequest,
+			slog.String("identityID", identityID), slog.String("handler", handler))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	req := new(credentialsRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		d.logger.Error("failed decode request", err, slog.String("handler", handler))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	a, err := auth(r.Context(), req.Email, req.Password, identityID)
+	if err != nil {
+		d.logger.Error("failed authenticate account", err, slog.String("handler", handler))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	cookie := d.session
+	cookie.Value = a.ID().String()
+	if err = cookies.WriteEncrypted(w, cookie, d.keys); err != nil {
+		d.logger.Error("failed write session cookie", err, slog.String("handler", handler))
+		d.handelErrURL(w, r, ErrInternalServer)
+		return
+	}
+
+	data, err := json.Marshal(accountResponse{ID: a.ID().String(), Email: a.Email()})
+	if err != nil {
+		d.logger.Error("failed marshal response", err, slog.String("handler", handler))
+		d.handelErrURL(w, r, ErrInternalServer)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+	_, err = w.Write(data)
+	if err != nil {
+		d.logger.Error("write body", err, slog.String("handler", handler))
+		return
+	}
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/delivery/http/cookies"
+	"github.com/sreway/shorturl/internal/domain/account"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+	"github.com/sreway/shorturl/internal/usecases/shortener"
+)
+
+func Test_delivery_login(t *testing.T) {
+	type want struct {
+		code     int
+		response string
+		session  bool
+	}
+	type args struct {
+		body string
+	}
+	type fields struct {
+		useCaseErr error
+	}
+
+	uri := "/api/user/login"
+	method := http.MethodPost
+	accountID := uuid.New()
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   want
+	}{
+		{
+			name: "positive login",
+			args: args{
+				body: `{"email":"user@example.com","password":"password"}`,
+			},
+			want: want{
+				code:     http.StatusOK,
+				response: `{"id":"` + accountID.String() + `","email":"user@example.com"}`,
+				session:  true,
+			},
+		},
+		{
+			name: "negative login (invalid body)",
+			args: args{
+				body: `invalid`,
+			},
+			want: want{
+				code:     http.StatusBadRequest,
+				response: "{\"error\":\"invalid request\"}\n",
+			},
+		},
+		{
+			name: "negative login (invalid credentials)",
+			args: args{
+				body: `{"email":"user@example.com","password":"invalid"}`,
+			},
+			fields: fields{useCaseErr: shortener.ErrInvalidCredentials},
+			want: want{
+				code:     http.StatusUnauthorized,
+				response: "{\"error\":\"invalid credentials\"}\n",
+			},
+		},
+	}
+
+	anyMock := gomock.Any()
+	identityID := uuid.New().String()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	keys, err := cookies.NewKeyring("secret_key")
+	assert.NoError(t, err)
+
+	for _, tt := range tests {
+		uc := usecasesMock.NewMockShortener(ctl)
+		a := account.NewAccount(accountID, "user@example.com")
+		uc.EXPECT().Login(anyMock, anyMock, anyMock, identityID).Return(a, tt.fields.useCaseErr).AnyTimes()
+		d := New(uc)
+		d.keys = keys
+		d.session = http.Cookie{Name: "session"}
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(method, uri, strings.NewReader(tt.args.body))
+			request = request.WithContext(context.WithValue(request.Context(), ctxKeyIdentityID{}, identityID))
+			w := httptest.NewRecorder()
+			h := http.HandlerFunc(d.login)
+			h.ServeHTTP(w, request)
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.want.code, resp.StatusCode)
+			resBody, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.response, string(resBody))
+			if !tt.want.session {
+				assert.Empty(t, resp.Cookies())
+				return
+			}
+
+			request = httptest.NewRequest(http.MethodGet, "/api/user/urls", nil)
+			request.AddCookie(resp.Cookies()[0])
+			var userID string
+			session(d.session, keys)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				userID, _ = r.Context().Value(ctxKeyUserID{}).(string)
+			})).ServeHTTP(httptest.NewRecorder(), request)
+			assert.Equal(t, accountID.String(), userID)
+		})
+	}
+}
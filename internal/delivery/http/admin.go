@@ -0,0 +1,201 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/exp/slog"
+)
+
+// adminURL godoc
+// @Summary search short URLs of all users
+// @Description search short URLs of all users by the long URL substring
+// @ID adminURL
+// @Produce application/json
+// @Param q query string false "long URL substring"
+// @Param user_id query string false "user id"
+// @Param limit query int false "maximum number of short URLs"
+// @Param offset query int false "number of short URLs to skip"
+// @Success 200 {object} []adminURLResponse
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/internal/admin/urls [get]
+func (d *delivery) adminURL(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	query := r.URL.Query()
+	var limit, offset int
+	for _, param := range []struct {
+		name  string
+		value *int
+	}{{"limit", &limit}, {"offset", &offset}} {
+		raw := query.Get(param.name)
+		if len(raw) == 0 {
+			continue
+		}
+
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			d.logger.Error("failed parse query parameter", err, slog.String("param", param.name),
+				slog.String("handler", "adminURL"))
+			d.handelErrURL(w, r, ErrInvalidRequest)
+			return
+		}
+		*param.value = value
+	}
+
+	urls, err := d.shortener.SearchURLs(r.Context(), query.Get("q"), query.Get("user_id"), limit, offset)
+	if err != nil {
+		d.logger.Error("failed search urls", err, slog.String("handler", "adminURL"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	resp := make([]adminURLResponse, len(urls))
+	for idx, u := range urls {
+		resp[idx] = adminURLResponse{
+			ShortURL:    u.ShortURL(),
+			OriginalURL: u.LongURL(),
+			UserID:      u.UserID().String(),
+			WorkspaceID: u.WorkspaceID().String(),
+			Deleted:     u.Deleted(),
+			Disabled:    u.Disabled(),
+		}
+	}
+
+	d.writeJSON(w, r, "adminURL", http.StatusOK, resp)
+}
+
+// adminDeleteURL godoc
+// @Summary force delete short URLs
+// @Description remove short URLs of any user immediately
+// @ID adminDeleteURL
+// @Param ids body []string true "short URL ids to delete"
+// @Success 204
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/internal/admin/urls [delete]
+func (d *delivery) adminDeleteURL(w http.ResponseWriter, r *http.Request) {
+	urls := new([]string)
+	if err := json.NewDecoder(r.Body).Decode(urls); err != nil {
+		d.logger.Error("failed decode request", err, slog.String("handler", "adminDeleteURL"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	if err := d.shortener.ForceDeleteURL(r.Context(), *urls); err != nil {
+		d.logger.Error("failed force delete urls", err, slog.String("handler", "adminDeleteURL"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// disableURL godoc
+// @Summary disable short URL
+// @Description disable short URL, it responds with 451 instead of redirect
+// @ID disableURL
+// @Param id path string true "short URL id"
+// @Success 204
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/internal/admin/urls/{id}/disable [post]
+func (d *delivery) disableURL(w http.ResponseWriter, r *http.Request) {
+	d.moderateURL(w, r, "disableURL", true)
+}
+
+// enableURL godoc
+// @Summary enable short URL
+// @Description enable previously disabled short URL
+// @ID enableURL
+// @Param id path string true "short URL id"
+// @Success 204
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/internal/admin/urls/{id}/enable [post]
+func (d *delivery) enableURL(w http.ResponseWriter, r *http.Request) {
+	d.moderateURL(w, r, "enableURL", false)
+}
+
+// banUser godoc
+// @Summary ban user
+// @Description ban user ID, banned users can't create short URLs
+// @ID banUser
+// @Param userID path string true "user id"
+// @Success 204
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/internal/admin/users/{userID}/ban [post]
+func (d *delivery) banUser(w http.ResponseWriter, r *http.Request) {
+	d.moderateUser(w, r, "banUser", true)
+}
+
+// unbanUser godoc
+// @Summary unban user
+// @Description lift the ban of the user ID
+// @ID unbanUser
+// @Param userID path string true "user id"
+// @Success 204
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/internal/admin/users/{userID}/unban [post]
+func (d *delivery) unbanUser(w http.ResponseWriter, r *http.Request) {
+	d.moderateUser(w, r, "unbanUser", false)
+}
+
+// moderateURL implements the setting of the short URL moderation attribute.
+func (d *delivery) moderateURL(w http.ResponseWriter, r *http.Request, handler string, disabled bool) {
+	if err := d.shortener.DisableURL(r.Context(), chi.URLParam(r, "id"), disabled); err != nil {
+		d.logger.Error("failed moderate url", err, slog.String("handler", handler))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// moderateUser implements the setting of the user ban.
+func (d *delivery) moderateUser(w http.ResponseWriter, r *http.Request, handler string, banned bool) {
+	if err := d.shortener.BanUser(r.Context(), chi.URLParam(r, "userID"), banned); err != nil {
+		d.logger.Error("failed moderate user", err, slog.String("handler", handler))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// promoteStorage godoc
+// @Summary promote secondary storage
+// @Description make the secondary storage of the dual-write primary, short URLs are read from it then
+// @ID promoteStorage
+// @Produce application/json
+// @Success 200 {object} promoteStorageResponse
+// @Failure 403 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/internal/admin/storage/promote [post]
+func (d *delivery) promoteStorage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	name, err := d.shortener.PromoteStorage(r.Context())
+	if err != nil {
+		d.logger.Error("failed promote storage", err, slog.String("handler", "promoteStorage"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	d.writeJSON(w, r, "promoteStorage", http.StatusOK, promoteStorageResponse{Driver: name})
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/url"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+	"github.com/sreway/shorturl/internal/usecases/shortener"
+)
+
+func Test_delivery_admin(t *testing.T) {
+	type want struct {
+		code int
+	}
+
+	type args struct {
+		uri     string
+		realIP  string
+		disable bool
+		ban     bool
+		promote bool
+	}
+
+	type fields struct {
+		useCaseErr error
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   want
+	}{
+		{
+			name: "positive disable url",
+			args: args{
+				uri:     "/api/internal/admin/urls/2ZrI5IHFnvPscPYKlxFtRQ/disable",
+				realIP:  "192.168.88.1",
+				disable: true,
+			},
+			want: want{
+				code: http.StatusNoContent,
+			},
+		},
+		{
+			name: "positive ban user",
+			args: args{
+				uri:    "/api/internal/admin/users/624708fa-d258-4b99-b09a-49d95f294626/ban",
+				realIP: "192.168.88.1",
+				ban:    true,
+			},
+			want: want{
+				code: http.StatusNoContent,
+			},
+		},
+		{
+			name: "negative disable url (not found)",
+			args: args{
+				uri:     "/api/internal/admin/urls/2ZrI5IHFnvPscPYKlxFtRQ/disable",
+				realIP:  "192.168.88.1",
+				disable: true,
+			},
+			fields: fields{
+				useCaseErr: url.ErrNotFound,
+			},
+			want: want{
+				code: http.StatusNotFound,
+			},
+		},
+		{
+			name: "negative ban user (invalid user id)",
+			args: args{
+				uri:    "/api/internal/admin/users/invalid/ban",
+				realIP: "192.168.88.1",
+				ban:    true,
+			},
+			fields: fields{
+				useCaseErr: shortener.ErrParseUUID,
+			},
+			want: want{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "positive promote storage",
+			args: args{
+				uri:     "/api/internal/admin/storage/promote",
+				realIP:  "192.168.88.1",
+				promote: true,
+			},
+			want: want{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "negative promote storage (dual-write not used)",
+			args: args{
+				uri:     "/api/internal/admin/storage/promote",
+				realIP:  "192.168.88.1",
+				promote: true,
+			},
+			fields: fields{
+				useCaseErr: shortener.ErrSwitchoverNotSupported,
+			},
+			want: want{
+				code: http.StatusNotImplemented,
+			},
+		},
+		{
+			name: "negative disable url (ip not allowed)",
+			args: args{
+				uri:    "/api/internal/admin/urls/2ZrI5IHFnvPscPYKlxFtRQ/disable",
+				realIP: "192.168.89.1",
+			},
+			want: want{
+				code: http.StatusForbidden,
+			},
+		},
+	}
+
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	_, subnet, err := net.ParseCIDR("192.168.88.0/24")
+	assert.NoError(t, err)
+
+	for _, tt := range tests {
+		uc := usecasesMock.NewMockShortener(ctl)
+		if tt.args.disable {
+			uc.EXPECT().DisableURL(anyMock, "2ZrI5IHFnvPscPYKlxFtRQ", true).Return(tt.fields.useCaseErr)
+		}
+		if tt.args.ban {
+			uc.EXPECT().BanUser(anyMock, anyMock, true).Return(tt.fields.useCaseErr)
+		}
+		if tt.args.promote {
+			uc.EXPECT().PromoteStorage(anyMock).Return("postgres", tt.fields.useCaseErr)
+		}
+		d := New(uc)
+		router := chi.NewRouter()
+		router.Route("/api/internal/admin", func(r chi.Router) {
+			r.Use(trustedSubnet(subnet))
+			r.Post("/urls/{id}/disable", d.disableURL)
+			r.Post("/users/{userID}/ban", d.banUser)
+			r.Post("/storage/promote", d.promoteStorage)
+		})
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, tt.args.uri, nil)
+			request.Header.Set("X-Real-IP", tt.args.realIP)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, request)
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.want.code, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// audit godoc
+// @Summary get audit log
+// @Description get audit log events of mutating operations ordered by creation time
+// @ID audit
+// @Produce application/json
+// @Param from query string false "period start, RFC 3339 time"
+// @Param to query string false "period end (exclusive), RFC 3339 time"
+// @Param user_id query string false "user id"
+// @Param limit query int false "maximum number of events"
+// @Param offset query int false "number of events to skip"
+// @Success 200 {object} []auditResponse
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/internal/audit [get]
+func (d *delivery) audit(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	query := r.URL.Query()
+	var from, to time.Time
+	for _, param := range []struct {
+		name  string
+		value *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		raw := query.Get(param.name)
+		if len(raw) == 0 {
+			continue
+		}
+
+		value, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			d.logger.Error("failed parse query parameter", err, slog.String("param", param.name),
+				slog.String("handler", "audit"))
+			d.handelErrURL(w, r, ErrInvalidRequest)
+			return
+		}
+		*param.value = value
+	}
+
+	var limit, offset int
+	for _, param := range []struct {
+		name  string
+		value *int
+	}{{"limit", &limit}, {"offset", &offset}} {
+		raw := query.Get(param.name)
+		if len(raw) == 0 {
+			continue
+		}
+
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			d.logger.Error("failed parse query parameter", err, slog.String("param", param.name),
+				slog.String("handler", "audit"))
+			d.handelErrURL(w, r, ErrInvalidRequest)
+			return
+		}
+		*param.value = value
+	}
+
+	events, err := d.shortener.GetAuditEvents(r.Context(), query.Get("user_id"), from, to, limit, offset)
+	if err != nil {
+		d.logger.Error("failed get audit events", err, slog.String("handler", "audit"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	resp := make([]auditResponse, len(events))
+	for idx, e := range events {
+		resp[idx] = auditResponse{
+			ID:        e.ID().String(),
+			Action:    string(e.Action()),
+			UserID:    e.UserID().String(),
+			TargetID:  e.TargetID().String(),
+			Transport: string(e.Transport()),
+			Actor:     e.Actor(),
+			CreatedAt: e.CreatedAt().Format(time.RFC3339Nano),
+		}
+	}
+
+	d.writeJSON(w, r, "audit", http.StatusOK, resp)
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/audit"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+	"github.com/sreway/shorturl/internal/usecases/shortener"
+)
+
+func Test_delivery_audit(t *testing.T) {
+	type want struct {
+		code int
+	}
+
+	type args struct {
+		uri string
+	}
+
+	type fields struct {
+		useCaseErr error
+		call       bool
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   want
+	}{
+		{
+			name: "positive get audit events",
+			args: args{
+				uri: "/api/internal/audit?from=2022-12-01T00:00:00Z&to=2022-12-02T00:00:00Z" +
+					"&user_id=624708fa-d258-4b99-b09a-49d95f294626",
+			},
+			fields: fields{
+				call: true,
+			},
+			want: want{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "negative get audit events (invalid time)",
+			args: args{
+				uri: "/api/internal/audit?from=yesterday",
+			},
+			want: want{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "negative get audit events (invalid user id)",
+			args: args{
+				uri: "/api/internal/audit?user_id=invalid",
+			},
+			fields: fields{
+				useCaseErr: shortener.ErrParseUUID,
+				call:       true,
+			},
+			want: want{
+				code: http.StatusBadRequest,
+			},
+		},
+	}
+
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	e := audit.NewEvent(uuid.New(), audit.ActionCreate, uuid.New(), uuid.New())
+	e.SetTransport(audit.TransportHTTP)
+	e.SetActor("192.168.88.1")
+	e.SetCreatedAt(time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC))
+
+	for _, tt := range tests {
+		uc := usecasesMock.NewMockShortener(ctl)
+		if tt.fields.call {
+			uc.EXPECT().GetAuditEvents(anyMock, anyMock, anyMock, anyMock, 0, 0).
+				Return([]audit.Event{e}, tt.fields.useCaseErr)
+		}
+		d := New(uc)
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, tt.args.uri, nil)
+			w := httptest.NewRecorder()
+			d.audit(w, request)
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.want.code, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package cookies
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrExpired implements expired cookie error.
+var ErrExpired = errors.New("cookie expired")
+
+// encryptedPrefix marks cookie values encrypted with AEAD.
+const encryptedPrefix = "e1"
+
+// Claims describes the payload of the encrypted cookie.
+type Claims struct {
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// Expired implements checking the expiry claim.
+func (c Claims) Expired(now time.Time) bool {
+	return c.ExpiresAt != 0 && now.Unix() >= c.ExpiresAt
+}
+
+// Renewable implements checking that less than half of the max age remains until the expiry claim,
+// such cookies are re-issued with the new expiry, so the clients in use keep their cookie.
+func (c Claims) Renewable(now time.Time, maxAge time.Duration) bool {
+	return c.ExpiresAt != 0 && time.Unix(c.ExpiresAt, 0).Sub(now) < maxAge/2
+}
+
+// Read implements extracting the cookie claims regardless of its format.
+//
+// Encrypted cookies are preferred, signed cookies are still accepted and reported as stale
+// so that they are re-issued in the encrypted format, their claims hold the subject only.
+func Read(r *http.Request, name string, keys *Keyring) (claims Claims, stale bool, err error) {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return claims, false, ErrNotFound
+	}
+
+	if !strings.HasPrefix(cookie.Value, encryptedPrefix+".") {
+		claims.Subject, _, err = ReadSigned(r, name, keys)
+		return claims, err == nil, err
+	}
+
+	return ReadEncrypted(r, name, keys)
+}
+
+// ReadEncrypted implements decrypting and validating claims from a cookie.
+func ReadEncrypted(r *http.Request, name string, keys *Keyring) (claims Claims, stale bool, err error) {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return claims, false, ErrNotFound
+	}
+
+	prefix, kid, payload, ok := splitValue(cookie.Value)
+	if !ok || prefix != encryptedPrefix {
+		return claims, false, ErrInvalidValue
+	}
+
+	k, ok := keys.lookup(kid)
+	if !ok {
+		return claims, false, ErrUnknownKey
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return claims, false, ErrInvalidValue
+	}
+
+	aead, err := newAEAD(k)
+	if err != nil {
+		return claims, false, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return claims, false, ErrInvalidValue
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData(name, k))
+	if err != nil {
+		return claims, false, ErrInvalidValue
+	}
+
+	if err = json.Unmarshal(plaintext, &claims); err != nil {
+		return claims, false, ErrInvalidValue
+	}
+
+	if claims.Expired(time.Now()) {
+		return claims, false, ErrExpired
+	}
+
+	return claims, k.id != keys.primary().id, nil
+}
+
+// WriteEncrypted implements cookie encryption.
+//
+// The cookie value becomes the subject claim, the expiry claim follows the cookie Max-Age.
+func WriteEncrypted(w http.ResponseWriter, cookie http.Cookie, keys *Keyring) error {
+	k := keys.primary()
+	now := time.Now()
+
+	claims := Claims{
+		Subject:  cookie.Value,
+		IssuedAt: now.Unix(),
+	}
+	if cookie.MaxAge > 0 {
+		claims.ExpiresAt = now.Add(time.Duration(cookie.MaxAge) * time.Second).Unix()
+	}
+
+	plaintext, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+
+	aead, err := newAEAD(k)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, additionalData(cookie.Name, k))
+	cookie.Value = strings.Join([]string{
+		encryptedPrefix, k.id, base64.RawURLEncoding.EncodeToString(sealed),
+	}, ".")
+	if len(cookie.Path) == 0 {
+		cookie.Path = "/"
+	}
+	http.SetCookie(w, &cookie)
+	return nil
+}
+
+// newAEAD implements the creation of AES-GCM cipher for the key.
+func newAEAD(k key) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(k.encryption[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// additionalData implements binding the ciphertext to the cookie name and key ID.
+func additionalData(name string, k key) []byte {
+	return []byte(name + "." + k.id)
+}
@@ -0,0 +1,137 @@
+package cookies
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	type args struct {
+		encrypted bool
+		maxAge    int
+		writeKeys []string
+		readKeys  []string
+		tamper    bool
+	}
+	type want struct {
+		stale bool
+		err   error
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "positive read encrypted",
+			args: args{
+				encrypted: true,
+				maxAge:    60,
+				writeKeys: []string{"new"},
+				readKeys:  []string{"new"},
+			},
+		},
+		{
+			name: "positive read encrypted (rotated key)",
+			args: args{
+				encrypted: true,
+				writeKeys: []string{"old"},
+				readKeys:  []string{"new", "old"},
+			},
+			want: want{stale: true},
+		},
+		{
+			name: "positive read signed (backward compatibility)",
+			args: args{
+				writeKeys: []string{"new"},
+				readKeys:  []string{"new"},
+			},
+			want: want{stale: true},
+		},
+		{
+			name: "negative read encrypted (tampered value)",
+			args: args{
+				encrypted: true,
+				writeKeys: []string{"new"},
+				readKeys:  []string{"new"},
+				tamper:    true,
+			},
+			want: want{err: ErrInvalidValue},
+		},
+		{
+			name: "negative read encrypted (retired key)",
+			args: args{
+				encrypted: true,
+				writeKeys: []string{"retired"},
+				readKeys:  []string{"new"},
+			},
+			want: want{err: ErrUnknownKey},
+		},
+	}
+
+	name := "user_id"
+	value := "624708fa-d258-4b99-b09a-49d95f294626"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeKeys, err := NewKeyring(tt.args.writeKeys...)
+			assert.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			cookie := http.Cookie{Name: name, Value: value, MaxAge: tt.args.maxAge}
+			if tt.args.encrypted {
+				assert.NoError(t, WriteEncrypted(w, cookie, writeKeys))
+			} else {
+				WriteSigned(w, cookie, writeKeys)
+			}
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			issued := resp.Cookies()[0]
+			assert.NotContains(t, issued.Value, value)
+			if tt.args.tamper {
+				issued.Value = issued.Value[:len(issued.Value)-4] + "AAAA"
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.AddCookie(issued)
+
+			readKeys, err := NewKeyring(tt.args.readKeys...)
+			assert.NoError(t, err)
+
+			got, stale, err := Read(r, name, readKeys)
+			if tt.want.err != nil {
+				assert.ErrorIs(t, err, tt.want.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, value, got.Subject)
+			assert.Equal(t, tt.want.stale, stale)
+		})
+	}
+}
+
+func TestReadEncrypted_expired(t *testing.T) {
+	keys, err := NewKeyring("secret")
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	assert.NoError(t, WriteEncrypted(w, http.Cookie{Name: "user_id", Value: "id", MaxAge: 1}, keys))
+	resp := w.Result()
+	defer resp.Body.Close()
+	issued := resp.Cookies()[0]
+	assert.True(t, strings.HasPrefix(issued.Value, encryptedPrefix+"."))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(issued)
+
+	claims, _, err := ReadEncrypted(r, "user_id", keys)
+	assert.NoError(t, err)
+	assert.NotZero(t, claims.ExpiresAt)
+	assert.True(t, claims.Expired(time.Unix(claims.ExpiresAt, 0)))
+	assert.False(t, claims.Expired(time.Unix(claims.IssuedAt, 0)))
+}
@@ -0,0 +1,70 @@
+package cookies
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// encryptionContext separates the derived encryption key from the signing secret.
+const encryptionContext = "cookie encryption key"
+
+// key describes a cookie secret key with its ID.
+type key struct {
+	id         string
+	secret     []byte
+	encryption [sha256.Size]byte
+}
+
+// Keyring implements an ordered set of cookie secret keys, the newest first.
+type Keyring struct {
+	keys []key
+	ids  map[string]key
+}
+
+// primary implements getting the key used for issuing new cookies.
+func (kr *Keyring) primary() key {
+	return kr.keys[0]
+}
+
+// lookup implements getting key by ID.
+func (kr *Keyring) lookup(id string) (key, bool) {
+	k, ok := kr.ids[id]
+	return k, ok
+}
+
+// keyID implements the derivation of a stable key ID from the secret.
+func keyID(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:4])
+}
+
+// NewKeyring implements the creation of the keyring.
+//
+// Secrets are ordered from the newest to the oldest, empty values are skipped.
+func NewKeyring(secrets ...string) (*Keyring, error) {
+	kr := &Keyring{
+		ids: make(map[string]key, len(secrets)),
+	}
+
+	for _, secret := range secrets {
+		if len(secret) == 0 {
+			continue
+		}
+		k := key{
+			id:         keyID(secret),
+			secret:     []byte(secret),
+			encryption: sha256.Sum256([]byte(encryptionContext + secret)),
+		}
+		if _, ok := kr.ids[k.id]; ok {
+			continue
+		}
+		kr.keys = append(kr.keys, k)
+		kr.ids[k.id] = k
+	}
+
+	if len(kr.keys) == 0 {
+		return nil, ErrEmptyKeyring
+	}
+
+	return kr, nil
+}
@@ -1,4 +1,4 @@
-// Package cookies implements middleware for signing cookies.
+// Package cookies implements signing and encryption of http cookies.
 package cookies
 
 import (
@@ -7,6 +7,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"net/http"
+	"strings"
 )
 
 // ErrNotFound implements cookie not found error.
@@ -15,46 +16,105 @@ var ErrNotFound = errors.New("cookie not found")
 // ErrInvalidValue implements invalid cookie value error.
 var ErrInvalidValue = errors.New("invalid cookie value")
 
+// ErrUnknownKey implements unknown cookie signing key error.
+var ErrUnknownKey = errors.New("unknown cookie key")
+
+// ErrEmptyKeyring implements empty cookie keyring error.
+var ErrEmptyKeyring = errors.New("empty cookie keyring")
+
+// signedPrefix marks cookie values that carry a key ID.
+const signedPrefix = "s1"
+
 // ReadSigned implements extracting signature from a cookie.
-func ReadSigned(r *http.Request, name string, secretKey string) (string, error) {
+//
+// The returned stale flag reports that the cookie was signed with a key other than
+// the primary one (or in the legacy format without key ID) and should be re-issued.
+func ReadSigned(r *http.Request, name string, keys *Keyring) (value string, stale bool, err error) {
 	cookie, err := r.Cookie(name)
 	if err != nil {
-		return "", ErrNotFound
+		return "", false, ErrNotFound
+	}
+
+	prefix, kid, payload, ok := splitValue(cookie.Value)
+	if !ok {
+		return readLegacy(name, cookie.Value, keys)
+	}
+
+	if prefix != signedPrefix {
+		return "", false, ErrInvalidValue
+	}
+
+	k, ok := keys.lookup(kid)
+	if !ok {
+		return "", false, ErrUnknownKey
 	}
 
-	encodedValue, err := base64.URLEncoding.DecodeString(cookie.Value)
+	value, err = verify(name, payload, k)
 	if err != nil {
-		return "", ErrInvalidValue
+		return "", false, err
 	}
 
-	signedValue := string(encodedValue)
+	return value, k.id != keys.primary().id, nil
+}
+
+// WriteSigned implements cookie signing.
+func WriteSigned(w http.ResponseWriter, cookie http.Cookie, keys *Keyring) {
+	k := keys.primary()
+	cookie.Value = strings.Join([]string{signedPrefix, k.id, sign(cookie.Name, cookie.Value, k)}, ".")
+	if len(cookie.Path) == 0 {
+		cookie.Path = "/"
+	}
+	http.SetCookie(w, &cookie)
+}
+
+// readLegacy implements verification of cookies issued before key IDs were introduced.
+func readLegacy(name, raw string, keys *Keyring) (string, bool, error) {
+	for _, k := range keys.keys {
+		value, err := verify(name, raw, k)
+		if err == nil {
+			return value, true, nil
+		}
+	}
+	return "", false, ErrInvalidValue
+}
+
+// splitValue implements splitting cookie value into format prefix, key ID and payload.
+func splitValue(raw string) (prefix, kid, payload string, ok bool) {
+	parts := strings.SplitN(raw, ".", 3)
+	if len(parts) != 3 {
+		return "", "", "", false
+	}
+	return parts[0], parts[1], parts[2], true
+}
+
+// sign implements encoding of the signed cookie payload.
+func sign(name, value string, k key) string {
+	mac := hmac.New(sha256.New, k.secret)
+	mac.Write([]byte(name))
+	mac.Write([]byte(value))
+	return base64.URLEncoding.EncodeToString(append(mac.Sum(nil), value...))
+}
+
+// verify implements decoding and signature verification of the signed cookie payload.
+func verify(name, payload string, k key) (string, error) {
+	signedValue, err := base64.URLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", ErrInvalidValue
+	}
 
 	if len(signedValue) < sha256.Size {
 		return "", ErrInvalidValue
 	}
 
-	sign := signedValue[:sha256.Size]
+	signature := signedValue[:sha256.Size]
 	value := signedValue[sha256.Size:]
 
-	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac := hmac.New(sha256.New, k.secret)
 	mac.Write([]byte(name))
-	mac.Write([]byte(value))
-	expectedSign := mac.Sum(nil)
-	if !hmac.Equal([]byte(sign), expectedSign) {
+	mac.Write(value)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
 		return "", ErrInvalidValue
 	}
 
-	return value, nil
-}
-
-// WriteSigned implements cookie signing.
-func WriteSigned(w http.ResponseWriter, cookie http.Cookie, secretKey string) {
-	mac := hmac.New(sha256.New, []byte(secretKey))
-	mac.Write([]byte(cookie.Name))
-	mac.Write([]byte(cookie.Value))
-	sign := mac.Sum(nil)
-	cookie.Value = string(sign) + cookie.Value
-	cookie.Value = base64.URLEncoding.EncodeToString([]byte(cookie.Value))
-	cookie.Path = "/"
-	http.SetCookie(w, &cookie)
+	return string(value), nil
 }
@@ -0,0 +1,137 @@
+package cookies
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeLegacy implements cookie signing in the format used before key IDs were introduced.
+func writeLegacy(w http.ResponseWriter, cookie http.Cookie, secretKey string) {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(cookie.Name))
+	mac.Write([]byte(cookie.Value))
+	cookie.Value = base64.URLEncoding.EncodeToString(append(mac.Sum(nil), cookie.Value...))
+	http.SetCookie(w, &cookie)
+}
+
+func TestReadSigned(t *testing.T) {
+	type args struct {
+		writeKeys []string
+		readKeys  []string
+		legacy    bool
+		tamper    bool
+	}
+	type want struct {
+		stale bool
+		err   error
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "positive read signed (primary key)",
+			args: args{
+				writeKeys: []string{"new"},
+				readKeys:  []string{"new", "old"},
+			},
+		},
+		{
+			name: "positive read signed (rotated key)",
+			args: args{
+				writeKeys: []string{"old"},
+				readKeys:  []string{"new", "old"},
+			},
+			want: want{stale: true},
+		},
+		{
+			name: "positive read signed (legacy format)",
+			args: args{
+				writeKeys: []string{"old"},
+				readKeys:  []string{"new", "old"},
+				legacy:    true,
+			},
+			want: want{stale: true},
+		},
+		{
+			name: "negative read signed (retired key)",
+			args: args{
+				writeKeys: []string{"retired"},
+				readKeys:  []string{"new", "old"},
+			},
+			want: want{err: ErrUnknownKey},
+		},
+		{
+			name: "negative read signed (legacy format, retired key)",
+			args: args{
+				writeKeys: []string{"retired"},
+				readKeys:  []string{"new", "old"},
+				legacy:    true,
+			},
+			want: want{err: ErrInvalidValue},
+		},
+		{
+			name: "negative read signed (tampered value)",
+			args: args{
+				writeKeys: []string{"new"},
+				readKeys:  []string{"new"},
+				tamper:    true,
+			},
+			want: want{err: ErrInvalidValue},
+		},
+	}
+
+	name := "user_id"
+	value := "624708fa-d258-4b99-b09a-49d95f294626"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if tt.args.legacy {
+				writeLegacy(w, http.Cookie{Name: name, Value: value}, tt.args.writeKeys[0])
+			} else {
+				writeKeys, err := NewKeyring(tt.args.writeKeys...)
+				assert.NoError(t, err)
+				WriteSigned(w, http.Cookie{Name: name, Value: value}, writeKeys)
+			}
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			cookie := resp.Cookies()[0]
+			if tt.args.tamper {
+				cookie.Value = cookie.Value[:len(cookie.Value)-4] + "AAAA"
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.AddCookie(cookie)
+
+			readKeys, err := NewKeyring(tt.args.readKeys...)
+			assert.NoError(t, err)
+
+			got, stale, err := ReadSigned(r, name, readKeys)
+			if tt.want.err != nil {
+				assert.ErrorIs(t, err, tt.want.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, value, got)
+			assert.Equal(t, tt.want.stale, stale)
+		})
+	}
+}
+
+func TestNewKeyring(t *testing.T) {
+	_, err := NewKeyring("", "")
+	assert.ErrorIs(t, err, ErrEmptyKeyring)
+
+	kr, err := NewKeyring("new", "old", "new")
+	assert.NoError(t, err)
+	assert.Len(t, kr.keys, 2)
+	assert.Equal(t, keyID("new"), kr.primary().id)
+}
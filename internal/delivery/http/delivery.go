@@ -11,6 +11,7 @@ import (
 	"golang.org/x/exp/slog"
 
 	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/delivery/http/cookies"
 	"github.com/sreway/shorturl/internal/usecases"
 )
 
@@ -19,6 +20,10 @@ type (
 		shortener usecases.Shortener
 		router    *chi.Mux
 		logger    *slog.Logger
+		keys      *cookies.Keyring
+		identity  http.Cookie
+		session   http.Cookie
+		sso       *sso
 	}
 )
 
@@ -36,6 +41,19 @@ func New(uc usecases.Shortener) *delivery {
 // Run implements run http server.
 func (d *delivery) Run(ctx context.Context, config config.HTTP) error {
 	var err error
+	d.keys, err = cookies.NewKeyring(config.GetCookie().GetSecretKeys()...)
+	if err != nil {
+		return err
+	}
+
+	cookieCfg := config.GetCookie()
+	d.identity = newCookie(config, cookieCfg.SignID, cookieCfg.MaxAge)
+	d.session = newCookie(config, cookieCfg.SessionName, cookieCfg.SessionMaxAge)
+
+	if config.GetOIDC().Enabled() {
+		d.sso = newSSO(config.GetOIDC())
+	}
+
 	d.router = d.initRouter(config)
 	httpServer := &http.Server{
 		Addr:    config.GetAddress(),
@@ -24,6 +24,12 @@ var ErrTrustedSubnetNotSetup = errors.New("trusted subnet not setup")
 // ErrEmptyRealIPHeader implements missing X-Real-IP header.
 var ErrEmptyRealIPHeader = errors.New("missing X-Real-IP header")
 
+// ErrOIDCState implements invalid OpenID Connect authorization state error.
+var ErrOIDCState = errors.New("invalid oidc state")
+
+// ErrOIDCToken implements invalid OpenID Connect token error.
+var ErrOIDCToken = errors.New("invalid oidc token")
+
 // errRender implements renderer interface for managing response payloads.
 func errRender(statusCode int, err error) render.Renderer {
 	return &errResponse{
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/exp/slog"
+)
+
+// job godoc
+// @Summary get job status
+// @Description get the state of the asynchronous job of the user with the outcome for every short URL
+// @Description (pending, deleted, not_found, not_owned)
+// @ID job
+// @Produce application/json
+// @Param id path string true "job id"
+// @Success 200 {object} jobResponse
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/jobs/{id} [get]
+func (d *delivery) job(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "job"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	item, err := d.shortener.GetJob(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		d.logger.Error("failed get job", err, slog.String("handler", "job"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	resp := jobResponse{
+		ID:        item.ID.String(),
+		Action:    string(item.Action),
+		Status:    string(item.Status),
+		Attempts:  item.Attempts,
+		CreatedAt: item.CreatedAt.Format(time.RFC3339),
+		URLs:      make([]jobURLResponse, len(item.URLs)),
+	}
+	for idx, u := range item.URLs {
+		resp.URLs[idx] = jobURLResponse{
+			ID:       u.ID,
+			ShortURL: u.ShortURL,
+			Outcome:  string(u.Outcome),
+		}
+	}
+
+	d.writeJSON(w, r, "job", http.StatusOK, resp)
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+	"github.com/sreway/shorturl/internal/usecases/shortener"
+)
+
+func Test_delivery_job(t *testing.T) {
+	type want struct {
+		code int
+	}
+
+	type fields struct {
+		useCaseErr error
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "positive get job",
+			want: want{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "negative get job (not found)",
+			fields: fields{
+				useCaseErr: task.ErrNotFound,
+			},
+			want: want{
+				code: http.StatusNotFound,
+			},
+		},
+		{
+			name: "negative get job (invalid id)",
+			fields: fields{
+				useCaseErr: shortener.ErrParseUUID,
+			},
+			want: want{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "negative get job (not supported)",
+			fields: fields{
+				useCaseErr: shortener.ErrJobsNotSupported,
+			},
+			want: want{
+				code: http.StatusNotImplemented,
+			},
+		},
+	}
+
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	userID := "624708fa-d258-4b99-b09a-49d95f294626"
+	job := task.Job{
+		ID:        uuid.MustParse("3f1c2a9e-8d2b-4b6f-9a51-0c7e4d2f1b88"),
+		Action:    task.ActionDelete,
+		Status:    task.StatusDone,
+		Attempts:  1,
+		CreatedAt: time.Now(),
+		URLs: []task.JobURL{
+			{ID: "2ZrI5IHFnvPscPYKlxFtRQ", ShortURL: "http://127.0.0.1:8080/2ZrI5IHFnvPscPYKlxFtRQ", Outcome: task.OutcomeDeleted},
+			{ID: "5nPymsbLZfXlsUDlZ4MIhY", ShortURL: "http://127.0.0.1:8080/5nPymsbLZfXlsUDlZ4MIhY", Outcome: task.OutcomeNotOwned},
+		},
+	}
+
+	for _, tt := range tests {
+		uc := usecasesMock.NewMockShortener(ctl)
+		uc.EXPECT().GetJob(anyMock, userID, job.ID.String()).Return(job, tt.fields.useCaseErr).AnyTimes()
+		d := New(uc)
+		router := chi.NewRouter()
+		router.Get("/api/user/jobs/{id}", d.job)
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/user/jobs/"+job.ID.String(), nil)
+			request = request.WithContext(context.WithValue(request.Context(), ctxKeyUserID{}, userID))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, request)
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.want.code, resp.StatusCode)
+			if tt.fields.useCaseErr != nil {
+				return
+			}
+
+			got := new(jobResponse)
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(got))
+			assert.Equal(t, "done", got.Status)
+			assert.Len(t, got.URLs, 2)
+			assert.Equal(t, "deleted", got.URLs[0].Outcome)
+			assert.Equal(t, "not_owned", got.URLs[1].Outcome)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package http
+
+import (
+	"expvar"
+	"net/http"
+)
+
+// metrics godoc
+// @Summary runtime and background jobs metrics
+// @Description published expvar variables, the jobs variable holds the task queue depth
+// @Description and the counters of processed, failed, retried and dead tasks and of job runs
+// @ID metrics
+// @Produce application/json
+// @Success 200
+// @Failure 403 {object} errResponse
+// @Router /internal/metrics [get]
+func (d *delivery) metrics(w http.ResponseWriter, r *http.Request) {
+	expvar.Handler().ServeHTTP(w, r)
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -13,16 +15,48 @@ import (
 
 	"github.com/sreway/shorturl/internal/config"
 	"github.com/sreway/shorturl/internal/delivery/http/cookies"
+	"github.com/sreway/shorturl/internal/domain/audit"
 )
 
 // ctxKeyUserID describes the type context value of the user ID.
 type ctxKeyUserID struct{}
 
+// ctxKeyIdentityID describes the type context value of the cookie identity ID,
+// it differs from the user ID when the account session is active.
+type ctxKeyIdentityID struct{}
+
 // useMiddleware implements middleware connection.
 func (d *delivery) useMiddleware(http config.HTTP, r chi.Router) {
 	r.Use(middleware.Compress(http.GetCompressLevel(), http.GetCompressTypes()...))
 	r.Use(decodeGZIP)
-	r.Use(signCookie(http.GetCookie().SignID, http.GetCookie().SecretKey))
+	r.Use(auditTransport)
+	r.Use(signCookie(d.identity, d.keys))
+	r.Use(session(d.session, d.keys))
+}
+
+// newCookie implements the creation of the cookie template from configuration.
+func newCookie(cfg config.HTTP, name string, maxAge time.Duration) http.Cookie {
+	c := cfg.GetCookie()
+	cookie := http.Cookie{
+		Name:     name,
+		Path:     "/",
+		MaxAge:   int(maxAge.Seconds()),
+		Secure:   c.Secure,
+		HttpOnly: c.HTTPOnly,
+	}
+
+	switch strings.ToLower(c.SameSite) {
+	case "lax":
+		cookie.SameSite = http.SameSiteLaxMode
+	case "strict":
+		cookie.SameSite = http.SameSiteStrictMode
+	case "none":
+		cookie.SameSite = http.SameSiteNoneMode
+	default:
+		cookie.SameSite = http.SameSiteDefaultMode
+	}
+
+	return cookie
 }
 
 // decodeGZIP implements compression middleware.
@@ -39,27 +73,76 @@ func decodeGZIP(next http.Handler) http.Handler {
 	})
 }
 
-// signCookie implements sign cookie middleware.
-func signCookie(name string, secretKey string) func(next http.Handler) http.Handler {
+// auditTransport implements the middleware marking the operations as received over http for the audit log,
+// the remote address identifies the operation until the trusted subnet middleware checks the real IP.
+func auditTransport(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			actor = r.RemoteAddr
+		}
+		ctx := audit.WithActor(audit.WithTransport(r.Context(), audit.TransportHTTP), actor)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// signCookie implements identity cookie middleware.
+//
+// Cookies are issued encrypted, signed cookies of previous releases are accepted and re-issued.
+// Cookies with less than half of the max age left are re-issued with the new expiry, so the identity
+// of the user in use does not expire.
+func signCookie(template http.Cookie, keys *cookies.Keyring) func(next http.Handler) http.Handler {
+	maxAge := time.Duration(template.MaxAge) * time.Second
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			val, err := cookies.ReadSigned(r, name, secretKey)
+			claims, stale, err := cookies.Read(r, template.Name, keys)
+			val := claims.Subject
 			if err != nil {
-				id := uuid.New()
-				cookie := http.Cookie{
-					Name:  name,
-					Value: id.String(),
+				val = uuid.New().String()
+			}
+			if err != nil || stale || claims.Renewable(time.Now(), maxAge) {
+				cookie := template
+				cookie.Value = val
+				if err = cookies.WriteEncrypted(w, cookie, keys); err != nil {
+					err = render.Render(w, r, errRender(http.StatusInternalServerError, ErrInternalServer))
+					if err != nil {
+						http.Error(w, err.Error(), http.StatusInternalServerError)
+					}
+					return
 				}
-				cookies.WriteSigned(w, cookie, secretKey)
-				val = id.String()
 			}
 			ctx := context.WithValue(r.Context(), ctxKeyUserID{}, val)
+			ctx = context.WithValue(ctx, ctxKeyIdentityID{}, val)
 			r = r.WithContext(ctx)
 			next.ServeHTTP(w, r)
 		})
 	}
 }
 
+// session implements account session middleware.
+//
+// The user ID of the request is replaced with the account ID when the session cookie is valid.
+func session(template http.Cookie, keys *cookies.Keyring) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, stale, err := cookies.ReadEncrypted(r, template.Name, keys)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if stale {
+				cookie := template
+				cookie.Value = claims.Subject
+				_ = cookies.WriteEncrypted(w, cookie, keys)
+			}
+
+			ctx := context.WithValue(r.Context(), ctxKeyUserID{}, claims.Subject)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 // trustedSubnet implements validate trusted subnet middleware.
 func trustedSubnet(subnet *net.IPNet) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -92,7 +175,7 @@ func trustedSubnet(subnet *net.IPNet) func(next http.Handler) http.Handler {
 
 				return
 			}
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(w, r.WithContext(audit.WithActor(r.Context(), rip)))
 		})
 	}
 }
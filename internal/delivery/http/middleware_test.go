@@ -0,0 +1,157 @@
+package http
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/delivery/http/cookies"
+	"github.com/sreway/shorturl/internal/domain/audit"
+)
+
+func Test_auditTransport(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("192.168.88.0/24")
+
+	tests := []struct {
+		name      string
+		subnet    *net.IPNet
+		wantActor string
+	}{
+		{
+			name:      "positive audit transport (remote address)",
+			wantActor: "10.0.0.5",
+		},
+		{
+			name:      "positive audit transport (trusted subnet real ip)",
+			subnet:    subnet,
+			wantActor: "192.168.88.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actor string
+			var transport audit.Transport
+			var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor = audit.ActorFromContext(r.Context())
+				transport = audit.TransportFromContext(r.Context())
+			})
+			if tt.subnet != nil {
+				handler = trustedSubnet(tt.subnet)(handler)
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/api/internal/audit", nil)
+			request.RemoteAddr = "10.0.0.5:41234"
+			request.Header.Set("X-Real-IP", "192.168.88.1")
+			auditTransport(handler).ServeHTTP(httptest.NewRecorder(), request)
+
+			assert.Equal(t, audit.TransportHTTP, transport)
+			assert.Equal(t, tt.wantActor, actor)
+		})
+	}
+}
+
+func Test_signCookie(t *testing.T) {
+	type args struct {
+		// maxAge defines the max age the request cookie was issued with, zero sends no cookie.
+		maxAge  int
+		signed  bool
+		invalid bool
+	}
+	type want struct {
+		reissued    bool
+		newIdentity bool
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "positive identity cookie (fresh)",
+			args: args{maxAge: 1000},
+		},
+		{
+			name: "positive identity cookie (less than half of max age left)",
+			args: args{maxAge: 400},
+			want: want{reissued: true},
+		},
+		{
+			name: "positive identity cookie (signed by previous release)",
+			args: args{signed: true},
+			want: want{reissued: true},
+		},
+		{
+			name: "positive identity cookie (missing)",
+			want: want{reissued: true, newIdentity: true},
+		},
+		{
+			name: "negative identity cookie (invalid value)",
+			args: args{maxAge: 1000, invalid: true},
+			want: want{reissued: true, newIdentity: true},
+		},
+	}
+
+	keys, err := cookies.NewKeyring("secret")
+	assert.NoError(t, err)
+	template := http.Cookie{Name: "user_id", Path: "/", MaxAge: 1000}
+	userID := "624708fa-d258-4b99-b09a-49d95f294626"
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.args.maxAge > 0 || tt.args.signed {
+				issued := httptest.NewRecorder()
+				cookie := http.Cookie{Name: template.Name, Value: userID, MaxAge: tt.args.maxAge}
+				if tt.args.signed {
+					cookies.WriteSigned(issued, cookie, keys)
+				} else {
+					assert.NoError(t, cookies.WriteEncrypted(issued, cookie, keys))
+				}
+				resp := issued.Result()
+				_ = resp.Body.Close()
+				c := resp.Cookies()[0]
+				if tt.args.invalid {
+					c.Value = c.Value[:len(c.Value)-4] + "AAAA"
+				}
+				request.AddCookie(c)
+			}
+
+			var got string
+			handler := signCookie(template, keys)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = r.Context().Value(ctxKeyUserID{}).(string)
+			}))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, request)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if tt.want.newIdentity {
+				assert.NotEqual(t, userID, got)
+			} else {
+				assert.Equal(t, userID, got)
+			}
+
+			if !tt.want.reissued {
+				assert.Empty(t, resp.Cookies())
+				return
+			}
+			assert.Len(t, resp.Cookies(), 1)
+			assert.Equal(t, template.MaxAge, resp.Cookies()[0].MaxAge)
+
+			// the re-issued cookie holds the identity with the expiry of the full max age
+			next := httptest.NewRequest(http.MethodGet, "/", nil)
+			next.AddCookie(resp.Cookies()[0])
+			claims, stale, err := cookies.ReadEncrypted(next, template.Name, keys)
+			assert.NoError(t, err)
+			assert.False(t, stale)
+			assert.Equal(t, got, claims.Subject)
+			assert.False(t, claims.Renewable(time.Now(), time.Duration(template.MaxAge)*time.Second))
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/exp/slog"
+	"golang.org/x/oauth2"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/delivery/http/cookies"
+)
+
+// oidcFlowTTL defines the lifetime of the authorization request.
+const oidcFlowTTL = 10 * time.Minute
+
+type (
+	// sso implements OpenID Connect authorization code flow with PKCE.
+	sso struct {
+		cfg      config.OIDC
+		mu       sync.Mutex
+		provider *oidc.Provider
+	}
+
+	// oidcFlow describes the authorization request state kept in the encrypted cookie.
+	oidcFlow struct {
+		State    string `json:"state"`
+		Nonce    string `json:"nonce"`
+		Verifier string `json:"verifier"`
+	}
+)
+
+// newSSO implements the creation of OpenID Connect single sign-on.
+func newSSO(cfg config.OIDC) *sso {
+	return &sso{cfg: cfg}
+}
+
+// discover implements lazy OpenID Connect provider discovery, a failed discovery is retried on the next request.
+func (s *sso) discover() (*oidc.Provider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.provider != nil {
+		return s.provider, nil
+	}
+
+	// the provider keeps the context to refresh signing keys, so it must outlive the request
+	provider, err := oidc.NewProvider(context.Background(), s.cfg.GetIssuer())
+	if err != nil {
+		return nil, err
+	}
+
+	s.provider = provider
+	return provider, nil
+}
+
+// oauth2 implements the creation of the OAuth 2.0 client configuration.
+func (s *sso) oauth2(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.cfg.GetClientID(),
+		ClientSecret: s.cfg.GetClientSecret(),
+		RedirectURL:  s.cfg.GetRedirectURL(),
+		Endpoint:     provider.Endpoint(),
+		Scopes:       s.cfg.GetScopes(),
+	}
+}
+
+// randomString implements generating URL safe random string.
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// oidcLogin godoc
+// @Summary start single sign-on
+// @Description redirect to the OpenID Connect provider
+// @ID oidcLogin
+// @Success 302
+// @Failure 500 {object} errResponse
+// @Router /api/user/oidc/login [get]
+func (d *delivery) oidcLogin(w http.ResponseWriter, r *http.Request) {
+	provider, err := d.sso.discover()
+	if err != nil {
+		d.logger.Error("failed discover oidc provider", err, slog.String("handler", "oidcLogin"))
+		d.handelErrURL(w, r, ErrInternalServer)
+		return
+	}
+
+	flow := oidcFlow{}
+	for _, v := range []*string{&flow.State, &flow.Nonce, &flow.Verifier} {
+		if *v, err = randomString(); err != nil {
+			d.logger.Error("failed generate oidc flow", err, slog.String("handler", "oidcLogin"))
+			d.handelErrURL(w, r, ErrInternalServer)
+			return
+		}
+	}
+
+	data, err := json.Marshal(flow)
+	if err != nil {
+		d.logger.Error("failed marshal oidc flow", err, slog.String("handler", "oidcLogin"))
+		d.handelErrURL(w, r, ErrInternalServer)
+		return
+	}
+
+	cookie := d.oidcFlowCookie()
+	cookie.Value = string(data)
+	if err = cookies.WriteEncrypted(w, cookie, d.keys); err != nil {
+		d.logger.Error("failed write oidc flow cookie", err, slog.String("handler", "oidcLogin"))
+		d.handelErrURL(w, r, ErrInternalServer)
+		return
+	}
+
+	challenge := sha256.Sum256([]byte(flow.Verifier))
+	authURL := d.sso.oauth2(provider).AuthCodeURL(flow.State,
+		oidc.Nonce(flow.Nonce),
+		oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// oidcCallback godoc
+// @Summary complete single sign-on
+// @Description exchange the authorization code and start the session, short URLs of the current cookie identity are moved to the user
+// @ID oidcCallback
+// @Produce application/json
+// @Param code query string true "authorization code"
+// @Param state query string true "authorization state"
+// @Success 200 {object} accountResponse
+// @Failure 401 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /api/user/oidc/callback [get]
+func (d *delivery) oidcCallback(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	identityID, ok := r.Context().Value(ctxKeyIdentityID{}).(string)
+	if !ok {
+		d.logger.Error("invalid identity id", ErrInvalidRequest,
+			slog.String("identityID", identityID), slog.String("handler", "oidcCallback"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	flowCookie := d.oidcFlowCookie()
+	claims, _, err := cookies.ReadEncrypted(r, flowCookie.Name, d.keys)
+	if err != nil {
+		d.logger.Error("failed read oidc flow cookie", err, slog.String("handler", "oidcCallback"))
+		d.handelErrURL(w, r, ErrOIDCState)
+		return
+	}
+
+	flowCookie.MaxAge = -1
+	http.SetCookie(w, &flowCookie)
+
+	flow := oidcFlow{}
+	if err = json.Unmarshal([]byte(claims.Subject), &flow); err != nil || flow.State != r.URL.Query().Get("state") {
+		d.logger.Error("invalid oidc state", ErrOIDCState, slog.String("handler", "oidcCallback"))
+		d.handelErrURL(w, r, ErrOIDCState)
+		return
+	}
+
+	if errCode := r.URL.Query().Get("error"); len(errCode) > 0 {
+		d.logger.Error("oidc provider error", ErrOIDCToken, slog.String("error", errCode),
+			slog.String("handler", "oidcCallback"))
+		d.handelErrURL(w, r, ErrOIDCToken)
+		return
+	}
+
+	provider, err := d.sso.discover()
+	if err != nil {
+		d.logger.Error("failed discover oidc provider", err, slog.String("handler", "oidcCallback"))
+		d.handelErrURL(w, r, ErrInternalServer)
+		return
+	}
+
+	token, err := d.sso.oauth2(provider).Exchange(r.Context(), r.URL.Query().Get("code"),
+		oauth2.SetAuthURLParam("code_verifier", flow.Verifier))
+	if err != nil {
+		d.logger.Error("failed exchange oidc code", err, slog.String("handler", "oidcCallback"))
+		d.handelErrURL(w, r, ErrOIDCToken)
+		return
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		d.logger.Error("missing oidc id token", ErrOIDCToken, slog.String("handler", "oidcCallback"))
+		d.handelErrURL(w, r, ErrOIDCToken)
+		return
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: d.sso.cfg.GetClientID()}).Verify(r.Context(), rawIDToken)
+	if err != nil || idToken.Nonce != flow.Nonce {
+		d.logger.Error("failed verify oidc id token", ErrOIDCToken, slog.String("handler", "oidcCallback"))
+		d.handelErrURL(w, r, ErrOIDCToken)
+		return
+	}
+
+	profile := struct {
+		Email string `json:"email"`
+	}{}
+	_ = idToken.Claims(&profile)
+
+	userID, err := d.shortener.SingleSignOn(r.Context(), idToken.Issuer, idToken.Subject, identityID)
+	if err != nil {
+		d.logger.Error("failed single sign-on", err, slog.String("handler", "oidcCallback"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	cookie := d.session
+	cookie.Value = userID
+	if err = cookies.WriteEncrypted(w, cookie, d.keys); err != nil {
+		d.logger.Error("failed write session cookie", err, slog.String("handler", "oidcCallback"))
+		d.handelErrURL(w, r, ErrInternalServer)
+		return
+	}
+
+	data, err := json.Marshal(accountResponse{ID: userID, Email: profile.Email})
+	if err != nil {
+		d.logger.Error("failed marshal response", err, slog.String("handler", "oidcCallback"))
+		d.handelErrURL(w, r, ErrInternalServer)
+		return
+	}
+
+	_, err = w.Write(data)
+	if err != nil {
+		d.logger.Error("write body", err, slog.String("handler", "oidcCallback"))
+		return
+	}
+}
+
+// oidcFlowCookie implements the creation of the authorization request cookie template.
+func (d *delivery) oidcFlowCookie() http.Cookie {
+	cookie := d.session
+	cookie.Name = "oidc_flow"
+	cookie.Path = "/api/user/oidc"
+	cookie.MaxAge = int(oidcFlowTTL.Seconds())
+	cookie.HttpOnly = true
+	cookie.SameSite = http.SameSiteLaxMode
+	return cookie
+}
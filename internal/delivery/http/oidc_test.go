@@ -0,0 +1,263 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/delivery/http/cookies"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+)
+
+type (
+	// mockProvider implements in-process OpenID Connect provider.
+	mockProvider struct {
+		*httptest.Server
+		t        *testing.T
+		key      *rsa.PrivateKey
+		clientID string
+		subject  string
+		mu       sync.Mutex
+		requests map[string]url.Values
+	}
+)
+
+func newMockProvider(t *testing.T, clientID, subject string) *mockProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	p := &mockProvider{
+		t:        t,
+		key:      key,
+		clientID: clientID,
+		subject:  subject,
+		requests: map[string]url.Values{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/jwks", p.jwks)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *mockProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"issuer":                                p.URL,
+		"authorization_endpoint":                p.URL + "/authorize",
+		"token_endpoint":                        p.URL + "/token",
+		"jwks_uri":                              p.URL + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (p *mockProvider) jwks(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &p.key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig"},
+	}})
+}
+
+func (p *mockProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	assert.Equal(p.t, p.clientID, query.Get("client_id"))
+	assert.Equal(p.t, "S256", query.Get("code_challenge_method"))
+
+	code := uuid.New().String()
+	p.mu.Lock()
+	p.requests[code] = query
+	p.mu.Unlock()
+
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+	assert.NoError(p.t, err)
+	values := redirect.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirect.RawQuery = values.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	assert.NoError(p.t, r.ParseForm())
+
+	p.mu.Lock()
+	query, ok := p.requests[r.PostForm.Get("code")]
+	delete(p.requests, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(challenge[:]) != query.Get("code_challenge") {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: p.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "test"))
+	assert.NoError(p.t, err)
+
+	now := time.Now()
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   p.URL,
+		"sub":   p.subject,
+		"aud":   p.clientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": query.Get("nonce"),
+		"email": "employee@example.com",
+	})
+	assert.NoError(p.t, err)
+
+	jws, err := signer.Sign(claims)
+	assert.NoError(p.t, err)
+	idToken, err := jws.CompactSerialize()
+	assert.NoError(p.t, err)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func Test_delivery_oidc(t *testing.T) {
+	type args struct {
+		state string
+	}
+	type want struct {
+		code    int
+		session bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "positive single sign-on",
+			want: want{
+				code:    http.StatusOK,
+				session: true,
+			},
+		},
+		{
+			name: "negative single sign-on (invalid state)",
+			args: args{
+				state: "invalid",
+			},
+			want: want{
+				code: http.StatusUnauthorized,
+			},
+		},
+	}
+
+	provider := newMockProvider(t, "shorturl", "employee-1")
+	defer provider.Close()
+
+	env := map[string]string{
+		"OIDC_ISSUER":    provider.URL,
+		"OIDC_CLIENT_ID": "shorturl",
+	}
+	for k, v := range env {
+		assert.NoError(t, os.Setenv(k, v))
+	}
+	defer func() {
+		for k := range env {
+			assert.NoError(t, os.Unsetenv(k))
+		}
+	}()
+
+	cfg, err := config.NewConfig()
+	assert.NoError(t, err)
+
+	keys, err := cookies.NewKeyring("secret_key")
+	assert.NoError(t, err)
+
+	anyMock := gomock.Any()
+	identityID := uuid.New().String()
+	userID := uuid.New().String()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	for _, tt := range tests {
+		uc := usecasesMock.NewMockShortener(ctl)
+		uc.EXPECT().SingleSignOn(anyMock, provider.URL, "employee-1", identityID).Return(userID, nil).AnyTimes()
+		d := New(uc)
+		d.keys = keys
+		d.session = http.Cookie{Name: "session"}
+		d.sso = newSSO(cfg.GetHTTP().GetOIDC())
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			d.oidcLogin(w, httptest.NewRequest(http.MethodGet, "/api/user/oidc/login", nil))
+			login := w.Result()
+			defer login.Body.Close()
+			assert.Equal(t, http.StatusFound, login.StatusCode)
+
+			authorize, err := client.Get(login.Header.Get("Location"))
+			assert.NoError(t, err)
+			defer authorize.Body.Close()
+			assert.Equal(t, http.StatusFound, authorize.StatusCode)
+
+			callbackURL, err := url.Parse(authorize.Header.Get("Location"))
+			assert.NoError(t, err)
+			if len(tt.args.state) > 0 {
+				values := callbackURL.Query()
+				values.Set("state", tt.args.state)
+				callbackURL.RawQuery = values.Encode()
+			}
+
+			request := httptest.NewRequest(http.MethodGet, callbackURL.String(), nil)
+			for _, c := range login.Cookies() {
+				request.AddCookie(c)
+			}
+			request = request.WithContext(context.WithValue(request.Context(), ctxKeyIdentityID{}, identityID))
+
+			w = httptest.NewRecorder()
+			d.oidcCallback(w, request)
+			callback := w.Result()
+			defer callback.Body.Close()
+			assert.Equal(t, tt.want.code, callback.StatusCode)
+
+			var session *http.Cookie
+			for _, c := range callback.Cookies() {
+				if c.Name == d.session.Name {
+					session = c
+				}
+			}
+			if !tt.want.session {
+				assert.Nil(t, session)
+				return
+			}
+
+			assert.NotNil(t, session)
+			request = httptest.NewRequest(http.MethodGet, "/", nil)
+			request.AddCookie(session)
+			claims, _, err := cookies.ReadEncrypted(request, d.session.Name, keys)
+			assert.NoError(t, err)
+			assert.Equal(t, userID, claims.Subject)
+		})
+	}
+}
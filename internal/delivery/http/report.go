@@ -0,0 +1,140 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/exp/slog"
+)
+
+// reportURL godoc
+// @Summary report short URL
+// @Description report the short URL abuse, the short URL is disabled when the report threshold is crossed
+// @ID reportURL
+// @Param id path string true "short URL id"
+// @Param report body reportRequest true "report reason"
+// @Success 202
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
+// @Failure 410 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /{id}/report [post]
+func (d *delivery) reportURL(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "reportURL"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	req := new(reportRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		d.logger.Error("failed decode request", err, slog.String("handler", "reportURL"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	if err := d.shortener.ReportURL(r.Context(), userID, chi.URLParam(r, "id"), req.Reason); err != nil {
+		d.logger.Error("failed report url", err, slog.String("handler", "reportURL"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// reports godoc
+// @Summary get moderation queue
+// @Description get reported short URLs with open reports, the most reported first
+// @ID reports
+// @Produce application/json
+// @Param limit query int false "maximum number of short URLs"
+// @Param offset query int false "number of short URLs to skip"
+// @Success 200 {object} []reportResponse
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/internal/admin/reports [get]
+func (d *delivery) reports(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	query := r.URL.Query()
+	var limit, offset int
+	for _, param := range []struct {
+		name  string
+		value *int
+	}{{"limit", &limit}, {"offset", &offset}} {
+		raw := query.Get(param.name)
+		if len(raw) == 0 {
+			continue
+		}
+
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			d.logger.Error("failed parse query parameter", err, slog.String("param", param.name),
+				slog.String("handler", "reports"))
+			d.handelErrURL(w, r, ErrInvalidRequest)
+			return
+		}
+		*param.value = value
+	}
+
+	queue, err := d.shortener.GetReportQueue(r.Context(), limit, offset)
+	if err != nil {
+		d.logger.Error("failed get report queue", err, slog.String("handler", "reports"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	resp := make([]reportResponse, len(queue))
+	for idx, item := range queue {
+		resp[idx] = reportResponse{
+			ShortURL:       item.ShortURL,
+			OriginalURL:    item.LongURL,
+			Disabled:       item.Disabled,
+			Flagged:        item.Flagged,
+			Count:          item.Count,
+			Reasons:        item.Reasons,
+			LastReportedAt: item.LastReportedAt.Format(time.RFC3339),
+		}
+	}
+
+	d.writeJSON(w, r, "reports", http.StatusOK, resp)
+}
+
+// resolveReports godoc
+// @Summary resolve reports of short URL
+// @Description close open reports of the short URL and disable or enable it according to the moderator decision
+// @ID resolveReports
+// @Param id path string true "short URL id"
+// @Param decision body resolveRequest true "moderator decision"
+// @Success 204
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/internal/admin/reports/{id}/resolve [post]
+func (d *delivery) resolveReports(w http.ResponseWriter, r *http.Request) {
+	req := new(resolveRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		d.logger.Error("failed decode request", err, slog.String("handler", "resolveReports"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	if err := d.shortener.ResolveReports(r.Context(), chi.URLParam(r, "id"), req.Disable); err != nil {
+		d.logger.Error("failed resolve reports", err, slog.String("handler", "resolveReports"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
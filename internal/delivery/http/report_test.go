@@ -0,0 +1,109 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/report"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+	"github.com/sreway/shorturl/internal/usecases/shortener"
+)
+
+func Test_delivery_reportURL(t *testing.T) {
+	type want struct {
+		code int
+	}
+
+	type args struct {
+		body   string
+		userID string
+	}
+
+	type fields struct {
+		useCaseErr error
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   want
+	}{
+		{
+			name: "positive report url",
+			args: args{
+				body:   `{"reason": "phishing"}`,
+				userID: "624708fa-d258-4b99-b09a-49d95f294626",
+			},
+			want: want{
+				code: http.StatusAccepted,
+			},
+		},
+		{
+			name: "negative report url (invalid reason)",
+			args: args{
+				body:   `{"reason": ""}`,
+				userID: "624708fa-d258-4b99-b09a-49d95f294626",
+			},
+			fields: fields{
+				useCaseErr: shortener.ErrInvalidReason,
+			},
+			want: want{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "negative report url (already reported)",
+			args: args{
+				body:   `{"reason": "phishing"}`,
+				userID: "624708fa-d258-4b99-b09a-49d95f294626",
+			},
+			fields: fields{
+				useCaseErr: report.ErrAlreadyExist,
+			},
+			want: want{
+				code: http.StatusConflict,
+			},
+		},
+		{
+			name: "negative report url (invalid body)",
+			args: args{
+				body:   "invalid",
+				userID: "624708fa-d258-4b99-b09a-49d95f294626",
+			},
+			want: want{
+				code: http.StatusBadRequest,
+			},
+		},
+	}
+
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	for _, tt := range tests {
+		uc := usecasesMock.NewMockShortener(ctl)
+		uc.EXPECT().ReportURL(anyMock, tt.args.userID, "2ZrI5IHFnvPscPYKlxFtRQ", anyMock).
+			Return(tt.fields.useCaseErr).AnyTimes()
+		d := New(uc)
+		router := chi.NewRouter()
+		router.Post("/{id}/report", d.reportURL)
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/2ZrI5IHFnvPscPYKlxFtRQ/report",
+				bytes.NewBufferString(tt.args.body))
+			request = request.WithContext(context.WithValue(request.Context(), ctxKeyUserID{}, tt.args.userID))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, request)
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.want.code, resp.StatusCode)
+		})
+	}
+}
@@ -8,4 +8,35 @@ type (
 		CorrelationID string `json:"correlation_id"`
 		OriginalURL   string `json:"original_url"`
 	}
+
+	credentialsRequest struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	updateURLRequest struct {
+		URL         string `json:"url,omitempty"`
+		WorkspaceID string `json:"workspace_id,omitempty"`
+	}
+
+	workspaceRequest struct {
+		Name string `json:"name"`
+	}
+
+	memberRequest struct {
+		Role string `json:"role"`
+	}
+
+	reportRequest struct {
+		Reason string `json:"reason"`
+	}
+
+	webhookRequest struct {
+		URL    string   `json:"url"`
+		Events []string `json:"events"`
+	}
+
+	resolveRequest struct {
+		Disable bool `json:"disable"`
+	}
 )
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/go-chi/render"
@@ -22,6 +23,89 @@ type (
 		URLs  int `json:"urls"`
 		Users int `json:"users"`
 	}
+	accountResponse struct {
+		ID    string `json:"id"`
+		Email string `json:"email"`
+	}
+	workspaceResponse struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Role string `json:"role"`
+	}
+	adminURLResponse struct {
+		ShortURL    string `json:"short_url"`
+		OriginalURL string `json:"original_url"`
+		UserID      string `json:"user_id"`
+		WorkspaceID string `json:"workspace_id"`
+		Deleted     bool   `json:"deleted"`
+		Disabled    bool   `json:"disabled"`
+	}
+	reportResponse struct {
+		ShortURL       string   `json:"short_url"`
+		OriginalURL    string   `json:"original_url"`
+		Disabled       bool     `json:"disabled"`
+		Flagged        bool     `json:"flagged"`
+		Count          int      `json:"count"`
+		Reasons        []string `json:"reasons"`
+		LastReportedAt string   `json:"last_reported_at"`
+	}
+	auditResponse struct {
+		ID        string `json:"id"`
+		Action    string `json:"action"`
+		UserID    string `json:"user_id"`
+		TargetID  string `json:"target_id"`
+		Transport string `json:"transport"`
+		Actor     string `json:"actor,omitempty"`
+		CreatedAt string `json:"created_at"`
+	}
+	webhookResponse struct {
+		ID     string   `json:"id"`
+		URL    string   `json:"url"`
+		Events []string `json:"events"`
+		Secret string   `json:"secret,omitempty"`
+	}
+	deadLetterResponse struct {
+		ID        string          `json:"id"`
+		WebhookID string          `json:"webhook_id"`
+		Event     string          `json:"event"`
+		Payload   json.RawMessage `json:"payload" swaggertype:"object"`
+		Attempts  int             `json:"attempts"`
+		LastError string          `json:"last_error"`
+		CreatedAt string          `json:"created_at"`
+	}
+	deleteURLResponse struct {
+		JobID string `json:"job_id"`
+	}
+	jobResponse struct {
+		ID        string           `json:"id"`
+		Action    string           `json:"action"`
+		Status    string           `json:"status"`
+		Attempts  int              `json:"attempts"`
+		CreatedAt string           `json:"created_at"`
+		URLs      []jobURLResponse `json:"urls"`
+	}
+	jobURLResponse struct {
+		ID       string `json:"id"`
+		ShortURL string `json:"short_url"`
+		Outcome  string `json:"outcome"`
+	}
+	healthResponse struct {
+		Status   string `json:"status"`
+		Storage  string `json:"storage"`
+		Driver   string `json:"driver,omitempty"`
+		Instance string `json:"instance,omitempty"`
+		Election bool   `json:"election"`
+		Leader   bool   `json:"leader"`
+	}
+	promoteStorageResponse struct {
+		Driver string `json:"driver"`
+	}
+	workspaceURLResponse struct {
+		ShortURL    string `json:"short_url"`
+		OriginalURL string `json:"original_url"`
+		WorkspaceID string `json:"workspace_id"`
+	}
+
 	errResponse struct {
 		Err            error  `json:"-"`
 		HTTPStatusCode int    `json:"-"`
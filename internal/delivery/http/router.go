@@ -26,7 +26,9 @@ func (d *delivery) routerURL(http config.HTTP, r chi.Router) {
 	r.Route("/", func(r chi.Router) {
 		r.Post("/", d.addURL)
 		r.Get("/{id}", d.getURL)
+		r.Post("/{id}/report", d.reportURL)
 		r.Get("/ping", d.ping)
+		r.Get("/health", d.health)
 	})
 
 	r.Route("/api", func(r chi.Router) {
@@ -37,11 +39,53 @@ func (d *delivery) routerURL(http config.HTTP, r chi.Router) {
 		r.Route("/user", func(r chi.Router) {
 			r.Get("/urls", d.userURL)
 			r.Delete("/urls", d.deleteURL)
+			r.Patch("/urls/{id}", d.updateURL)
+			r.Get("/jobs/{id}", d.job)
+			r.Route("/workspaces", func(r chi.Router) {
+				r.Get("/", d.workspaces)
+				r.Post("/", d.createWorkspace)
+				r.Get("/{workspaceID}/urls", d.workspaceURL)
+				r.Put("/{workspaceID}/members/{memberID}", d.setMember)
+				r.Delete("/{workspaceID}/members/{memberID}", d.deleteMember)
+			})
+			r.Route("/webhooks", func(r chi.Router) {
+				r.Get("/", d.webhooks)
+				r.Post("/", d.createWebhook)
+				r.Get("/dead-letters", d.deadLetters)
+				r.Delete("/{webhookID}", d.deleteWebhook)
+			})
+			r.Post("/register", d.register)
+			r.Post("/login", d.login)
+			r.Post("/logout", d.logout)
+			if d.sso != nil {
+				r.Get("/oidc/login", d.oidcLogin)
+				r.Get("/oidc/callback", d.oidcCallback)
+			}
 		})
 		r.Route("/internal/stats", func(r chi.Router) {
 			r.Use(trustedSubnet(http.GetTrustedSubnet()))
 			r.Get("/", d.stats)
 		})
+		r.Route("/internal/metrics", func(r chi.Router) {
+			r.Use(trustedSubnet(http.GetTrustedSubnet()))
+			r.Get("/", d.metrics)
+		})
+		r.Route("/internal/audit", func(r chi.Router) {
+			r.Use(trustedSubnet(http.GetTrustedSubnet()))
+			r.Get("/", d.audit)
+		})
+		r.Route("/internal/admin", func(r chi.Router) {
+			r.Use(trustedSubnet(http.GetTrustedSubnet()))
+			r.Get("/urls", d.adminURL)
+			r.Delete("/urls", d.adminDeleteURL)
+			r.Post("/urls/{id}/disable", d.disableURL)
+			r.Post("/urls/{id}/enable", d.enableURL)
+			r.Post("/users/{userID}/ban", d.banUser)
+			r.Post("/users/{userID}/unban", d.unbanUser)
+			r.Get("/reports", d.reports)
+			r.Post("/reports/{id}/resolve", d.resolveReports)
+			r.Post("/storage/promote", d.promoteStorage)
+		})
 	})
 
 	r.Mount("/docs", httpSwagger.WrapHandler)
@@ -10,7 +10,12 @@ import (
 	"github.com/go-chi/render"
 	"golang.org/x/exp/slog"
 
+	"github.com/sreway/shorturl/internal/domain/account"
+	"github.com/sreway/shorturl/internal/domain/report"
+	"github.com/sreway/shorturl/internal/domain/task"
 	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/webhook"
+	"github.com/sreway/shorturl/internal/domain/workspace"
 	"github.com/sreway/shorturl/internal/usecases/shortener"
 )
 
@@ -78,6 +83,7 @@ func (d *delivery) addURL(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {string} string
 // @Failure 404 {object} errResponse
 // @Failure 400 {object} errResponse
+// @Failure 451 {object} errResponse
 // @Failure 500 {object} errResponse
 // @Failure 501 {object} errResponse
 // @Router /{id} [post]
@@ -227,7 +233,7 @@ func (d *delivery) userURL(w http.ResponseWriter, r *http.Request) {
 // @Produce application/json
 // @Param bathURL body []batchURLRequest true "several long URLs to shorten"
 // @Success 201 {object} []batchURLResponse
-// @Failure 409 {object} errResponse
+// @Failure 409 {object} []batchURLResponse "the long URLs already shortened with the existing short URLs"
 // @Failure 400 {object} errResponse
 // @Failure 500 {object} errResponse
 // @Failure 501 {object} errResponse
@@ -272,11 +278,16 @@ func (d *delivery) batchURL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	httpStatus := http.StatusCreated
 	urls, err := d.shortener.BatchURL(r.Context(), correlationID, rawURL, userID)
 	if err != nil {
 		d.logger.Error("failed batch add urls", err, slog.String("handler", "batchURL"))
-		d.handelErrURL(w, r, err)
-		return
+		// the items already shortened are returned with the existing short URLs
+		if !errors.Is(err, entity.ErrAlreadyExist) || len(urls) == 0 {
+			d.handelErrURL(w, r, err)
+			return
+		}
+		httpStatus = http.StatusConflict
 	}
 
 	resp := make([]batchURLResponse, len(urls))
@@ -294,7 +305,7 @@ func (d *delivery) batchURL(w http.ResponseWriter, r *http.Request) {
 		d.handelErrURL(w, r, err)
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(httpStatus)
 	_, err = w.Write(data)
 	if err != nil {
 		d.logger.Error("write body", err, slog.String("handler", "batchURL"))
@@ -309,7 +320,7 @@ func (d *delivery) batchURL(w http.ResponseWriter, r *http.Request) {
 // @ID deleteURL
 // @Produce application/json
 // @Param ids body []string true "short URL ids to delete"
-// @Success 202
+// @Success 202 {object} deleteURLResponse
 // @Failure 410 {object} errResponse
 // @Failure 400 {object} errResponse
 // @Failure 500 {object} errResponse
@@ -335,14 +346,15 @@ func (d *delivery) deleteURL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := d.shortener.DeleteURL(r.Context(), userID, *urls)
+	jobID, err := d.shortener.DeleteURL(r.Context(), userID, *urls)
 	if err != nil {
 		d.logger.Error("failed delete urls", err, slog.String("handler", "deleteURL"))
 		d.handelErrURL(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusAccepted)
+	w.Header().Set("Location", "/api/user/jobs/"+jobID)
+	d.writeJSON(w, r, "deleteURL", http.StatusAccepted, deleteURLResponse{JobID: jobID})
 }
 
 // ping godoc
@@ -363,6 +375,39 @@ func (d *delivery) ping(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// health godoc
+// @Summary health status of the replica
+// @Description health status of the shortener storage with the active storage driver and the leadership of the replica,
+// @Description the replica is always the leader when the leader election is not used
+// @ID health
+// @Produce application/json
+// @Success 200 {object} healthResponse
+// @Failure 503 {object} healthResponse
+// @Router /health [get]
+func (d *delivery) health(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	status := d.shortener.Health(r.Context())
+	resp := healthResponse{
+		Status:   "ok",
+		Storage:  "ok",
+		Driver:   status.Driver,
+		Instance: status.Instance,
+		Election: status.Election,
+		Leader:   status.Leader,
+	}
+
+	code := http.StatusOK
+	if !status.Healthy() {
+		d.logger.Error("failed check storage", status.Storage, slog.String("handler", "health"))
+		resp.Status = "unavailable"
+		resp.Storage = "unavailable"
+		code = http.StatusServiceUnavailable
+	}
+
+	d.writeJSON(w, r, "health", code, resp)
+}
+
 // stats godoc
 // @Summary shorturl statistics
 // @Description shorturl statistics
@@ -414,6 +459,26 @@ func (d *delivery) handelErrURL(w http.ResponseWriter, r *http.Request, err erro
 		httpStatus = http.StatusBadRequest
 	case errors.Is(err, ErrInvalidRequest):
 		httpStatus = http.StatusBadRequest
+	case errors.Is(err, shortener.ErrEmptyWorkspaceName):
+		httpStatus = http.StatusBadRequest
+	case errors.Is(err, workspace.ErrInvalidRole):
+		httpStatus = http.StatusBadRequest
+	case errors.Is(err, workspace.ErrForbidden):
+		httpStatus = http.StatusForbidden
+	case errors.Is(err, workspace.ErrNotFound):
+		httpStatus = http.StatusNotFound
+	case errors.Is(err, shortener.ErrParseEmail):
+		httpStatus = http.StatusBadRequest
+	case errors.Is(err, shortener.ErrWeakPassword):
+		httpStatus = http.StatusBadRequest
+	case errors.Is(err, shortener.ErrInvalidCredentials):
+		httpStatus = http.StatusUnauthorized
+	case errors.Is(err, ErrOIDCState):
+		httpStatus = http.StatusUnauthorized
+	case errors.Is(err, ErrOIDCToken):
+		httpStatus = http.StatusUnauthorized
+	case errors.Is(err, account.ErrAlreadyExist):
+		httpStatus = http.StatusConflict
 	case errors.Is(err, entity.ErrNotFound):
 		httpStatus = http.StatusNotFound
 	case errors.Is(err, entity.ErrAlreadyExist):
@@ -423,6 +488,24 @@ func (d *delivery) handelErrURL(w http.ResponseWriter, r *http.Request, err erro
 		httpStatus = http.StatusInternalServerError
 	case errors.Is(err, entity.ErrDeleted):
 		httpStatus = http.StatusGone
+	case errors.Is(err, entity.ErrDisabled):
+		httpStatus = http.StatusUnavailableForLegalReasons
+	case errors.Is(err, shortener.ErrUserBanned):
+		httpStatus = http.StatusForbidden
+	case errors.Is(err, shortener.ErrInvalidReason):
+		httpStatus = http.StatusBadRequest
+	case errors.Is(err, report.ErrAlreadyExist):
+		httpStatus = http.StatusConflict
+	case errors.Is(err, shortener.ErrEmptyWebhookEvents):
+		httpStatus = http.StatusBadRequest
+	case errors.Is(err, webhook.ErrInvalidEventType):
+		httpStatus = http.StatusBadRequest
+	case errors.Is(err, webhook.ErrForbiddenEndpoint):
+		httpStatus = http.StatusBadRequest
+	case errors.Is(err, webhook.ErrNotFound):
+		httpStatus = http.StatusNotFound
+	case errors.Is(err, task.ErrNotFound):
+		httpStatus = http.StatusNotFound
 	default:
 		httpStatus = http.StatusNotImplemented
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/sreway/shorturl/internal/domain/health"
 	statMock "github.com/sreway/shorturl/internal/domain/stats/mock"
 	"github.com/sreway/shorturl/internal/domain/url"
 	urlMock "github.com/sreway/shorturl/internal/domain/url/mock"
@@ -221,6 +222,20 @@ func Test_delivery_getURL(t *testing.T) {
 				code: http.StatusNotFound,
 			},
 		},
+
+		{
+			name: "negative get url (disabled)",
+			args: args{
+				uri:    "/2ZrI5IHFnvPscPYKlxFtRQ",
+				method: http.MethodGet,
+			},
+			fields: fields{
+				useCaseErr: url.ErrDisabled,
+			},
+			want: want{
+				code: http.StatusUnavailableForLegalReasons,
+			},
+		},
 	}
 
 	anyMock := gomock.Any()
@@ -598,6 +613,25 @@ func Test_delivery_batchURL(t *testing.T) {
 				},
 				useCaseErr: url.ErrAlreadyExist,
 			},
+			want: want{
+				code: http.StatusConflict,
+				headers: map[string]string{
+					"Content-Type": "application/json; charset=utf-8",
+				},
+				response: "[{\"correlation_id\":\"1\",\"short_url\":\"http://localhost:8080/7jUSM5AZgNW4DwdHts5b0F\"}]",
+			},
+		},
+
+		{
+			name: "negative add batch url (exist url without existing short urls)",
+			args: args{
+				uri:    "/api/shorten/batch",
+				method: http.MethodPost,
+				body:   `[{"correlation_id":"1","original_url":"https://ya.ru"}]`,
+			},
+			fields: fields{
+				useCaseErr: url.ErrAlreadyExist,
+			},
 			want: want{
 				code: http.StatusConflict,
 				headers: map[string]string{
@@ -708,6 +742,68 @@ func Test_delivery_ping(t *testing.T) {
 	}
 }
 
+func Test_delivery_health(t *testing.T) {
+	type want struct {
+		code     int
+		response string
+	}
+
+	tests := []struct {
+		name   string
+		status health.Status
+		want   want
+	}{
+		{
+			name:   "positive health (leader)",
+			status: health.Status{Instance: "host-1", Driver: "postgres", Election: true, Leader: true},
+			want: want{
+				code: http.StatusOK,
+				response: `{"status":"ok","storage":"ok","driver":"postgres","instance":"host-1","election":true,` +
+					`"leader":true}`,
+			},
+		},
+		{
+			name:   "positive health (follower)",
+			status: health.Status{Instance: "host-2", Election: true},
+			want: want{
+				code: http.StatusOK,
+				response: `{"status":"ok","storage":"ok","instance":"host-2","election":true,` +
+					`"leader":false}`,
+			},
+		},
+		{
+			name:   "negative health (storage unavailable)",
+			status: health.Status{Storage: ErrStorageCheck, Leader: true},
+			want: want{
+				code:     http.StatusServiceUnavailable,
+				response: `{"status":"unavailable","storage":"unavailable","election":false,"leader":true}`,
+			},
+		},
+	}
+
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	for _, tt := range tests {
+		uc := usecasesMock.NewMockShortener(ctl)
+		uc.EXPECT().Health(anyMock).Return(tt.status)
+		d := New(uc)
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/health", nil)
+			w := httptest.NewRecorder()
+			h := http.HandlerFunc(d.health)
+			h.ServeHTTP(w, request)
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.want.code, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want.response, string(body))
+		})
+	}
+}
+
 func Test_delivery_deleteURL(t *testing.T) {
 	type want struct {
 		code     int
@@ -722,6 +818,7 @@ func Test_delivery_deleteURL(t *testing.T) {
 
 	uri := "/api/user/urls"
 	method := http.MethodDelete
+	jobID := "3f1c2a9e-8d2b-4b6f-9a51-0c7e4d2f1b88"
 
 	tests := []struct {
 		name   string
@@ -735,7 +832,8 @@ func Test_delivery_deleteURL(t *testing.T) {
 				body: `["2ZrI5IHFnvPscPYKlxFtRQ"]`,
 			},
 			want: want{
-				code: http.StatusAccepted,
+				code:     http.StatusAccepted,
+				response: `{"job_id":"3f1c2a9e-8d2b-4b6f-9a51-0c7e4d2f1b88"}`,
 			},
 		},
 		{
@@ -779,7 +877,7 @@ func Test_delivery_deleteURL(t *testing.T) {
 
 	for _, tt := range tests {
 		uc := usecasesMock.NewMockShortener(ctl)
-		uc.EXPECT().DeleteURL(anyMock, anyMock, anyMock).Return(tt.fields.useCaseErr).AnyTimes()
+		uc.EXPECT().DeleteURL(anyMock, anyMock, anyMock).Return(jobID, tt.fields.useCaseErr).AnyTimes()
 		d := New(uc)
 		t.Run(tt.name, func(t *testing.T) {
 			request := httptest.NewRequest(method, uri, strings.NewReader(tt.args.body))
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/webhook"
+)
+
+// webhooks godoc
+// @Summary get webhooks of the user
+// @Description get webhook endpoints of the user, secrets are not returned
+// @ID webhooks
+// @Produce application/json
+// @Success 200 {object} []webhookResponse
+// @Failure 400 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/webhooks [get]
+func (d *delivery) webhooks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "webhooks"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	webhooks, err := d.shortener.GetWebhooks(r.Context(), userID)
+	if err != nil {
+		d.logger.Error("failed get webhooks", err, slog.String("handler", "webhooks"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	resp := make([]webhookResponse, len(webhooks))
+	for idx, item := range webhooks {
+		resp[idx] = newWebhookResponse(item)
+	}
+
+	d.writeJSON(w, r, "webhooks", http.StatusOK, resp)
+}
+
+// createWebhook godoc
+// @Summary create webhook
+// @Description register the webhook endpoint for the event types (url.created, url.deleted, url.clicked),
+// @Description payloads are signed with HMAC-SHA256 of the returned secret in the X-Shorturl-Signature header
+// @ID createWebhook
+// @Produce application/json
+// @Param webhook body webhookRequest true "webhook"
+// @Success 201 {object} webhookResponse
+// @Failure 400 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/webhooks [post]
+func (d *delivery) createWebhook(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "createWebhook"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	req := new(webhookRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		d.logger.Error("failed decode request", err, slog.String("handler", "createWebhook"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	item, err := d.shortener.CreateWebhook(r.Context(), userID, req.URL, req.Events)
+	if err != nil {
+		d.logger.Error("failed create webhook", err, slog.String("handler", "createWebhook"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	resp := newWebhookResponse(item)
+	resp.Secret = item.Secret()
+	d.writeJSON(w, r, "createWebhook", http.StatusCreated, resp)
+}
+
+// deleteWebhook godoc
+// @Summary delete webhook
+// @Description remove the webhook endpoint of the user
+// @ID deleteWebhook
+// @Param webhookID path string true "webhook id"
+// @Success 204
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/webhooks/{webhookID} [delete]
+func (d *delivery) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "deleteWebhook"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	if err := d.shortener.DeleteWebhook(r.Context(), userID, chi.URLParam(r, "webhookID")); err != nil {
+		d.logger.Error("failed delete webhook", err, slog.String("handler", "deleteWebhook"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deadLetters godoc
+// @Summary get failed webhook deliveries
+// @Description get deliveries to the webhooks of the user that exhausted their retry attempts
+// @ID deadLetters
+// @Produce application/json
+// @Success 200 {object} []deadLetterResponse
+// @Failure 400 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/webhooks/dead-letters [get]
+func (d *delivery) deadLetters(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "deadLetters"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	deliveries, err := d.shortener.GetDeadLetters(r.Context(), userID)
+	if err != nil {
+		d.logger.Error("failed get dead letters", err, slog.String("handler", "deadLetters"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	resp := make([]deadLetterResponse, len(deliveries))
+	for idx, item := range deliveries {
+		resp[idx] = deadLetterResponse{
+			ID:        item.ID.String(),
+			WebhookID: item.WebhookID.String(),
+			Event:     string(item.Event),
+			Payload:   item.Payload,
+			Attempts:  item.Attempts,
+			LastError: item.LastError,
+			CreatedAt: item.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	d.writeJSON(w, r, "deadLetters", http.StatusOK, resp)
+}
+
+// newWebhookResponse implements the creation of the webhook response payload without the secret.
+func newWebhookResponse(item webhook.Webhook) webhookResponse {
+	events := make([]string, len(item.Events()))
+	for idx, v := range item.Events() {
+		events[idx] = string(v)
+	}
+
+	return webhookResponse{
+		ID:     item.ID().String(),
+		URL:    item.URL(),
+		Events: events,
+	}
+}
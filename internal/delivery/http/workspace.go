@@ -0,0 +1,285 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/exp/slog"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/workspace"
+)
+
+// updateURL godoc
+// @Summary update short URL
+// @Description change the long URL of the short URL or move it to another workspace, requires the editor role
+// @ID updateURL
+// @Produce application/json
+// @Param id path string true "short URL id"
+// @Param changes body updateURLRequest true "short URL changes"
+// @Success 200 {object} workspaceURLResponse
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
+// @Failure 410 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/urls/{id} [patch]
+func (d *delivery) updateURL(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "updateURL"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	req := new(updateURLRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		d.logger.Error("failed decode request", err, slog.String("handler", "updateURL"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	u, err := d.shortener.UpdateURL(r.Context(), userID, chi.URLParam(r, "id"), req.URL, req.WorkspaceID)
+	if err != nil {
+		d.logger.Error("failed update url", err, slog.String("handler", "updateURL"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	d.writeJSON(w, r, "updateURL", http.StatusOK, newWorkspaceURLResponse(u))
+}
+
+// workspaces godoc
+// @Summary get workspaces of the user
+// @Description get workspaces the user is a member of with the user role
+// @ID workspaces
+// @Produce application/json
+// @Success 200 {object} []workspaceResponse
+// @Failure 400 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/workspaces [get]
+func (d *delivery) workspaces(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "workspaces"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	workspaces, err := d.shortener.GetWorkspaces(r.Context(), userID)
+	if err != nil {
+		d.logger.Error("failed get workspaces", err, slog.String("handler", "workspaces"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	resp := make([]workspaceResponse, len(workspaces))
+	for idx, item := range workspaces {
+		resp[idx] = newWorkspaceResponse(item)
+	}
+
+	d.writeJSON(w, r, "workspaces", http.StatusOK, resp)
+}
+
+// createWorkspace godoc
+// @Summary create workspace
+// @Description create workspace administered by the user
+// @ID createWorkspace
+// @Produce application/json
+// @Param workspace body workspaceRequest true "workspace"
+// @Success 201 {object} workspaceResponse
+// @Failure 400 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/workspaces [post]
+func (d *delivery) createWorkspace(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "createWorkspace"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	req := new(workspaceRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		d.logger.Error("failed decode request", err, slog.String("handler", "createWorkspace"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	item, err := d.shortener.CreateWorkspace(r.Context(), userID, req.Name)
+	if err != nil {
+		d.logger.Error("failed create workspace", err, slog.String("handler", "createWorkspace"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	d.writeJSON(w, r, "createWorkspace", http.StatusCreated, newWorkspaceResponse(item))
+}
+
+// workspaceURL godoc
+// @Summary get short URLs of the workspace
+// @Description get short URLs of the workspace, requires the viewer role
+// @ID workspaceURL
+// @Produce application/json
+// @Param workspaceID path string true "workspace id"
+// @Success 200 {object} []workspaceURLResponse
+// @Success 204
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/workspaces/{workspaceID}/urls [get]
+func (d *delivery) workspaceURL(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "workspaceURL"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	urls, err := d.shortener.GetWorkspaceURLs(r.Context(), userID, chi.URLParam(r, "workspaceID"))
+	if err != nil {
+		d.logger.Error("failed get workspace urls", err, slog.String("handler", "workspaceURL"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	if len(urls) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	resp := make([]workspaceURLResponse, len(urls))
+	for idx, u := range urls {
+		resp[idx] = newWorkspaceURLResponse(u)
+	}
+
+	d.writeJSON(w, r, "workspaceURL", http.StatusOK, resp)
+}
+
+// setMember godoc
+// @Summary set workspace member
+// @Description add the workspace member or change the member role, requires the admin role
+// @ID setMember
+// @Param workspaceID path string true "workspace id"
+// @Param memberID path string true "member user id"
+// @Param member body memberRequest true "member role"
+// @Success 204
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/workspaces/{workspaceID}/members/{memberID} [put]
+func (d *delivery) setMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "setMember"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	req := new(memberRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		d.logger.Error("failed decode request", err, slog.String("handler", "setMember"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	err := d.shortener.SetWorkspaceMember(r.Context(), userID, chi.URLParam(r, "workspaceID"),
+		chi.URLParam(r, "memberID"), req.Role)
+	if err != nil {
+		d.logger.Error("failed set workspace member", err, slog.String("handler", "setMember"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteMember godoc
+// @Summary delete workspace member
+// @Description remove the workspace member, requires the admin role
+// @ID deleteMember
+// @Param workspaceID path string true "workspace id"
+// @Param memberID path string true "member user id"
+// @Success 204
+// @Failure 400 {object} errResponse
+// @Failure 403 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Failure 501 {object} errResponse
+// @Router /api/user/workspaces/{workspaceID}/members/{memberID} [delete]
+func (d *delivery) deleteMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxKeyUserID{}).(string)
+	if !ok {
+		d.logger.Error("invalid user id", ErrInvalidRequest,
+			slog.String("userID", userID), slog.String("handler", "deleteMember"))
+		d.handelErrURL(w, r, ErrInvalidRequest)
+		return
+	}
+
+	err := d.shortener.DeleteWorkspaceMember(r.Context(), userID, chi.URLParam(r, "workspaceID"),
+		chi.URLParam(r, "memberID"))
+	if err != nil {
+		d.logger.Error("failed delete workspace member", err, slog.String("handler", "deleteMember"))
+		d.handelErrURL(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON implements writing the response payload as JSON.
+func (d *delivery) writeJSON(w http.ResponseWriter, r *http.Request, handler string, statusCode int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		d.logger.Error("failed marshal response", err, slog.String("handler", handler))
+		d.handelErrURL(w, r, ErrInternalServer)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+	_, err = w.Write(data)
+	if err != nil {
+		d.logger.Error("write body", err, slog.String("handler", handler))
+		return
+	}
+}
+
+// newWorkspaceResponse implements the creation of the workspace response payload.
+func newWorkspaceResponse(item workspace.Workspace) workspaceResponse {
+	return workspaceResponse{
+		ID:   item.ID().String(),
+		Name: item.Name(),
+		Role: string(item.Role()),
+	}
+}
+
+// newWorkspaceURLResponse implements the creation of the workspace short URL response payload.
+func newWorkspaceURLResponse(u entity.URL) workspaceURLResponse {
+	return workspaceURLResponse{
+		ShortURL:    u.ShortURL(),
+		OriginalURL: u.LongURL(),
+		WorkspaceID: u.WorkspaceID().String(),
+	}
+}
@@ -0,0 +1,65 @@
+// Package account implements and describes the type of registered user account.
+package account
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// Account describes the implementation of the registered user account type.
+	Account interface {
+		ID() uuid.UUID
+		Email() string
+		PasswordHash() []byte
+		CreatedAt() time.Time
+		SetPasswordHash(value []byte)
+		SetCreatedAt(value time.Time)
+	}
+
+	entity struct {
+		id           uuid.UUID
+		email        string
+		passwordHash []byte
+		createdAt    time.Time
+	}
+)
+
+// ID implements getting account ID, it is used as the user ID of the account links.
+func (e *entity) ID() uuid.UUID {
+	return e.id
+}
+
+// Email implements getting account email.
+func (e *entity) Email() string {
+	return e.email
+}
+
+// PasswordHash implements getting account password hash.
+func (e *entity) PasswordHash() []byte {
+	return e.passwordHash
+}
+
+// CreatedAt implements getting account creation time.
+func (e *entity) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// SetPasswordHash implements the setting of the account password hash.
+func (e *entity) SetPasswordHash(value []byte) {
+	e.passwordHash = value
+}
+
+// SetCreatedAt implements the setting of the account creation time.
+func (e *entity) SetCreatedAt(value time.Time) {
+	e.createdAt = value
+}
+
+// NewAccount implements the creation of the account type.
+func NewAccount(id uuid.UUID, email string) *entity {
+	return &entity{
+		id:    id,
+		email: email,
+	}
+}
@@ -0,0 +1,9 @@
+package account
+
+import "errors"
+
+// ErrNotFound implements account not found error.
+var ErrNotFound = errors.New("account not found")
+
+// ErrAlreadyExist implements account already exist error.
+var ErrAlreadyExist = errors.New("account already exist")
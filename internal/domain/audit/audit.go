@@ -0,0 +1,164 @@
+// Package audit implements and describes the type of audit log event.
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ActionCreate defines the creation of the short URL.
+	ActionCreate Action = "create"
+	// ActionUpdate defines the change of the short URL.
+	ActionUpdate Action = "update"
+	// ActionDelete defines the deletion of the short URL.
+	ActionDelete Action = "delete"
+	// ActionDisable defines the disabling of the short URL by the moderator.
+	ActionDisable Action = "disable"
+	// ActionRestore defines the enabling of the previously disabled short URL by the moderator.
+	ActionRestore Action = "restore"
+	// ActionBan defines the ban of the user.
+	ActionBan Action = "ban"
+	// ActionUnban defines lifting the ban of the user.
+	ActionUnban Action = "unban"
+)
+
+const (
+	// TransportHTTP defines operations received by the http server.
+	TransportHTTP Transport = "http"
+	// TransportGRPC defines operations received by the grpc server.
+	TransportGRPC Transport = "grpc"
+)
+
+type (
+	// Action describes the type of the audited operation.
+	Action string
+
+	// Transport describes the transport the audited operation was received over.
+	Transport string
+
+	// Event describes the implementation of the audit log event type.
+	Event interface {
+		ID() uuid.UUID
+		Action() Action
+		UserID() uuid.UUID
+		TargetID() uuid.UUID
+		Transport() Transport
+		Actor() string
+		CreatedAt() time.Time
+		SetTransport(value Transport)
+		SetActor(value string)
+		SetCreatedAt(value time.Time)
+	}
+
+	// Filter describes the parameters of the audit log query.
+	Filter struct {
+		// UserID limits the events to the user, zero value matches all users.
+		UserID uuid.UUID
+		// From limits the events to created at or after the time, zero value is unbounded.
+		From time.Time
+		// To limits the events to created before the time, zero value is unbounded.
+		To     time.Time
+		Limit  int
+		Offset int
+	}
+
+	entity struct {
+		id        uuid.UUID
+		action    Action
+		userID    uuid.UUID
+		targetID  uuid.UUID
+		transport Transport
+		actor     string
+		createdAt time.Time
+	}
+
+	ctxKeyTransport struct{}
+	ctxKeyActor     struct{}
+)
+
+// ID implements getting event ID.
+func (e *entity) ID() uuid.UUID {
+	return e.id
+}
+
+// Action implements getting the audited operation.
+func (e *entity) Action() Action {
+	return e.action
+}
+
+// UserID implements getting the user ID who performed the operation,
+// zero value for the operations of the trusted subnet.
+func (e *entity) UserID() uuid.UUID {
+	return e.userID
+}
+
+// TargetID implements getting the short URL ID, or the user ID for ban events.
+func (e *entity) TargetID() uuid.UUID {
+	return e.targetID
+}
+
+// Transport implements getting the transport the operation was received over.
+func (e *entity) Transport() Transport {
+	return e.transport
+}
+
+// Actor implements getting the network address the operation was received from,
+// it identifies the operations of the trusted subnet having no user ID.
+func (e *entity) Actor() string {
+	return e.actor
+}
+
+// CreatedAt implements getting event creation time.
+func (e *entity) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// SetTransport implements the setting of the transport the operation was received over.
+func (e *entity) SetTransport(value Transport) {
+	e.transport = value
+}
+
+// SetActor implements the setting of the network address the operation was received from.
+func (e *entity) SetActor(value string) {
+	e.actor = value
+}
+
+// SetCreatedAt implements the setting of the event creation time.
+func (e *entity) SetCreatedAt(value time.Time) {
+	e.createdAt = value
+}
+
+// NewEvent implements the creation of the audit log event type.
+func NewEvent(id uuid.UUID, action Action, userID, targetID uuid.UUID) *entity {
+	return &entity{
+		id:       id,
+		action:   action,
+		userID:   userID,
+		targetID: targetID,
+	}
+}
+
+// WithTransport implements adding the transport of the operation to the context.
+func WithTransport(ctx context.Context, transport Transport) context.Context {
+	return context.WithValue(ctx, ctxKeyTransport{}, transport)
+}
+
+// TransportFromContext implements getting the transport of the operation from the context.
+func TransportFromContext(ctx context.Context) Transport {
+	transport, _ := ctx.Value(ctxKeyTransport{}).(Transport)
+	return transport
+}
+
+// WithActor implements adding the network address the operation was received from to the context.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, ctxKeyActor{}, actor)
+}
+
+// ActorFromContext implements getting the network address the operation was received from from the context.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(ctxKeyActor{}).(string)
+	return actor
+}
@@ -0,0 +1,22 @@
+// Package health describes the health status of the application replica.
+package health
+
+// Status implements the health status of the application replica.
+type Status struct {
+	// Instance defines the identifier of the replica.
+	Instance string
+	// Storage defines the failed storage check, it is nil for the healthy storage.
+	Storage error
+	// Driver defines the name of the active storage driver.
+	Driver string
+	// Election defines that the replicas sharing the storage elect the leader,
+	// the single replica is always the leader otherwise.
+	Election bool
+	// Leader defines that the replica runs the singleton jobs.
+	Leader bool
+}
+
+// Healthy implements checking the replica serves requests.
+func (s Status) Healthy() bool {
+	return s.Storage == nil
+}
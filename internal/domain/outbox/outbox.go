@@ -0,0 +1,64 @@
+// Package outbox implements and describes the type of domain event message written to the transactional outbox.
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+const (
+	// TypeURLCreated defines the creation of the short URL.
+	TypeURLCreated Type = "url.created"
+	// TypeURLDeleted defines the deletion of the short URL.
+	TypeURLDeleted Type = "url.deleted"
+)
+
+type (
+	// Type describes the type of the domain event.
+	Type string
+
+	// Message describes the domain event stored in the outbox until it is dispatched to the sinks.
+	// Messages are delivered at least once, sinks deduplicate them by ID.
+	Message struct {
+		ID        uuid.UUID
+		Type      Type
+		URLID     uuid.UUID
+		UserID    uuid.UUID
+		Payload   []byte
+		CreatedAt time.Time
+	}
+
+	// URLPayload describes the payload of the short URL events.
+	URLPayload struct {
+		OriginalURL string    `json:"original_url,omitempty"`
+		WorkspaceID uuid.UUID `json:"workspace_id"`
+	}
+)
+
+// NewURLMessage implements the creation of the short URL event message.
+func NewURLMessage(t Type, u entity.URL) (Message, error) {
+	payload := URLPayload{
+		WorkspaceID: u.WorkspaceID(),
+	}
+	if t == TypeURLCreated {
+		payload.OriginalURL = u.LongURL()
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		ID:        uuid.New(),
+		Type:      t,
+		URLID:     u.ID(),
+		UserID:    u.UserID(),
+		Payload:   data,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,8 @@
+package report
+
+import (
+	"errors"
+)
+
+// ErrAlreadyExist implements open report of the same reporter already exist error.
+var ErrAlreadyExist = errors.New("report already exist")
@@ -0,0 +1,85 @@
+// Package report implements and describes the type of short URL abuse report.
+package report
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// Report describes the implementation of the abuse report type.
+	Report interface {
+		ID() uuid.UUID
+		URLID() uuid.UUID
+		ReporterID() uuid.UUID
+		Reason() string
+		CreatedAt() time.Time
+		SetReason(value string)
+		SetCreatedAt(value time.Time)
+	}
+
+	// Summary describes the moderation queue entry aggregating open reports of the short URL.
+	Summary struct {
+		URLID          uuid.UUID
+		ShortURL       string
+		LongURL        string
+		Disabled       bool
+		Flagged        bool
+		Count          int
+		Reasons        []string
+		LastReportedAt time.Time
+	}
+
+	entity struct {
+		id         uuid.UUID
+		urlID      uuid.UUID
+		reporterID uuid.UUID
+		reason     string
+		createdAt  time.Time
+	}
+)
+
+// ID implements getting report ID.
+func (e *entity) ID() uuid.UUID {
+	return e.id
+}
+
+// URLID implements getting the reported short URL ID.
+func (e *entity) URLID() uuid.UUID {
+	return e.urlID
+}
+
+// ReporterID implements getting the user ID of the reporter.
+func (e *entity) ReporterID() uuid.UUID {
+	return e.reporterID
+}
+
+// Reason implements getting the report reason.
+func (e *entity) Reason() string {
+	return e.reason
+}
+
+// CreatedAt implements getting report creation time.
+func (e *entity) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// SetReason implements the setting of the report reason.
+func (e *entity) SetReason(value string) {
+	e.reason = value
+}
+
+// SetCreatedAt implements the setting of the report creation time.
+func (e *entity) SetCreatedAt(value time.Time) {
+	e.createdAt = value
+}
+
+// NewReport implements the creation of the report type.
+func NewReport(id, urlID, reporterID uuid.UUID) *entity {
+	return &entity{
+		id:         id,
+		urlID:      urlID,
+		reporterID: reporterID,
+	}
+}
@@ -0,0 +1,11 @@
+package task
+
+import (
+	"errors"
+)
+
+// ErrNotFound implements task not found error.
+var ErrNotFound = errors.New("task not found")
+
+// ErrLeaseLost implements task claimed again after the lease of the worker ended error.
+var ErrLeaseLost = errors.New("task lease lost")
@@ -0,0 +1,97 @@
+// Package task implements and describes the type of deferred task processed by the task queue.
+package task
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ActionDelete defines the deletion of the short URLs of the user.
+	ActionDelete Action = "delete"
+)
+
+const (
+	// StatusPending defines the task waiting for its run time.
+	StatusPending Status = "pending"
+	// StatusRunning defines the task claimed by the queue worker, the claim expires at the run time.
+	StatusRunning Status = "running"
+	// StatusDone defines the successfully processed task.
+	StatusDone Status = "done"
+	// StatusDead defines the task whose attempts are exhausted.
+	StatusDead Status = "dead"
+)
+
+const (
+	// OutcomePending defines the short URL the task has not been applied to yet.
+	OutcomePending Outcome = "pending"
+	// OutcomeDeleted defines the deleted short URL.
+	OutcomeDeleted Outcome = "deleted"
+	// OutcomeNotFound defines the short URL that does not exist.
+	OutcomeNotFound Outcome = "not_found"
+	// OutcomeNotOwned defines the short URL whose workspace the user may not edit.
+	OutcomeNotOwned Outcome = "not_owned"
+)
+
+type (
+	// Action describes the type of the task.
+	Action string
+	// Status describes the processing state of the task.
+	Status string
+	// Outcome describes the result of applying the task to the single short URL.
+	Outcome string
+
+	// Task describes the deferred task applied to the short URLs of the user.
+	Task struct {
+		ID        uuid.UUID
+		Action    Action
+		UserID    uuid.UUID
+		URLIDs    []uuid.UUID
+		Status    Status
+		Attempts  int
+		LastError string
+		Outcomes  map[uuid.UUID]Outcome
+		RunAt     time.Time
+		CreatedAt time.Time
+	}
+
+	// Job describes the state of the task reported to the user.
+	Job struct {
+		ID        uuid.UUID
+		Action    Action
+		Status    Status
+		Attempts  int
+		CreatedAt time.Time
+		URLs      []JobURL
+	}
+
+	// JobURL describes the result of the job for the short URL.
+	JobURL struct {
+		ID       string
+		ShortURL string
+		Outcome  Outcome
+	}
+)
+
+// Outcome implements getting the result of applying the task to the short URL.
+func (t Task) Outcome(urlID uuid.UUID) Outcome {
+	if outcome, ok := t.Outcomes[urlID]; ok {
+		return outcome
+	}
+	return OutcomePending
+}
+
+// New implements the creation of the pending task due immediately.
+func New(action Action, userID uuid.UUID, urlIDs []uuid.UUID) Task {
+	now := time.Now().UTC()
+	return Task{
+		ID:        uuid.New(),
+		Action:    action,
+		UserID:    userID,
+		URLIDs:    urlIDs,
+		Status:    StatusPending,
+		RunAt:     now,
+		CreatedAt: now,
+	}
+}
@@ -16,6 +16,9 @@ var ErrAlreadyExist = errors.New("URL already exist")
 // ErrDeleted implements short URL already deleted error.
 var ErrDeleted = errors.New("URL deleted")
 
+// ErrDisabled implements short URL disabled by moderation error.
+var ErrDisabled = errors.New("URL disabled")
+
 // ErrURL defines short URL error.
 type ErrURL struct {
 	error  error
@@ -51,3 +54,33 @@ func NewURLErr(id, userID uuid.UUID, err error) error {
 		error:  err,
 	}
 }
+
+// ErrBatch defines multiple short URLs error.
+// The existing short URL IDs are keyed by the IDs of the batch items already shortened.
+type ErrBatch struct {
+	error    error
+	existing map[uuid.UUID]uuid.UUID
+}
+
+// Error implements "Error" method for multiple short URLs error.
+func (e *ErrBatch) Error() string {
+	return fmt.Sprintf("%d urls:%s", len(e.existing), e.error)
+}
+
+// Is implements "Is" method for multiple short URLs error.
+func (e ErrBatch) Is(err error) bool {
+	return errors.Is(e.error, err)
+}
+
+// Existing implements getting the existing short URL IDs keyed by the batch item IDs.
+func (e *ErrBatch) Existing() map[uuid.UUID]uuid.UUID {
+	return e.existing
+}
+
+// NewBatchErr implements the creation of the multiple short URLs error.
+func NewBatchErr(existing map[uuid.UUID]uuid.UUID, err error) error {
+	return &ErrBatch{
+		existing: existing,
+		error:    err,
+	}
+}
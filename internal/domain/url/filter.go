@@ -0,0 +1,15 @@
+package url
+
+import (
+	"github.com/google/uuid"
+)
+
+// Filter describes the parameters of short URLs search across users.
+type Filter struct {
+	// Query matches the long URL substring, empty value matches all short URLs.
+	Query string
+	// UserID limits the search to short URLs of the user, zero value matches all users.
+	UserID uuid.UUID
+	Limit  int
+	Offset int
+}
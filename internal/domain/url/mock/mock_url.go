@@ -63,6 +63,20 @@ func (mr *MockURLMockRecorder) Deleted() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deleted", reflect.TypeOf((*MockURL)(nil).Deleted))
 }
 
+// Disabled mocks base method.
+func (m *MockURL) Disabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Disabled indicates an expected call of Disabled.
+func (mr *MockURLMockRecorder) Disabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disabled", reflect.TypeOf((*MockURL)(nil).Disabled))
+}
+
 // ID mocks base method.
 func (m *MockURL) ID() uuid.UUID {
 	m.ctrl.T.Helper()
@@ -129,6 +143,18 @@ func (mr *MockURLMockRecorder) SetDeleted(value interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeleted", reflect.TypeOf((*MockURL)(nil).SetDeleted), value)
 }
 
+// SetDisabled mocks base method.
+func (m *MockURL) SetDisabled(value bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDisabled", value)
+}
+
+// SetDisabled indicates an expected call of SetDisabled.
+func (mr *MockURLMockRecorder) SetDisabled(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisabled", reflect.TypeOf((*MockURL)(nil).SetDisabled), value)
+}
+
 // SetLongURL mocks base method.
 func (m *MockURL) SetLongURL(value url.URL) {
 	m.ctrl.T.Helper()
@@ -153,6 +179,18 @@ func (mr *MockURLMockRecorder) SetShortURL(value interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShortURL", reflect.TypeOf((*MockURL)(nil).SetShortURL), value)
 }
 
+// SetWorkspaceID mocks base method.
+func (m *MockURL) SetWorkspaceID(value uuid.UUID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetWorkspaceID", value)
+}
+
+// SetWorkspaceID indicates an expected call of SetWorkspaceID.
+func (mr *MockURLMockRecorder) SetWorkspaceID(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkspaceID", reflect.TypeOf((*MockURL)(nil).SetWorkspaceID), value)
+}
+
 // ShortURL mocks base method.
 func (m *MockURL) ShortURL() string {
 	m.ctrl.T.Helper()
@@ -194,3 +232,17 @@ func (mr *MockURLMockRecorder) UserID() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockURL)(nil).UserID))
 }
+
+// WorkspaceID mocks base method.
+func (m *MockURL) WorkspaceID() uuid.UUID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkspaceID")
+	ret0, _ := ret[0].(uuid.UUID)
+	return ret0
+}
+
+// WorkspaceID indicates an expected call of WorkspaceID.
+func (mr *MockURLMockRecorder) WorkspaceID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkspaceID", reflect.TypeOf((*MockURL)(nil).WorkspaceID))
+}
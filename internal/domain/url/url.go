@@ -13,25 +13,31 @@ type (
 	URL interface {
 		ID() uuid.UUID
 		UserID() uuid.UUID
+		WorkspaceID() uuid.UUID
 		LongURL() string
 		ShortURL() string
 		LongValue() url.URL
 		ShortValue() url.URL
 		CorrelationID() string
 		Deleted() bool
+		Disabled() bool
 		SetLongURL(value url.URL)
 		SetShortURL(value url.URL)
 		SetCorrelationID(value string)
 		SetDeleted(value bool)
+		SetDisabled(value bool)
+		SetWorkspaceID(value uuid.UUID)
 	}
 
 	entity struct {
 		id            uuid.UUID
 		userID        uuid.UUID
+		workspaceID   uuid.UUID
 		longURL       url.URL
 		shortURL      url.URL
 		correlationID string
 		deleted       bool
+		disabled      bool
 	}
 )
 
@@ -65,6 +71,11 @@ func (e *entity) UserID() uuid.UUID {
 	return e.userID
 }
 
+// WorkspaceID implements getting the owning workspace ID.
+func (e *entity) WorkspaceID() uuid.UUID {
+	return e.workspaceID
+}
+
 // CorrelationID implements getting correlation ID.
 func (e *entity) CorrelationID() string {
 	return e.correlationID
@@ -75,6 +86,11 @@ func (e *entity) Deleted() bool {
 	return e.deleted
 }
 
+// Disabled implements getting the moderation attribute.
+func (e *entity) Disabled() bool {
+	return e.disabled
+}
+
 // SetShortURL implements the setting of a short URL value.
 func (e *entity) SetShortURL(value url.URL) {
 	e.shortURL = value
@@ -95,10 +111,22 @@ func (e *entity) SetDeleted(value bool) {
 	e.deleted = value
 }
 
+// SetDisabled implements the setting of the moderation attribute.
+func (e *entity) SetDisabled(value bool) {
+	e.disabled = value
+}
+
+// SetWorkspaceID implements the setting of the owning workspace ID.
+func (e *entity) SetWorkspaceID(value uuid.UUID) {
+	e.workspaceID = value
+}
+
 // NewURL implements the creation of the short URL type.
+// The short URL belongs to the personal workspace of the user until another workspace is set.
 func NewURL(id, userID uuid.UUID) *entity {
 	return &entity{
-		id:     id,
-		userID: userID,
+		id:          id,
+		userID:      userID,
+		workspaceID: userID,
 	}
 }
@@ -0,0 +1,14 @@
+package webhook
+
+import (
+	"errors"
+)
+
+// ErrNotFound implements webhook not found error.
+var ErrNotFound = errors.New("webhook not found")
+
+// ErrInvalidEventType implements webhook invalid event type error.
+var ErrInvalidEventType = errors.New("invalid event type")
+
+// ErrForbiddenEndpoint implements webhook endpoint on loopback, private or link-local address error.
+var ErrForbiddenEndpoint = errors.New("webhook endpoint address not allowed")
@@ -0,0 +1,156 @@
+// Package webhook implements and describes the type of outbound webhook notifying users about short URL events.
+package webhook
+
+import (
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// EventURLCreated defines the creation of the short URL.
+	EventURLCreated EventType = "url.created"
+	// EventURLDeleted defines the deletion of the short URL.
+	EventURLDeleted EventType = "url.deleted"
+	// EventURLClicked defines the redirect of the short URL.
+	EventURLClicked EventType = "url.clicked"
+)
+
+type (
+	// EventType describes the type of the event the webhook is subscribed to.
+	EventType string
+
+	// Webhook describes the implementation of the webhook endpoint type.
+	Webhook interface {
+		ID() uuid.UUID
+		UserID() uuid.UUID
+		URL() string
+		Secret() string
+		Events() []EventType
+		Subscribed(event EventType) bool
+		SetURL(value string)
+		SetSecret(value string)
+		SetEvents(value []EventType)
+	}
+
+	// Event describes the short URL event delivered to the webhooks of the user.
+	Event struct {
+		ID        uuid.UUID
+		Type      EventType
+		UserID    uuid.UUID
+		CreatedAt time.Time
+		Data      any
+	}
+
+	// URLData describes the payload data of the short URL events.
+	URLData struct {
+		ID          string `json:"id"`
+		ShortURL    string `json:"short_url,omitempty"`
+		OriginalURL string `json:"original_url,omitempty"`
+	}
+
+	// Delivery describes the failed delivery of the event kept in the dead-letter list.
+	Delivery struct {
+		ID        uuid.UUID
+		WebhookID uuid.UUID
+		UserID    uuid.UUID
+		Event     EventType
+		Payload   []byte
+		Attempts  int
+		LastError string
+		CreatedAt time.Time
+	}
+
+	entity struct {
+		id     uuid.UUID
+		userID uuid.UUID
+		url    string
+		secret string
+		events []EventType
+	}
+)
+
+// eventTypes defines the known event types.
+var eventTypes = map[EventType]struct{}{
+	EventURLCreated: {},
+	EventURLDeleted: {},
+	EventURLClicked: {},
+}
+
+// ParseEventType implements parsing the event type from a string.
+func ParseEventType(value string) (EventType, error) {
+	event := EventType(value)
+	if _, ok := eventTypes[event]; !ok {
+		return "", ErrInvalidEventType
+	}
+	return event, nil
+}
+
+// ID implements getting webhook ID.
+func (e *entity) ID() uuid.UUID {
+	return e.id
+}
+
+// UserID implements getting the user ID owning the webhook.
+func (e *entity) UserID() uuid.UUID {
+	return e.userID
+}
+
+// URL implements getting the webhook endpoint URL.
+func (e *entity) URL() string {
+	return e.url
+}
+
+// Secret implements getting the key of the payload HMAC signature.
+func (e *entity) Secret() string {
+	return e.secret
+}
+
+// Events implements getting the event types the webhook is subscribed to.
+func (e *entity) Events() []EventType {
+	return e.events
+}
+
+// Subscribed implements checking that the webhook is subscribed to the event type.
+func (e *entity) Subscribed(event EventType) bool {
+	for _, v := range e.events {
+		if v == event {
+			return true
+		}
+	}
+	return false
+}
+
+// SetURL implements the setting of the webhook endpoint URL.
+func (e *entity) SetURL(value string) {
+	e.url = value
+}
+
+// SetSecret implements the setting of the key of the payload HMAC signature.
+func (e *entity) SetSecret(value string) {
+	e.secret = value
+}
+
+// SetEvents implements the setting of the event types the webhook is subscribed to.
+func (e *entity) SetEvents(value []EventType) {
+	e.events = value
+}
+
+// NewWebhook implements the creation of the webhook type.
+func NewWebhook(id, userID uuid.UUID) *entity {
+	return &entity{
+		id:     id,
+		userID: userID,
+	}
+}
+
+// sharedAddressSpace defines the carrier-grade NAT addresses (RFC 6598), they are not reachable from the internet.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// PublicAddress implements checking that the webhook endpoint address is reachable from the internet.
+// Loopback, private, link-local, multicast and unspecified addresses reach the server network only.
+func PublicAddress(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
@@ -0,0 +1,14 @@
+package workspace
+
+import (
+	"errors"
+)
+
+// ErrNotFound implements workspace not found error.
+var ErrNotFound = errors.New("workspace not found")
+
+// ErrForbidden implements workspace permission denied error.
+var ErrForbidden = errors.New("workspace permission denied")
+
+// ErrInvalidRole implements invalid workspace role error.
+var ErrInvalidRole = errors.New("invalid workspace role")
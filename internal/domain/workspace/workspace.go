@@ -0,0 +1,109 @@
+// Package workspace implements and describes the type of workspace sharing short URLs between users.
+package workspace
+
+import (
+	"github.com/google/uuid"
+)
+
+type (
+	// Role describes the permissions of a workspace member.
+	Role string
+
+	// Workspace describes the implementation of the workspace type.
+	Workspace interface {
+		ID() uuid.UUID
+		Name() string
+		Role() Role
+		SetName(value string)
+		SetRole(value Role)
+	}
+
+	entity struct {
+		id   uuid.UUID
+		name string
+		role Role
+	}
+)
+
+const (
+	// RoleViewer allows listing workspace short URLs.
+	RoleViewer Role = "viewer"
+	// RoleEditor additionally allows updating and deleting workspace short URLs.
+	RoleEditor Role = "editor"
+	// RoleAdmin additionally allows managing workspace members.
+	RoleAdmin Role = "admin"
+)
+
+// roleRank defines the order of roles, every role includes the permissions of lower ones.
+var roleRank = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// ParseRole implements parsing the role from a string.
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	if _, ok := roleRank[role]; !ok {
+		return "", ErrInvalidRole
+	}
+	return role, nil
+}
+
+// Allows implements checking that the role grants the permissions of the required role.
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRank[r]
+	return ok && rank >= roleRank[required]
+}
+
+// RolesAllowing implements getting the roles that grant the permissions of the required role.
+func RolesAllowing(required Role) []Role {
+	roles := make([]Role, 0, len(roleRank))
+	for _, role := range []Role{RoleViewer, RoleEditor, RoleAdmin} {
+		if role.Allows(required) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// Personal implements checking that the workspace is the personal workspace of the user.
+//
+// Every user implicitly administers the workspace with the same ID as the user ID,
+// short URLs are created there unless they are moved to a shared workspace.
+func Personal(workspaceID, userID uuid.UUID) bool {
+	return workspaceID == userID
+}
+
+// ID implements getting workspace ID.
+func (e *entity) ID() uuid.UUID {
+	return e.id
+}
+
+// Name implements getting workspace name.
+func (e *entity) Name() string {
+	return e.name
+}
+
+// Role implements getting the role of the current member.
+func (e *entity) Role() Role {
+	return e.role
+}
+
+// SetName implements the setting of the workspace name.
+func (e *entity) SetName(value string) {
+	e.name = value
+}
+
+// SetRole implements the setting of the role of the current member.
+func (e *entity) SetRole(value Role) {
+	e.role = value
+}
+
+// NewWorkspace implements the creation of the workspace type.
+func NewWorkspace(id uuid.UUID, name string) *entity {
+	return &entity{
+		id:   id,
+		name: name,
+	}
+}
@@ -0,0 +1,130 @@
+package bolt
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	bbolt "go.etcd.io/bbolt"
+	"golang.org/x/exp/slog"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+// Search implements getting short URLs of all users matching the filter ordered by ID.
+func (r *repo) Search(_ context.Context, filter entity.Filter) ([]entity.URL, error) {
+	urls := make([]entity.URL, 0)
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		var skipped int
+		c := tx.Bucket(bucketURLs).Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			if filter.Limit > 0 && len(urls) == filter.Limit {
+				return nil
+			}
+
+			id, err := uuid.FromBytes(k)
+			if err != nil {
+				return err
+			}
+
+			v, _, err := get(tx, id)
+			if err != nil {
+				return err
+			}
+
+			if !strings.Contains(v.Value, filter.Query) {
+				continue
+			}
+
+			if filter.UserID != uuid.Nil && v.UserID != filter.UserID {
+				continue
+			}
+
+			if skipped < filter.Offset {
+				skipped++
+				continue
+			}
+
+			u, err := v.entity(id)
+			if err != nil {
+				r.logger.Error("failed parse raw url", err, slog.String("func", "Search"),
+					slog.String("url", v.Value))
+				return err
+			}
+			urls = append(urls, u)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+// SetDisabled implements the setting of the moderation attribute of the short URL.
+func (r *repo) SetDisabled(_ context.Context, id uuid.UUID, disabled bool) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		v, ok, err := get(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return entity.NewURLErr(id, uuid.UUID{}, entity.ErrNotFound)
+		}
+
+		v.Disabled = disabled
+		return put(tx, id, v)
+	})
+}
+
+// ForceDelete implements the removal of short URLs regardless of the owner.
+func (r *repo) ForceDelete(_ context.Context, ids []uuid.UUID) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		for _, id := range ids {
+			v, ok, err := get(tx, id)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				continue
+			}
+
+			if err = remove(tx, id, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("failed delete urls", err, slog.String("func", "ForceDelete"))
+		return err
+	}
+	return nil
+}
+
+// BanUser implements the setting of the user ban.
+func (r *repo) BanUser(_ context.Context, userID uuid.UUID, banned bool) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		if banned {
+			return tx.Bucket(bucketBanned).Put(userID[:], nil)
+		}
+		return tx.Bucket(bucketBanned).Delete(userID[:])
+	})
+	if err != nil {
+		r.logger.Error("failed update user ban", err, slog.String("func", "BanUser"))
+		return err
+	}
+	return nil
+}
+
+// IsBanned implements checking the user ban.
+func (r *repo) IsBanned(_ context.Context, userID uuid.UUID) (bool, error) {
+	var banned bool
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		banned = tx.Bucket(bucketBanned).Get(userID[:]) != nil
+		return nil
+	})
+	return banned, err
+}
@@ -0,0 +1,228 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	bbolt "go.etcd.io/bbolt"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+)
+
+// storageTask describes the deferred task type stored in the tasks bucket.
+type storageTask struct {
+	ID        uuid.UUID                  `json:"id"`
+	Action    task.Action                `json:"action"`
+	UserID    uuid.UUID                  `json:"user_id"`
+	URLIDs    []uuid.UUID                `json:"url_ids"`
+	Status    task.Status                `json:"status"`
+	Attempts  int                        `json:"attempts"`
+	LastError string                     `json:"last_error,omitempty"`
+	Outcomes  map[uuid.UUID]task.Outcome `json:"outcomes,omitempty"`
+	RunAt     time.Time                  `json:"run_at"`
+	CreatedAt time.Time                  `json:"created_at"`
+}
+
+// AddTask implements saving the deferred task.
+func (r *repo) AddTask(_ context.Context, item task.Task) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		return putTask(tx, storageTask(item))
+	})
+	if err != nil {
+		r.logger.Error("failed add task", err, slog.String("func", "AddTask"))
+		return err
+	}
+	return nil
+}
+
+// ClaimTasks implements marking the due tasks of the action running until the lease ends.
+// The storage file is opened by the single process, so the claim is exclusive within the write transaction.
+func (r *repo) ClaimTasks(_ context.Context, action task.Action, limit int, lease time.Duration) ([]task.Task, error) {
+	tasks := make([]task.Task, 0)
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		now := time.Now()
+		due := make([]storageTask, 0)
+		err := tx.Bucket(bucketTasks).ForEach(func(_, data []byte) error {
+			var v storageTask
+			if err := json.Unmarshal(data, &v); err != nil {
+				return err
+			}
+			if v.Action != action || v.RunAt.After(now) {
+				return nil
+			}
+			if v.Status == task.StatusPending || v.Status == task.StatusRunning {
+				due = append(due, v)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		sort.Slice(due, func(i, j int) bool {
+			return due[i].RunAt.Before(due[j].RunAt)
+		})
+		if len(due) > limit {
+			due = due[:limit]
+		}
+
+		for _, v := range due {
+			v.Status = task.StatusRunning
+			v.Attempts++
+			v.RunAt = now.Add(lease)
+			if err = putTask(tx, v); err != nil {
+				return err
+			}
+			tasks = append(tasks, task.Task(v))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTask implements getting the deferred task.
+func (r *repo) GetTask(_ context.Context, id uuid.UUID) (task.Task, error) {
+	var v storageTask
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		v, err = getTask(tx, id)
+		return err
+	})
+	if err != nil {
+		return task.Task{}, err
+	}
+	return task.Task(v), nil
+}
+
+// CompleteTask implements marking the task of the claimed attempt processed with the outcomes for its short URLs.
+func (r *repo) CompleteTask(_ context.Context, id uuid.UUID, attempt int,
+	outcomes map[uuid.UUID]task.Outcome,
+) error {
+	return r.updateTask(id, attempt, func(v *storageTask) {
+		v.Status = task.StatusDone
+		v.RunAt = time.Now()
+		v.LastError = ""
+		v.Outcomes = outcomes
+	})
+}
+
+// RetryTask implements returning the failed task of the claimed attempt to the queue until the run time.
+func (r *repo) RetryTask(_ context.Context, id uuid.UUID, attempt int, runAt time.Time, reason string) error {
+	return r.setTaskStatus(id, attempt, task.StatusPending, runAt, reason)
+}
+
+// FailTask implements moving the task of the claimed attempt whose attempts are exhausted to the dead state.
+func (r *repo) FailTask(_ context.Context, id uuid.UUID, attempt int, reason string) error {
+	return r.setTaskStatus(id, attempt, task.StatusDead, time.Now(), reason)
+}
+
+// CountTasks implements getting the number of the tasks in the processing state.
+func (r *repo) CountTasks(_ context.Context, status task.Status) (int, error) {
+	var count int
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketTasks).ForEach(func(_, data []byte) error {
+			var v storageTask
+			if err := json.Unmarshal(data, &v); err != nil {
+				return err
+			}
+			if v.Status == status {
+				count++
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		r.logger.Error("failed count tasks", err, slog.String("func", "CountTasks"))
+		return 0, err
+	}
+	return count, nil
+}
+
+// PurgeTasks implements deleting the tasks processed before the time, the number of deleted tasks is returned.
+func (r *repo) PurgeTasks(_ context.Context, before time.Time) (int, error) {
+	var count int
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		keys := make([][]byte, 0)
+		b := tx.Bucket(bucketTasks)
+		err := b.ForEach(func(k, data []byte) error {
+			var v storageTask
+			if err := json.Unmarshal(data, &v); err != nil {
+				return err
+			}
+			if v.Status == task.StatusDone && v.RunAt.Before(before) {
+				keys = append(keys, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// the bucket is not changed while it is iterated
+		for _, k := range keys {
+			if err = b.Delete(k); err != nil {
+				return err
+			}
+		}
+		count = len(keys)
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("failed purge tasks", err, slog.String("func", "PurgeTasks"))
+		return 0, err
+	}
+	return count, nil
+}
+
+// setTaskStatus implements changing the processing state of the task of the claimed attempt.
+func (r *repo) setTaskStatus(id uuid.UUID, attempt int, status task.Status, runAt time.Time, reason string) error {
+	return r.updateTask(id, attempt, func(v *storageTask) {
+		v.Status = status
+		v.RunAt = runAt
+		v.LastError = reason
+	})
+}
+
+// updateTask implements changing the running task of the claimed attempt by fn within the write transaction,
+// task.ErrLeaseLost is returned when the task was claimed again.
+func (r *repo) updateTask(id uuid.UUID, attempt int, fn func(v *storageTask)) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		v, err := getTask(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if v.Status != task.StatusRunning || v.Attempts != attempt {
+			return task.ErrLeaseLost
+		}
+
+		fn(&v)
+		return putTask(tx, v)
+	})
+}
+
+// getTask implements reading the stored task, task.ErrNotFound is returned when it does not exist.
+func getTask(tx *bbolt.Tx, id uuid.UUID) (storageTask, error) {
+	var v storageTask
+	data := tx.Bucket(bucketTasks).Get(id[:])
+	if data == nil {
+		return v, task.ErrNotFound
+	}
+	return v, json.Unmarshal(data, &v)
+}
+
+// putTask implements writing the stored task.
+func putTask(tx *bbolt.Tx, v storageTask) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(bucketTasks).Put(v.ID[:], data)
+}
@@ -0,0 +1,28 @@
+package bolt
+
+import (
+	"context"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/repository/storage/driver"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// DriverName defines the name of the embedded bbolt storage driver.
+const DriverName = "bolt"
+
+func init() {
+	driver.Register(DriverName, driver.Driver{
+		Open: func(_ context.Context, cfg config.Storage) (storage.URL, error) {
+			r, err := New(cfg.GetBolt())
+			if err != nil {
+				return nil, err
+			}
+			return r, nil
+		},
+		Configured: func(cfg config.Storage) bool {
+			return len(cfg.GetBolt().GetPath()) > 0
+		},
+		Priority: 30,
+	})
+}
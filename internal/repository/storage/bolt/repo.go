@@ -0,0 +1,511 @@
+// Package bolt implements a repository for storing short URLs in the embedded bbolt key-value storage.
+//
+// The short URLs are kept in the urls bucket by ID, the user_urls bucket indexes them by the user ID
+// and the original_urls bucket keeps the unique (user_id, original_url) constraint. Workspaces are not
+// stored, so the short URLs are editable in the personal workspace of the user only.
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+
+	"github.com/google/uuid"
+	bbolt "go.etcd.io/bbolt"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/task"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/workspace"
+)
+
+var (
+	// bucketURLs defines the bucket of short URLs by ID.
+	bucketURLs = []byte("urls")
+	// bucketUserURLs defines the index of short URLs by the user ID, the key is the user ID followed by the ID.
+	bucketUserURLs = []byte("user_urls")
+	// bucketOriginalURLs defines the unique index of the original URLs of the user,
+	// the key is the user ID followed by the original URL.
+	bucketOriginalURLs = []byte("original_urls")
+	// bucketBanned defines the bucket of banned user IDs.
+	bucketBanned = []byte("banned_users")
+	// bucketTasks defines the bucket of deferred tasks by ID.
+	bucketTasks = []byte("tasks")
+)
+
+type (
+	repo struct {
+		db     *bbolt.DB
+		logger *slog.Logger
+	}
+
+	// storageURL describes the short URL type stored in the urls bucket.
+	storageURL struct {
+		UserID      uuid.UUID `json:"user_id"`
+		WorkspaceID uuid.UUID `json:"workspace_id"`
+		Value       string    `json:"value"`
+		Deleted     bool      `json:"deleted,omitempty"`
+		Disabled    bool      `json:"disabled,omitempty"`
+	}
+)
+
+// Ping implements health check storage.
+func (r *repo) Ping(_ context.Context) error {
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		if tx.Bucket(bucketURLs) == nil {
+			return bbolt.ErrBucketNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("failed check storage", err, slog.String("func", "Ping"))
+		return err
+	}
+	return nil
+}
+
+// Add implements saving short URL.
+func (r *repo) Add(_ context.Context, item entity.URL) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		if id, ok := lookupOriginal(tx, item.UserID(), item.LongURL()); ok {
+			return entity.NewURLErr(id, uuid.UUID{}, entity.ErrAlreadyExist)
+		}
+
+		return put(tx, item.ID(), newStorageURL(item))
+	})
+}
+
+// Get implements getting short URL.
+func (r *repo) Get(_ context.Context, id uuid.UUID) (entity.URL, error) {
+	var u entity.URL
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		v, ok, err := get(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return entity.NewURLErr(id, uuid.UUID{}, entity.ErrNotFound)
+		}
+
+		u, err = v.entity(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// GetByUserID implements getting short URLs for user ID.
+func (r *repo) GetByUserID(_ context.Context, userID uuid.UUID) ([]entity.URL, error) {
+	urls := make([]entity.URL, 0)
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		return userURLs(tx, userID, func(id uuid.UUID, v storageURL) error {
+			u, err := v.entity(id)
+			if err != nil {
+				return err
+			}
+			urls = append(urls, u)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+// GetByWorkspaceID implements getting short URLs of the workspace visible to the user ID,
+// only the personal workspace of the user is visible.
+func (r *repo) GetByWorkspaceID(ctx context.Context, workspaceID, userID uuid.UUID) ([]entity.URL, error) {
+	urls := make([]entity.URL, 0)
+	if !workspace.Personal(workspaceID, userID) {
+		return urls, nil
+	}
+
+	owned, err := r.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range owned {
+		if u.WorkspaceID() == workspaceID {
+			urls = append(urls, u)
+		}
+	}
+	return urls, nil
+}
+
+// ScanIDs implements passing the IDs of all short URLs to fn, the scan stops at the first error of fn.
+func (r *repo) ScanIDs(_ context.Context, fn func(id uuid.UUID) error) error {
+	return r.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketURLs).ForEach(func(k, _ []byte) error {
+			id, err := uuid.FromBytes(k)
+			if err != nil {
+				return err
+			}
+			return fn(id)
+		})
+	})
+}
+
+// CountRecords implements counting the banned users, the storage keeps no other records besides short URLs.
+func (r *repo) CountRecords(_ context.Context) (map[string]int, error) {
+	var n int
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		n = tx.Bucket(bucketBanned).Stats().KeyN
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	if n > 0 {
+		counts["banned_users"] = n
+	}
+	return counts, nil
+}
+
+// Import implements saving short URLs copied from another storage with all their attributes,
+// the short URLs stored under the same ID are replaced. Nothing is saved when one of them conflicts.
+func (r *repo) Import(_ context.Context, urls []entity.URL) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		for _, item := range urls {
+			if id, ok := lookupOriginal(tx, item.UserID(), item.LongURL()); ok && id != item.ID() {
+				return entity.NewURLErr(item.ID(), item.UserID(), entity.ErrAlreadyExist)
+			}
+
+			v, ok, err := get(tx, item.ID())
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				if err = remove(tx, item.ID(), v); err != nil {
+					return err
+				}
+			}
+
+			if err = put(tx, item.ID(), newStorageURL(item)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Update implements changing the long URL and the owning workspace of the short URL.
+func (r *repo) Update(_ context.Context, item entity.URL) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		v, ok, err := get(tx, item.ID())
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return entity.NewURLErr(item.ID(), item.UserID(), entity.ErrNotFound)
+		}
+
+		if !workspace.Personal(v.WorkspaceID, item.UserID()) || !workspace.Personal(item.WorkspaceID(), item.UserID()) {
+			return entity.NewURLErr(item.ID(), item.UserID(), workspace.ErrForbidden)
+		}
+
+		if id, ok := lookupOriginal(tx, v.UserID, item.LongURL()); ok && id != item.ID() {
+			return entity.NewURLErr(item.ID(), item.UserID(), entity.ErrAlreadyExist)
+		}
+
+		if err = remove(tx, item.ID(), v); err != nil {
+			return err
+		}
+
+		v.WorkspaceID = item.WorkspaceID()
+		v.Value = item.LongURL()
+		return put(tx, item.ID(), v)
+	})
+}
+
+// Close implements closing the storage file.
+func (r *repo) Close() error {
+	if err := r.db.Close(); err != nil {
+		r.logger.Error("failed close storage file", err, slog.String("func", "Close"))
+		return err
+	}
+	return nil
+}
+
+// Batch implements saving multiple short URLs, no short URL is saved when some of them exist.
+// The returned error keeps the existing IDs, items repeating a URL of the batch are not reported as existing.
+func (r *repo) Batch(_ context.Context, urls []entity.URL) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		var (
+			inserted = make(map[uuid.UUID]bool, len(urls))
+			existing = make(map[uuid.UUID]uuid.UUID)
+			conflict bool
+		)
+		for _, item := range urls {
+			if id, ok := lookupOriginal(tx, item.UserID(), item.LongURL()); ok {
+				conflict = true
+				if !inserted[id] {
+					existing[item.ID()] = id
+				}
+				continue
+			}
+
+			if err := put(tx, item.ID(), newStorageURL(item)); err != nil {
+				return err
+			}
+			inserted[item.ID()] = true
+		}
+
+		if conflict {
+			return entity.NewBatchErr(existing, entity.ErrAlreadyExist)
+		}
+		return nil
+	})
+}
+
+// BatchDelete implements the deletion multiple short URLs.
+func (r *repo) BatchDelete(_ context.Context, urls []entity.URL) (map[uuid.UUID]task.Outcome, error) {
+	outcomes := make(map[uuid.UUID]task.Outcome, len(urls))
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		for _, item := range urls {
+			v, ok, err := get(tx, item.ID())
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case !ok:
+				outcomes[item.ID()] = task.OutcomeNotFound
+			case !workspace.Personal(v.WorkspaceID, item.UserID()):
+				outcomes[item.ID()] = task.OutcomeNotOwned
+			default:
+				v.Deleted = true
+				if err = put(tx, item.ID(), v); err != nil {
+					return err
+				}
+				outcomes[item.ID()] = task.OutcomeDeleted
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("failed delete urls", err, slog.String("func", "BatchDelete"))
+		return nil, err
+	}
+	return outcomes, nil
+}
+
+// ChangeOwner implements moving short URLs to another user ID.
+// URLs already shortened by the new owner stay with the previous one,
+// URLs of the personal workspace are moved to the personal workspace of the new owner.
+func (r *repo) ChangeOwner(_ context.Context, from, to uuid.UUID) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		moved := make(map[uuid.UUID]storageURL)
+		err := userURLs(tx, from, func(id uuid.UUID, v storageURL) error {
+			if _, ok := lookupOriginal(tx, to, v.Value); ok {
+				return nil
+			}
+			moved[id] = v
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for id, v := range moved {
+			if err = remove(tx, id, v); err != nil {
+				return err
+			}
+
+			v.UserID = to
+			if workspace.Personal(v.WorkspaceID, from) {
+				v.WorkspaceID = to
+			}
+
+			if err = put(tx, id, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetUserCount implements the getting user count stat.
+func (r *repo) GetUserCount(_ context.Context) (int, error) {
+	var counter int
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		var last []byte
+		return tx.Bucket(bucketUserURLs).ForEach(func(k, _ []byte) error {
+			if !bytes.Equal(k[:16], last) {
+				counter++
+				last = append(last[:0], k[:16]...)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return counter, nil
+}
+
+// GetURLCount implements the getting url count stat.
+func (r *repo) GetURLCount(_ context.Context) (int, error) {
+	var counter int
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		counter = tx.Bucket(bucketURLs).Stats().KeyN
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return counter, nil
+}
+
+// newStorageURL implements the creation of the stored short URL type.
+func newStorageURL(item entity.URL) storageURL {
+	return storageURL{
+		UserID:      item.UserID(),
+		WorkspaceID: item.WorkspaceID(),
+		Value:       item.LongURL(),
+		Deleted:     item.Deleted(),
+		Disabled:    item.Disabled(),
+	}
+}
+
+// entity implements the creation of the short URL type from the stored value.
+func (v storageURL) entity(id uuid.UUID) (entity.URL, error) {
+	value, err := url.ParseRequestURI(v.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	u := entity.NewURL(id, v.UserID)
+	u.SetWorkspaceID(v.WorkspaceID)
+	u.SetLongURL(*value)
+	u.SetDeleted(v.Deleted)
+	u.SetDisabled(v.Disabled)
+	return u, nil
+}
+
+// get implements reading the stored short URL, false is returned when it does not exist.
+func get(tx *bbolt.Tx, id uuid.UUID) (storageURL, bool, error) {
+	var v storageURL
+	data := tx.Bucket(bucketURLs).Get(id[:])
+	if data == nil {
+		return v, false, nil
+	}
+	return v, true, json.Unmarshal(data, &v)
+}
+
+// put implements writing the stored short URL with its index keys.
+func put(tx *bbolt.Tx, id uuid.UUID, v storageURL) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Bucket(bucketURLs).Put(id[:], data); err != nil {
+		return err
+	}
+
+	if err = tx.Bucket(bucketUserURLs).Put(userKey(v.UserID, id), nil); err != nil {
+		return err
+	}
+
+	return tx.Bucket(bucketOriginalURLs).Put(originalKey(v.UserID, v.Value), id[:])
+}
+
+// remove implements deleting the stored short URL with its index keys.
+func remove(tx *bbolt.Tx, id uuid.UUID, v storageURL) error {
+	if err := tx.Bucket(bucketURLs).Delete(id[:]); err != nil {
+		return err
+	}
+
+	if err := tx.Bucket(bucketUserURLs).Delete(userKey(v.UserID, id)); err != nil {
+		return err
+	}
+
+	return tx.Bucket(bucketOriginalURLs).Delete(originalKey(v.UserID, v.Value))
+}
+
+// lookupOriginal implements getting the ID of the short URL of the original URL shortened by the user.
+func lookupOriginal(tx *bbolt.Tx, userID uuid.UUID, original string) (uuid.UUID, bool) {
+	data := tx.Bucket(bucketOriginalURLs).Get(originalKey(userID, original))
+	if data == nil {
+		return uuid.UUID{}, false
+	}
+
+	id, err := uuid.FromBytes(data)
+	return id, err == nil
+}
+
+// userURLs implements passing the stored short URLs of the user to fn.
+func userURLs(tx *bbolt.Tx, userID uuid.UUID, fn func(id uuid.UUID, v storageURL) error) error {
+	c := tx.Bucket(bucketUserURLs).Cursor()
+	for k, _ := c.Seek(userID[:]); k != nil && bytes.HasPrefix(k, userID[:]); k, _ = c.Next() {
+		id, err := uuid.FromBytes(k[16:])
+		if err != nil {
+			return err
+		}
+
+		v, ok, err := get(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err = fn(id, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// userKey implements the key of the user index.
+func userKey(userID, id uuid.UUID) []byte {
+	return append(append(make([]byte, 0, 32), userID[:]...), id[:]...)
+}
+
+// originalKey implements the key of the unique index of the original URLs.
+func originalKey(userID uuid.UUID, original string) []byte {
+	return append(append(make([]byte, 0, 16+len(original)), userID[:]...), original...)
+}
+
+// New implements the creation of storage.
+func New(cfg config.Bolt) (*repo, error) {
+	log := slog.New(slog.NewJSONHandler(os.Stdout).
+		WithAttrs([]slog.Attr{slog.String("repository", "bolt")}))
+
+	db, err := bbolt.Open(cfg.GetPath(), 0o600, &bbolt.Options{Timeout: cfg.GetTimeout()})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{bucketURLs, bucketUserURLs, bucketOriginalURLs, bucketBanned, bucketTasks} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &repo{
+		db:     db,
+		logger: log,
+	}, nil
+}
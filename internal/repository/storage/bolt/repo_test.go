@@ -0,0 +1,277 @@
+package bolt
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/workspace"
+)
+
+// testConfig implements the embedded bbolt storage configuration with the file in the test directory.
+type testConfig struct {
+	path string
+}
+
+func (c testConfig) GetPath() string           { return c.path }
+func (c testConfig) GetTimeout() time.Duration { return time.Second }
+
+func newTestRepo(t *testing.T) *repo {
+	r, err := New(testConfig{path: filepath.Join(t.TempDir(), "shorturl.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = r.Close()
+	})
+	return r
+}
+
+func newTestURL(id, userID uuid.UUID, rawURL string) entity.URL {
+	value, _ := url.Parse(rawURL)
+	u := entity.NewURL(id, userID)
+	u.SetWorkspaceID(userID)
+	u.SetLongURL(*value)
+	return u
+}
+
+func Test_repo_Add(t *testing.T) {
+	var (
+		userID  = uuid.MustParse("1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f")
+		otherID = uuid.MustParse("2d3e4f5a-6b7c-4d8e-9f0a-1b2c3d4e5f6a")
+		id      = uuid.MustParse("3e4f5a6b-7c8d-4e9f-8a0b-2c3d4e5f6a7b")
+	)
+	tests := []struct {
+		name    string
+		url     entity.URL
+		wantID  uuid.UUID
+		wantErr error
+	}{
+		{
+			name: "positive add url (other user)",
+			url:  newTestURL(uuid.New(), otherID, "https://example.com"),
+		},
+		{
+			name:    "negative add url (already exist)",
+			url:     newTestURL(uuid.New(), userID, "https://example.com"),
+			wantID:  id,
+			wantErr: entity.ErrAlreadyExist,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			assert.NoError(t, r.Add(ctx, newTestURL(id, userID, "https://example.com")))
+
+			err := r.Add(ctx, tt.url)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				var errURL *entity.ErrURL
+				assert.True(t, errors.As(err, &errURL))
+				assert.Equal(t, tt.wantID, errURL.ID())
+				return
+			}
+
+			got, err := r.Get(ctx, tt.url.ID())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.url.LongURL(), got.LongURL())
+			assert.Equal(t, tt.url.UserID(), got.UserID())
+		})
+	}
+}
+
+func Test_repo_Update(t *testing.T) {
+	var (
+		userID = uuid.MustParse("4f5a6b7c-8d9e-4f0a-9b1c-3d4e5f6a7b8c")
+		id     = uuid.MustParse("5a6b7c8d-9e0f-4a1b-8c2d-4e5f6a7b8c9d")
+		sameID = uuid.MustParse("6b7c8d9e-0f1a-4b2c-9d3e-5f6a7b8c9d0e")
+	)
+	tests := []struct {
+		name    string
+		url     entity.URL
+		wantErr error
+	}{
+		{
+			name: "positive update url",
+			url:  newTestURL(id, userID, "https://example.com/updated"),
+		},
+		{
+			name:    "negative update url (already exist)",
+			url:     newTestURL(id, userID, "https://example.com/same"),
+			wantErr: entity.ErrAlreadyExist,
+		},
+		{
+			name:    "negative update url (not found)",
+			url:     newTestURL(uuid.New(), userID, "https://example.com/updated"),
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name:    "negative update url (other user)",
+			url:     newTestURL(id, uuid.New(), "https://example.com/updated"),
+			wantErr: workspace.ErrForbidden,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			assert.NoError(t, r.Add(ctx, newTestURL(id, userID, "https://example.com")))
+			assert.NoError(t, r.Add(ctx, newTestURL(sameID, userID, "https://example.com/same")))
+
+			err := r.Update(ctx, tt.url)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+
+			got, err := r.Get(ctx, id)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.url.LongURL(), got.LongURL())
+
+			// the previous original URL is released by the update
+			assert.NoError(t, r.Add(ctx, newTestURL(uuid.New(), userID, "https://example.com")))
+		})
+	}
+}
+
+func Test_repo_Batch(t *testing.T) {
+	var (
+		userID     = uuid.MustParse("7c8d9e0f-1a2b-4c3d-8e4f-6a7b8c9d0e1f")
+		existingID = uuid.MustParse("6b7c8d9e-0f1a-4b2c-9d3e-5f6a7b8c9d0e")
+		conflictID = uuid.MustParse("5a6b7c8d-9e0f-4a1b-8c2d-4e5f6a7b8c9d")
+	)
+	tests := []struct {
+		name         string
+		urls         []entity.URL
+		wantCount    int
+		wantExisting map[uuid.UUID]uuid.UUID
+		wantErr      error
+	}{
+		{
+			name: "positive batch urls",
+			urls: []entity.URL{
+				newTestURL(uuid.New(), userID, "https://example.com/1"),
+				newTestURL(uuid.New(), userID, "https://example.com/2"),
+			},
+			wantCount: 3,
+		},
+		{
+			name: "negative batch urls (already exist)",
+			urls: []entity.URL{
+				newTestURL(uuid.New(), userID, "https://example.com/1"),
+				newTestURL(conflictID, userID, "https://example.com"),
+			},
+			wantCount:    1,
+			wantExisting: map[uuid.UUID]uuid.UUID{conflictID: existingID},
+			wantErr:      entity.ErrAlreadyExist,
+		},
+		{
+			name: "negative batch urls (repeated in batch)",
+			urls: []entity.URL{
+				newTestURL(uuid.New(), userID, "https://example.com/1"),
+				newTestURL(uuid.New(), userID, "https://example.com/1"),
+			},
+			wantCount:    1,
+			wantExisting: map[uuid.UUID]uuid.UUID{},
+			wantErr:      entity.ErrAlreadyExist,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			assert.NoError(t, r.Add(ctx, newTestURL(existingID, userID, "https://example.com")))
+
+			err := r.Batch(ctx, tt.urls)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				var errBatch *entity.ErrBatch
+				assert.True(t, errors.As(err, &errBatch))
+				assert.Equal(t, tt.wantExisting, errBatch.Existing())
+			}
+
+			count, err := r.GetURLCount(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
+
+func Test_repo_BatchDelete(t *testing.T) {
+	var (
+		userID    = uuid.MustParse("8d9e0f1a-2b3c-4d4e-9f5a-7b8c9d0e1f2a")
+		ownedID   = uuid.MustParse("9e0f1a2b-3c4d-4e5f-8a6b-8c9d0e1f2a3b")
+		otherID   = uuid.MustParse("0f1a2b3c-4d5e-4f6a-9b7c-9d0e1f2a3b4c")
+		missingID = uuid.MustParse("1a2b3c4d-5e6f-4a7b-8c8d-0e1f2a3b4c5d")
+	)
+	ctx := context.Background()
+	r := newTestRepo(t)
+	assert.NoError(t, r.Add(ctx, newTestURL(ownedID, userID, "https://example.com/owned")))
+	assert.NoError(t, r.Add(ctx, newTestURL(otherID, uuid.New(), "https://example.com/other")))
+
+	outcomes, err := r.BatchDelete(ctx, []entity.URL{
+		entity.NewURL(ownedID, userID),
+		entity.NewURL(otherID, userID),
+		entity.NewURL(missingID, userID),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]task.Outcome{
+		ownedID:   task.OutcomeDeleted,
+		otherID:   task.OutcomeNotOwned,
+		missingID: task.OutcomeNotFound,
+	}, outcomes)
+
+	u, err := r.Get(ctx, ownedID)
+	assert.NoError(t, err)
+	assert.True(t, u.Deleted())
+
+	u, err = r.Get(ctx, otherID)
+	assert.NoError(t, err)
+	assert.False(t, u.Deleted())
+}
+
+func Test_repo_ChangeOwner(t *testing.T) {
+	var (
+		from    = uuid.MustParse("2b3c4d5e-6f7a-4b8c-9d9e-1f2a3b4c5d6e")
+		to      = uuid.MustParse("3c4d5e6f-7a8b-4c9d-8e0f-2a3b4c5d6e7f")
+		movedID = uuid.MustParse("4d5e6f7a-8b9c-4d0e-9f1a-3b4c5d6e7f8a")
+		keptID  = uuid.MustParse("5e6f7a8b-9c0d-4e1f-8a2b-4c5d6e7f8a9b")
+	)
+	ctx := context.Background()
+	r := newTestRepo(t)
+	assert.NoError(t, r.Add(ctx, newTestURL(movedID, from, "https://example.com/moved")))
+	assert.NoError(t, r.Add(ctx, newTestURL(keptID, from, "https://example.com/kept")))
+	assert.NoError(t, r.Add(ctx, newTestURL(uuid.New(), to, "https://example.com/kept")))
+
+	assert.NoError(t, r.ChangeOwner(ctx, from, to))
+
+	moved, err := r.GetByUserID(ctx, to)
+	assert.NoError(t, err)
+	assert.Len(t, moved, 2)
+
+	u, err := r.Get(ctx, movedID)
+	assert.NoError(t, err)
+	assert.Equal(t, to, u.UserID())
+	assert.Equal(t, to, u.WorkspaceID())
+
+	kept, err := r.GetByUserID(ctx, from)
+	assert.NoError(t, err)
+	assert.Len(t, kept, 1)
+	assert.Equal(t, keptID, kept[0].ID())
+
+	count, err := r.GetUserCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sreway/shorturl/internal/domain/account"
+)
+
+// storageAccount describes the account type used in repository.
+type storageAccount struct {
+	ID           uuid.UUID `json:"id"`
+	PasswordHash []byte    `json:"password_hash"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AddAccount implements saving registered user account.
+func (r *repo) AddAccount(_ context.Context, item account.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.accounts[item.Email()]; ok {
+		return account.ErrAlreadyExist
+	}
+
+	r.accounts[item.Email()] = storageAccount{
+		ID:           item.ID(),
+		PasswordHash: item.PasswordHash(),
+		CreatedAt:    item.CreatedAt(),
+	}
+	return nil
+}
+
+// GetAccount implements getting registered user account by email.
+func (r *repo) GetAccount(_ context.Context, email string) (account.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i, ok := r.accounts[email]
+	if !ok {
+		return nil, account.ErrNotFound
+	}
+
+	a := account.NewAccount(i.ID, email)
+	a.SetPasswordHash(i.PasswordHash)
+	a.SetCreatedAt(i.CreatedAt)
+	return a, nil
+}
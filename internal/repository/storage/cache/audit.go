@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sreway/shorturl/internal/domain/audit"
+)
+
+// storageEvent describes the audit log event type used in repository.
+type storageEvent struct {
+	ID        uuid.UUID       `json:"id"`
+	Action    audit.Action    `json:"action"`
+	UserID    uuid.UUID       `json:"user_id"`
+	TargetID  uuid.UUID       `json:"target_id"`
+	Transport audit.Transport `json:"transport"`
+	Actor     string          `json:"actor,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// AddEvents implements appending audit log events.
+// Events are written to the JSON lines file when it is set and kept in memory ordered by creation time,
+// so queries do not read the file.
+func (r *repo) AddEvents(_ context.Context, events []audit.Event) error {
+	r.auditMu.Lock()
+	defer r.auditMu.Unlock()
+
+	added := make([]storageEvent, len(events))
+	for idx, item := range events {
+		added[idx] = storageEvent{
+			ID:        item.ID(),
+			Action:    item.Action(),
+			UserID:    item.UserID(),
+			TargetID:  item.TargetID(),
+			Transport: item.Transport(),
+			Actor:     item.Actor(),
+			CreatedAt: item.CreatedAt(),
+		}
+	}
+
+	if r.auditFile != nil {
+		encoder := json.NewEncoder(r.auditFile)
+		for _, v := range added {
+			if err := encoder.Encode(v); err != nil {
+				return err
+			}
+		}
+		if err := r.auditFile.Sync(); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range added {
+		r.auditInsert(v)
+	}
+
+	return nil
+}
+
+// GetEvents implements getting audit log events matching the filter ordered by creation time.
+func (r *repo) GetEvents(_ context.Context, filter audit.Filter) ([]audit.Event, error) {
+	r.auditMu.Lock()
+	defer r.auditMu.Unlock()
+
+	first, last := 0, len(r.events)
+	if !filter.From.IsZero() {
+		first = sort.Search(len(r.events), func(i int) bool {
+			return !r.events[i].CreatedAt.Before(filter.From)
+		})
+	}
+	if !filter.To.IsZero() {
+		last = sort.Search(len(r.events), func(i int) bool {
+			return !r.events[i].CreatedAt.Before(filter.To)
+		})
+	}
+
+	result := make([]audit.Event, 0)
+	skipped := 0
+	for idx := first; idx < last; idx++ {
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+		v := r.events[idx]
+		if filter.UserID != uuid.Nil && v.UserID != filter.UserID {
+			continue
+		}
+		if skipped < filter.Offset {
+			skipped++
+			continue
+		}
+		e := audit.NewEvent(v.ID, v.Action, v.UserID, v.TargetID)
+		e.SetTransport(v.Transport)
+		e.SetActor(v.Actor)
+		e.SetCreatedAt(v.CreatedAt)
+		result = append(result, e)
+	}
+
+	return result, nil
+}
+
+// auditInsert implements adding the event to the events ordered by creation time,
+// events of the same time keep the order they were added in.
+func (r *repo) auditInsert(v storageEvent) {
+	idx := sort.Search(len(r.events), func(i int) bool {
+		return r.events[i].CreatedAt.After(v.CreatedAt)
+	})
+	r.events = append(r.events, storageEvent{})
+	copy(r.events[idx+1:], r.events[idx:])
+	r.events[idx] = v
+}
+
+// auditOpen implements the opening of the audit log file for appending,
+// the events written before are read once.
+func (r *repo) auditOpen(path string) error {
+	flag := os.O_WRONLY | os.O_APPEND | os.O_CREATE
+	file, err := os.OpenFile(path, flag, 0o644)
+	if err != nil {
+		return err
+	}
+
+	events, err := auditLoad(path)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	r.events = r.events[:0]
+	for _, v := range events {
+		r.auditInsert(v)
+	}
+	r.auditFile = file
+	return nil
+}
+
+// auditLoad implements reading audit log events from the JSON lines file.
+func auditLoad(path string) ([]storageEvent, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	events := make([]storageEvent, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var v storageEvent
+		if err = json.Unmarshal(scanner.Bytes(), &v); err != nil {
+			return nil, err
+		}
+		events = append(events, v)
+	}
+
+	return events, scanner.Err()
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/audit"
+)
+
+func Test_repo_GetEvents(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	r := New(AuditFile(path))
+
+	userID := uuid.New()
+	now := time.Now().UTC()
+	events := make([]audit.Event, 0)
+	// the events are added out of their creation order, as by the concurrent operations
+	for _, offset := range []int{2, 0, 3, 1} {
+		e := audit.NewEvent(uuid.New(), audit.ActionCreate, userID, uuid.New())
+		e.SetTransport(audit.TransportHTTP)
+		e.SetActor("192.168.88.1")
+		e.SetCreatedAt(now.Add(time.Duration(offset) * time.Minute))
+		events = append(events, e)
+	}
+	other := audit.NewEvent(uuid.New(), audit.ActionBan, uuid.Nil, userID)
+	other.SetCreatedAt(now.Add(90 * time.Second))
+	events = append(events, other)
+	assert.NoError(t, r.AddEvents(ctx, events))
+
+	tests := []struct {
+		name   string
+		filter audit.Filter
+		want   []time.Duration
+	}{
+		{
+			name:   "positive get events",
+			filter: audit.Filter{UserID: userID},
+			want:   []time.Duration{0, time.Minute, 2 * time.Minute, 3 * time.Minute},
+		},
+		{
+			name:   "positive get events (period)",
+			filter: audit.Filter{From: now.Add(time.Minute), To: now.Add(3 * time.Minute)},
+			want:   []time.Duration{time.Minute, 90 * time.Second, 2 * time.Minute},
+		},
+		{
+			name:   "positive get events (limit and offset)",
+			filter: audit.Filter{UserID: userID, Limit: 2, Offset: 1},
+			want:   []time.Duration{time.Minute, 2 * time.Minute},
+		},
+		{
+			name:   "positive get events (offset out of range)",
+			filter: audit.Filter{UserID: userID, Offset: 10},
+			want:   []time.Duration{},
+		},
+	}
+
+	// the events written before are read from the file once it is opened again
+	reopened := New(AuditFile(path))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, storage := range []*repo{r, reopened} {
+				got, err := storage.GetEvents(ctx, tt.filter)
+				assert.NoError(t, err)
+				createdAt := make([]time.Duration, len(got))
+				for idx, e := range got {
+					createdAt[idx] = e.CreatedAt().Sub(now)
+				}
+				assert.Equal(t, tt.want, createdAt)
+			}
+		})
+	}
+
+	got, err := reopened.GetEvents(ctx, audit.Filter{UserID: userID, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.88.1", got[0].Actor())
+	assert.Equal(t, audit.TransportHTTP, got[0].Transport())
+}
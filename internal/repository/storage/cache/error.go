@@ -9,3 +9,15 @@ var ErrEmptyPath = errors.New("empty path")
 
 // ErrInvalidStorageType implements in-memory storage invalid storage type error.
 var ErrInvalidStorageType = errors.New("invalid storage type")
+
+// ErrInvalidInterval implements in-memory storage invalid interval error.
+var ErrInvalidInterval = errors.New("invalid interval")
+
+// ErrUnsupportedVersion implements in-memory storage unsupported file version error.
+var ErrUnsupportedVersion = errors.New("unsupported file version")
+
+// ErrInvalidFormat implements in-memory storage invalid file format error.
+var ErrInvalidFormat = errors.New("invalid file format")
+
+// ErrFileLoad implements in-memory storage failed file load error.
+var ErrFileLoad = errors.New("failed load storage file")
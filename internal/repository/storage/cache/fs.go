@@ -1,15 +1,46 @@
 package cache
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/workspace"
+)
+
+// fileVersion defines the version of the storage file layout written by the repository.
+const fileVersion = 2
+
+// fileMagic defines the header of the storage file in the binary encoding.
+var fileMagic = []byte("SHORTURL\x00")
+
+const (
+	// FormatJSON defines the JSON encoding of the storage file.
+	FormatJSON = "json"
+	// FormatBinary defines the compact binary encoding of the storage file.
+	FormatBinary = "binary"
 )
 
-// fs describes the type of stored data.
+// fs describes the type of stored data. The files without the version keep short URLs in Data,
+// they are upgraded to the current layout on the next store.
 type fs struct {
-	Data map[uuid.UUID]storageURL `json:"data"`
+	Version    int                            `json:"version"`
+	URLs       []fileURL                      `json:"urls,omitempty"`
+	Data       map[uuid.UUID]legacyURL        `json:"data,omitempty"`
+	Accounts   map[string]storageAccount      `json:"accounts,omitempty"`
+	Workspaces map[uuid.UUID]storageWorkspace `json:"workspaces,omitempty"`
+	Banned     []uuid.UUID                    `json:"banned,omitempty"`
+	Reports    []storageReport                `json:"reports,omitempty"`
+	Webhooks   map[uuid.UUID]storageWebhook   `json:"webhooks,omitempty"`
+	Dead       []storageDelivery              `json:"dead_letters,omitempty"`
 }
 
 // fileOpen implements the opening of the storage file.
@@ -19,57 +50,140 @@ func (r *repo) fileOpen(path string) error {
 	if err != nil {
 		return err
 	}
-	r.file = file
-	return nil
+	r.filePath = path
+	return file.Close()
 }
 
-// fileClose implements closing the storage file.
+// fileClose implements storing the storage state to the file and closing the journal file.
 func (r *repo) fileClose() error {
-	err := r.fileStore()
+	err := r.compact()
 	if err != nil {
 		r.logger.Error("failed store data to file", err)
 		return err
 	}
 	r.logger.Info("trigger close repository file")
-	return r.file.Close()
+
+	if r.journal == nil {
+		return nil
+	}
+	return r.journal.Close()
 }
 
-// fileLoad implements loading the storage state from a file.
+// fileLoad implements loading the storage state from a file and replaying the journal file on top of it.
 func (r *repo) fileLoad() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	store := new(fs)
+	file, err := os.Open(r.filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
 
-	if err := json.NewDecoder(r.file).Decode(store); err != nil {
+	store, err := fileDecode(file)
+	if err != nil {
 		return err
 	}
 
-	r.data = store.Data
+	if store.Version > fileVersion {
+		return ErrUnsupportedVersion
+	}
+
+	if store.Version < fileVersion && len(store.Data) > 0 {
+		r.logger.Info("upgrade storage file", slog.Int("version", store.Version),
+			slog.Int("upgrade_version", fileVersion))
+		for id, v := range store.Data {
+			store.URLs = append(store.URLs, v.fileURL(id))
+		}
+	}
+
+	for _, v := range store.URLs {
+		item, err := v.storageURL()
+		if err != nil {
+			return err
+		}
+		r.data[v.ID] = item
+	}
+	if store.Accounts != nil {
+		r.accounts = store.Accounts
+	}
+	if store.Workspaces != nil {
+		r.workspaces = store.Workspaces
+	}
+	for _, userID := range store.Banned {
+		r.banned[userID] = struct{}{}
+	}
+	r.reports = store.Reports
+	if store.Webhooks != nil {
+		r.webhooks = store.Webhooks
+	}
+	r.dead = store.Dead
 	r.logger.Info("success load url data from file")
 
+	if len(r.journalPath) > 0 {
+		return r.journalOpen(r.journalPath)
+	}
 	return nil
 }
 
-// fileStore implements saving the storage state to a file.
-func (r *repo) fileStore() error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// fileSnapshot implements copying the storage state written to the file, so the state is encoded
+// without holding the lock. The lock is held by the caller.
+func (r *repo) fileSnapshot() *fs {
+	store := new(fs)
+	store.Version = fileVersion
+	store.URLs = make([]fileURL, 0, len(r.data))
+	for id, v := range r.data {
+		store.URLs = append(store.URLs, newFileURL(id, v))
+	}
+	store.Accounts = make(map[string]storageAccount, len(r.accounts))
+	for email, v := range r.accounts {
+		store.Accounts[email] = v
+	}
+	store.Workspaces = make(map[uuid.UUID]storageWorkspace, len(r.workspaces))
+	for id, v := range r.workspaces {
+		members := make(map[uuid.UUID]workspace.Role, len(v.Members))
+		for userID, role := range v.Members {
+			members[userID] = role
+		}
+		v.Members = members
+		store.Workspaces[id] = v
+	}
+	for userID := range r.banned {
+		store.Banned = append(store.Banned, userID)
+	}
+	store.Reports = append([]storageReport(nil), r.reports...)
+	store.Webhooks = make(map[uuid.UUID]storageWebhook, len(r.webhooks))
+	for id, v := range r.webhooks {
+		store.Webhooks[id] = v
+	}
+	store.Dead = append([]storageDelivery(nil), r.dead...)
+	return store
+}
 
-	err := r.file.Truncate(0)
+// fileStore implements saving the storage state snapshot to a file, the state is written to the temporary file
+// renamed over the storage file, so the interrupted write keeps the previous state. The lock is not held.
+func (r *repo) fileStore(store *fs) error {
+	tmp, err := os.CreateTemp(filepath.Dir(r.filePath), ".storage-*")
 	if err != nil {
 		return err
 	}
+	defer os.Remove(tmp.Name())
 
-	_, err = r.file.Seek(0, 0)
-	if err != nil {
+	if err = r.fileEncode(tmp, store); err != nil {
+		_ = tmp.Close()
 		return err
 	}
 
-	store := new(fs)
-	store.Data = r.data
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
 
-	if err = json.NewEncoder(r.file).Encode(store); err != nil {
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp.Name(), r.filePath); err != nil {
 		return err
 	}
 
@@ -77,3 +191,44 @@ func (r *repo) fileStore() error {
 
 	return nil
 }
+
+// fileEncode implements writing the storage state in the encoding of the repository.
+func (r *repo) fileEncode(w io.Writer, store *fs) error {
+	if r.fileFormat != FormatBinary {
+		return json.NewEncoder(w).Encode(store)
+	}
+
+	buf := bufio.NewWriter(w)
+	if _, err := buf.Write(fileMagic); err != nil {
+		return err
+	}
+
+	if err := gob.NewEncoder(buf).Encode(store); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+// fileDecode implements reading the storage state in any encoding, the encoding is detected by the header.
+// The empty file created on the first start holds the empty state.
+func fileDecode(rd io.Reader) (*fs, error) {
+	store := new(fs)
+	buf := bufio.NewReader(rd)
+
+	header, err := buf.Peek(len(fileMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if bytes.Equal(header, fileMagic) {
+		if _, err = buf.Discard(len(fileMagic)); err != nil {
+			return nil, err
+		}
+		return store, gob.NewDecoder(buf).Decode(store)
+	}
+
+	if err = json.NewDecoder(buf).Decode(store); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return store, nil
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+func Test_repo_fileStore(t *testing.T) {
+	var (
+		activeID   = uuid.MustParse("6d7e8f9a-0b1c-4d2e-9f3a-5b6c7d8e9f0a")
+		deletedID  = uuid.MustParse("7e8f9a0b-1c2d-4e3f-8a4b-6c7d8e9f0a1b")
+		disabledID = uuid.MustParse("8f9a0b1c-2d3e-4f4a-9b5c-7d8e9f0a1b2c")
+	)
+	tests := []struct {
+		name   string
+		format string
+		magic  bool
+	}{
+		{
+			name:   "positive round trip (json)",
+			format: FormatJSON,
+		},
+		{
+			name:   "positive round trip (binary)",
+			format: FormatBinary,
+			magic:  true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "storage")
+
+			active := newTestURL(activeID, "https://example.com/active?q=1")
+			deleted := newTestURL(deletedID, "https://example.com/deleted")
+			deleted.SetDeleted(true)
+			disabled := newTestURL(disabledID, "https://example.com/disabled")
+			disabled.SetDisabled(true)
+			urls := []entity.URL{active, deleted, disabled}
+
+			r := New(FileFormat(tt.format), File(filePath))
+			assert.NoError(t, r.Import(ctx, urls))
+			assert.NoError(t, r.Close())
+
+			data, err := os.ReadFile(filePath)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.magic, bytes.HasPrefix(data, fileMagic))
+
+			// the file in any format is loaded regardless of the configured one
+			loaded := New(File(filePath))
+			defer loaded.Close()
+			for _, want := range urls {
+				got, err := loaded.Get(ctx, want.ID())
+				assert.NoError(t, err)
+				assert.Equal(t, want.UserID(), got.UserID())
+				assert.Equal(t, want.WorkspaceID(), got.WorkspaceID())
+				assert.Equal(t, want.LongURL(), got.LongURL())
+				assert.Equal(t, want.Deleted(), got.Deleted())
+				assert.Equal(t, want.Disabled(), got.Disabled())
+			}
+		})
+	}
+}
+
+func Test_repo_fileLoad_legacy(t *testing.T) {
+	var (
+		id     = uuid.MustParse("9a0b1c2d-3e4f-4a5b-8c6d-8e9f0a1b2c3d")
+		userID = uuid.MustParse("0b1c2d3e-4f5a-4b6c-9d7e-9f0a1b2c3d4e")
+	)
+	ctx := context.Background()
+	filePath := filepath.Join(t.TempDir(), "storage.json")
+
+	// the file of the first version keeps short URLs in data and has no workspaces
+	legacy := `{"data":{"` + id.String() + `":{"user_id":"` + userID.String() +
+		`","value":"https://example.com/legacy","disabled":true}}}`
+	assert.NoError(t, os.WriteFile(filePath, []byte(legacy), 0o644))
+
+	r := New(File(filePath))
+	u, err := r.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, u.UserID())
+	assert.Equal(t, userID, u.WorkspaceID())
+	assert.Equal(t, "https://example.com/legacy", u.LongURL())
+	assert.True(t, u.Disabled())
+	assert.NoError(t, r.Close())
+
+	data, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+
+	var store fs
+	assert.NoError(t, json.Unmarshal(data, &store))
+	assert.Equal(t, fileVersion, store.Version)
+	assert.Empty(t, store.Data)
+	assert.Len(t, store.URLs, 1)
+	assert.Equal(t, id, store.URLs[0].ID)
+}
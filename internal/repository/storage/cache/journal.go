@@ -0,0 +1,266 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+)
+
+// maxJournalRecordSize defines the maximum size of the short URL record in the journal file.
+const maxJournalRecordSize = 1 << 20
+
+const (
+	// journalPut defines the record of the short URL state.
+	journalPut = "put"
+	// journalRemove defines the record of the removed short URL.
+	journalRemove = "remove"
+)
+
+// journalRecord describes the change of the short URL appended to the journal file.
+type journalRecord struct {
+	Op string `json:"op"`
+	fileURL
+}
+
+// putURLs implements saving the short URL states, the states are appended to the journal file
+// before they are applied. The lock is held by the caller.
+func (r *repo) putURLs(items map[uuid.UUID]storageURL) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	records := make([]journalRecord, 0, len(items))
+	for id, v := range items {
+		records = append(records, journalRecord{Op: journalPut, fileURL: newFileURL(id, v)})
+	}
+
+	if err := r.journalAppend(records); err != nil {
+		return err
+	}
+
+	for id, v := range items {
+		r.data[id] = v
+	}
+	return nil
+}
+
+// removeURLs implements removing the short URLs, the removals are appended to the journal file
+// before they are applied. The lock is held by the caller.
+func (r *repo) removeURLs(ids []uuid.UUID) error {
+	records := make([]journalRecord, len(ids))
+	for idx, id := range ids {
+		records[idx] = journalRecord{Op: journalRemove, fileURL: fileURL{ID: id}}
+	}
+
+	if err := r.journalAppend(records); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		delete(r.data, id)
+	}
+	return nil
+}
+
+// journalAppend implements writing the records to the journal file with a single write and syncing it,
+// nothing is written when the journal is not used.
+func (r *repo) journalAppend(records []journalRecord) error {
+	if r.journal == nil || len(records) == 0 {
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	for _, v := range records {
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+	}
+
+	if _, err := r.journal.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	if err := r.journal.Sync(); err != nil {
+		return err
+	}
+
+	r.journalRecords += len(records)
+	return nil
+}
+
+// journalOpen implements the opening of the journal file and replaying the short URL changes on top
+// of the loaded snapshot, the rotated journal left by the failed compaction is replayed first.
+// The lock is held by the caller.
+func (r *repo) journalOpen(path string) error {
+	for _, v := range []string{r.journalRotatedPath(), path} {
+		if err := r.journalReplay(v); err != nil {
+			return err
+		}
+	}
+
+	flag := os.O_WRONLY | os.O_APPEND | os.O_CREATE
+	file, err := os.OpenFile(path, flag, 0o644)
+	if err != nil {
+		return err
+	}
+
+	r.journal = file
+	return nil
+}
+
+// journalReplay implements applying the short URL changes from the journal file in the order of writing.
+// A torn record left by the interrupted write is skipped.
+func (r *repo) journalReplay(path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxJournalRecordSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var v journalRecord
+		if err = json.Unmarshal(scanner.Bytes(), &v); err != nil {
+			r.logger.Error("skip torn journal record", err, slog.String("func", "journalReplay"))
+			continue
+		}
+		r.journalRecords++
+
+		switch v.Op {
+		case journalPut:
+			item, err := v.storageURL()
+			if err != nil {
+				r.logger.Error("skip invalid journal record", err, slog.String("func", "journalReplay"))
+				continue
+			}
+			r.data[v.ID] = item
+		case journalRemove:
+			delete(r.data, v.ID)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+
+	r.logger.Info("success replay url journal", slog.Int("records", r.journalRecords))
+	return nil
+}
+
+// compact implements writing the snapshot of the storage state to the file and dropping the journal records
+// included in it. The journal is rotated and the state is copied under the lock, the snapshot is written
+// without it, so the requests are not blocked by the file write. The records appended after the rotation
+// are kept, their replay on top of the snapshot is idempotent. The rotated journal left by the failed
+// compaction is kept and replayed until the next compaction succeeds.
+func (r *repo) compact() error {
+	r.compactMu.Lock()
+	defer r.compactMu.Unlock()
+
+	r.mu.Lock()
+	records := r.journalRecords
+	if err := r.journalRotate(); err != nil {
+		r.mu.Unlock()
+		return err
+	}
+	// rotated defines the number of records moved to the rotated journal, they are counted again
+	// when the snapshot is not written
+	rotated := records - r.journalRecords
+	store := r.fileSnapshot()
+	r.mu.Unlock()
+
+	if err := r.fileStore(store); err != nil {
+		r.mu.Lock()
+		r.journalRecords += rotated
+		r.mu.Unlock()
+		return err
+	}
+
+	if r.journal == nil {
+		return nil
+	}
+
+	if err := os.Remove(r.journalRotatedPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// journalRotate implements renaming the journal file to the rotated one and opening the new journal file,
+// the journal is not rotated while the rotated journal of the failed compaction is kept. The lock is held
+// by the caller.
+func (r *repo) journalRotate() error {
+	if r.journal == nil {
+		return nil
+	}
+
+	if _, err := os.Stat(r.journalRotatedPath()); err == nil {
+		return nil
+	}
+
+	if err := os.Rename(r.journalPath, r.journalRotatedPath()); err != nil {
+		return err
+	}
+
+	flag := os.O_WRONLY | os.O_APPEND | os.O_CREATE
+	file, err := os.OpenFile(r.journalPath, flag, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if err = r.journal.Close(); err != nil {
+		r.logger.Error("failed close rotated url journal", err, slog.String("func", "journalRotate"))
+	}
+
+	r.journal = file
+	r.journalRecords = 0
+	return nil
+}
+
+// journalRotatedPath implements getting the path of the journal file rotated by the compaction.
+func (r *repo) journalRotatedPath() string {
+	return r.journalPath + ".old"
+}
+
+// compactLoop implements compacting the journal file with the interval until the repository is closed,
+// the compaction is skipped when nothing was journaled.
+func (r *repo) compactLoop(interval time.Duration) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			r.mu.RLock()
+			records := r.journalRecords
+			r.mu.RUnlock()
+
+			if records == 0 {
+				continue
+			}
+
+			if err := r.compact(); err != nil {
+				r.logger.Error("failed compact url journal", err, slog.String("func", "compactLoop"))
+				continue
+			}
+			r.logger.Info("success compact url journal", slog.Int("records", records))
+		}
+	}
+}
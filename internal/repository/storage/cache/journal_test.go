@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+func newTestURL(id uuid.UUID, rawURL string) entity.URL {
+	value, _ := url.Parse(rawURL)
+	u := entity.NewURL(id, uuid.MustParse("4f2e6c1a-8b3d-4e5f-9a7c-1d2e3f4a5b6c"))
+	u.SetWorkspaceID(uuid.MustParse("7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"))
+	u.SetLongURL(*value)
+	return u
+}
+
+func Test_repo_journalReplay(t *testing.T) {
+	var (
+		id1 = uuid.MustParse("1e2f3a4b-5c6d-4e7f-8a9b-0c1d2e3f4a5b")
+		id2 = uuid.MustParse("2f3a4b5c-6d7e-4f8a-9b0c-1d2e3f4a5b6c")
+		id3 = uuid.MustParse("3a4b5c6d-7e8f-4a9b-8c1d-2e3f4a5b6c7d")
+	)
+	tests := []struct {
+		name string
+		// torn defines the trailing record left by the write interrupted by the crash
+		torn string
+	}{
+		{
+			name: "positive replay journal (unclean stop)",
+		},
+		{
+			name: "positive replay journal (torn trailing record)",
+			torn: `{"op":"put","id":"4b5c6d7e-8f9a-4b0c-9d1e-3f4a5b6c7d8e","user_id":"4f2e`,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			filePath, journalPath := filepath.Join(dir, "storage.json"), filepath.Join(dir, "storage.wal")
+
+			r := New(Journal(journalPath), File(filePath))
+			assert.NoError(t, r.Add(ctx, newTestURL(id1, "https://example.com/1")))
+			assert.NoError(t, r.Add(ctx, newTestURL(id2, "https://example.com/2")))
+			assert.NoError(t, r.Add(ctx, newTestURL(id3, "https://example.com/3")))
+			assert.NoError(t, r.SetDisabled(ctx, id2, true))
+			assert.NoError(t, r.ForceDelete(ctx, []uuid.UUID{id3}))
+
+			if len(tt.torn) > 0 {
+				file, err := os.OpenFile(journalPath, os.O_WRONLY|os.O_APPEND, 0o644)
+				assert.NoError(t, err)
+				_, err = file.WriteString(tt.torn)
+				assert.NoError(t, err)
+				assert.NoError(t, file.Close())
+			}
+
+			// the repository is not closed, so the storage file keeps the state before the changes
+			replayed := New(Journal(journalPath), File(filePath))
+			defer replayed.Close()
+
+			count, err := replayed.GetURLCount(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, count)
+
+			u, err := replayed.Get(ctx, id1)
+			assert.NoError(t, err)
+			assert.Equal(t, "https://example.com/1", u.LongURL())
+			assert.False(t, u.Disabled())
+
+			u, err = replayed.Get(ctx, id2)
+			assert.NoError(t, err)
+			assert.True(t, u.Disabled())
+
+			_, err = replayed.Get(ctx, id3)
+			assert.ErrorIs(t, err, entity.ErrNotFound)
+		})
+	}
+}
+
+func Test_repo_compact(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	filePath, journalPath := filepath.Join(dir, "storage.json"), filepath.Join(dir, "storage.wal")
+	id := uuid.MustParse("5c6d7e8f-9a0b-4c1d-8e2f-4a5b6c7d8e9f")
+
+	r := New(Journal(journalPath), File(filePath))
+	assert.NoError(t, r.Add(ctx, newTestURL(id, "https://example.com/compact")))
+	assert.Equal(t, 1, r.journalRecords)
+
+	info, err := os.Stat(journalPath)
+	assert.NoError(t, err)
+	assert.NotZero(t, info.Size())
+
+	assert.NoError(t, r.compact())
+	assert.Zero(t, r.journalRecords)
+
+	info, err = os.Stat(journalPath)
+	assert.NoError(t, err)
+	assert.Zero(t, info.Size())
+
+	// the records appended after the truncation are written from the start of the file
+	assert.NoError(t, r.SetDisabled(ctx, id, true))
+
+	replayed := New(Journal(journalPath), File(filePath))
+	defer replayed.Close()
+	assert.Equal(t, 1, replayed.journalRecords)
+
+	u, err := replayed.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/compact", u.LongURL())
+	assert.True(t, u.Disabled())
+}
+
+func Test_repo_compact_failed(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	filePath, journalPath := filepath.Join(dir, "storage.json"), filepath.Join(dir, "storage.wal")
+	var (
+		id1 = uuid.MustParse("6d7e8f9a-0b1c-4d2e-8f3a-5b6c7d8e9f0b")
+		id2 = uuid.MustParse("7e8f9a0b-1c2d-4e3f-9a4b-6c7d8e9f0a1c")
+	)
+
+	r := New(Journal(journalPath), File(filePath))
+	assert.NoError(t, r.Add(ctx, newTestURL(id1, "https://example.com/rotated")))
+
+	// the snapshot is not written, so the rotated journal is kept
+	r.filePath = filepath.Join(dir, "missing", "storage.json")
+	assert.Error(t, r.compact())
+	assert.Equal(t, 1, r.journalRecords)
+	assert.FileExists(t, journalPath+".old")
+
+	assert.NoError(t, r.Add(ctx, newTestURL(id2, "https://example.com/appended")))
+
+	replayed := New(Journal(journalPath), File(filePath))
+	assert.Equal(t, 2, replayed.journalRecords)
+	for _, id := range []uuid.UUID{id1, id2} {
+		_, err := replayed.Get(ctx, id)
+		assert.NoError(t, err)
+	}
+
+	// the next compaction writes the snapshot and drops the rotated journal
+	assert.NoError(t, replayed.compact())
+	assert.NoFileExists(t, journalPath+".old")
+	assert.NoError(t, replayed.Close())
+
+	loaded := New(Journal(journalPath), File(filePath))
+	defer loaded.Close()
+	count, err := loaded.GetURLCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
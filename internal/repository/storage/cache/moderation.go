@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+// Search implements getting short URLs of all users matching the filter.
+func (r *repo) Search(_ context.Context, filter entity.Filter) ([]entity.URL, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]uuid.UUID, 0, len(r.data))
+	for k, v := range r.data {
+		if filter.UserID != uuid.Nil && v.UserID != filter.UserID {
+			continue
+		}
+		if !strings.Contains(v.Value.String(), filter.Query) {
+			continue
+		}
+		ids = append(ids, k)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+
+	if filter.Offset >= len(ids) {
+		return []entity.URL{}, nil
+	}
+	ids = ids[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(ids) {
+		ids = ids[:filter.Limit]
+	}
+
+	result := make([]entity.URL, len(ids))
+	for idx, id := range ids {
+		result[idx] = newURL(id, r.data[id])
+	}
+
+	return result, nil
+}
+
+// SetDisabled implements the setting of the moderation attribute of the short URL.
+func (r *repo) SetDisabled(_ context.Context, id uuid.UUID, disabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, ok := r.data[id]
+	if !ok {
+		return entity.NewURLErr(id, uuid.UUID{}, entity.ErrNotFound)
+	}
+
+	v.Disabled = disabled
+	return r.putURLs(map[uuid.UUID]storageURL{id: v})
+}
+
+// ForceDelete implements the removal of short URLs and their reports regardless of the owner.
+func (r *repo) ForceDelete(_ context.Context, ids []uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.removeURLs(ids); err != nil {
+		return err
+	}
+
+	deleted := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		deleted[id] = struct{}{}
+	}
+
+	reports := r.reports[:0]
+	for _, v := range r.reports {
+		if _, ok := deleted[v.URLID]; !ok {
+			reports = append(reports, v)
+		}
+	}
+	r.reports = reports
+	return nil
+}
+
+// BanUser implements the setting of the user ban.
+func (r *repo) BanUser(_ context.Context, userID uuid.UUID, banned bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if banned {
+		r.banned[userID] = struct{}{}
+	} else {
+		delete(r.banned, userID)
+	}
+	return nil
+}
+
+// IsBanned implements checking the user ban.
+func (r *repo) IsBanned(_ context.Context, userID uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.banned[userID]
+	return ok, nil
+}
@@ -1,5 +1,7 @@
 package cache
 
+import "time"
+
 // Option describes an option for repository.
 type Option func(*repo) error
 
@@ -16,13 +18,86 @@ func File(path string) Option {
 			return err
 		}
 
-		r.fileUse = true
-
 		err = r.fileLoad()
 		if err != nil {
 			r.logger.Error("failed load url data from file", err)
 			return err
 		}
+
+		// the file failed to load is not overwritten on close
+		r.fileUse = true
+		return nil
+	}
+}
+
+// AuditFile implements an option that sets the JSON lines file path of the audit log.
+func AuditFile(path string) Option {
+	return func(r *repo) error {
+		if len(path) == 0 {
+			return ErrEmptyPath
+		}
+
+		err := r.auditOpen(path)
+		if err != nil {
+			r.logger.Error("failed open audit log file path", err)
+			return err
+		}
+		return nil
+	}
+}
+
+// TaskJournal implements an option that sets the JSON lines journal file path of the deferred tasks.
+func TaskJournal(path string) Option {
+	return func(r *repo) error {
+		if len(path) == 0 {
+			return ErrEmptyPath
+		}
+
+		err := r.taskOpen(path)
+		if err != nil {
+			r.logger.Error("failed open task journal file path", err)
+			return err
+		}
+		return nil
+	}
+}
+
+// Journal implements an option that sets the JSON lines journal file path of the short URL changes,
+// the journal is used with the storage file and replayed on top of it at startup, so the option
+// is applied before File. The changes of other entities are not journaled.
+func Journal(path string) Option {
+	return func(r *repo) error {
+		if len(path) == 0 {
+			return ErrEmptyPath
+		}
+
+		r.journalPath = path
+		return nil
+	}
+}
+
+// CompactInterval implements an option that sets the interval of compacting the journal file
+// into the storage file.
+func CompactInterval(interval time.Duration) Option {
+	return func(r *repo) error {
+		if interval <= 0 {
+			return ErrInvalidInterval
+		}
+
+		r.compactInterval = interval
+		return nil
+	}
+}
+
+// FileFormat implements an option that sets the encoding of the storage file, the file
+// in any encoding is loaded.
+func FileFormat(format string) Option {
+	return func(r *repo) error {
+		if format != FormatJSON && format != FormatBinary {
+			return ErrInvalidFormat
+		}
+
+		r.fileFormat = format
 		return nil
 	}
 }
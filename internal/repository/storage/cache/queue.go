@@ -0,0 +1,319 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+)
+
+// maxTaskRecordSize defines the maximum size of the task record in the journal file.
+const maxTaskRecordSize = 16 << 20
+
+// storageTask describes the deferred task type used in repository.
+type storageTask struct {
+	ID        uuid.UUID                  `json:"id"`
+	Action    task.Action                `json:"action"`
+	UserID    uuid.UUID                  `json:"user_id"`
+	URLIDs    []uuid.UUID                `json:"url_ids"`
+	Status    task.Status                `json:"status"`
+	Attempts  int                        `json:"attempts"`
+	LastError string                     `json:"last_error,omitempty"`
+	Outcomes  map[uuid.UUID]task.Outcome `json:"outcomes,omitempty"`
+	RunAt     time.Time                  `json:"run_at"`
+	CreatedAt time.Time                  `json:"created_at"`
+}
+
+// taskRecord describes the task state or removal appended to the journal file.
+type taskRecord struct {
+	storageTask
+	Removed bool `json:"removed,omitempty"`
+}
+
+// AddTask implements saving the deferred task.
+func (r *repo) AddTask(_ context.Context, item task.Task) error {
+	r.taskMu.Lock()
+	defer r.taskMu.Unlock()
+
+	return r.taskPut(storageTask(item))
+}
+
+// ClaimTasks implements marking the due tasks of the action running until the lease ends.
+func (r *repo) ClaimTasks(_ context.Context, action task.Action, limit int, lease time.Duration) ([]task.Task, error) {
+	r.taskMu.Lock()
+	defer r.taskMu.Unlock()
+
+	now := time.Now()
+	due := make([]storageTask, 0)
+	for _, v := range r.tasks {
+		if v.Action != action || (v.Status != task.StatusPending && v.Status != task.StatusRunning) {
+			continue
+		}
+		if v.RunAt.After(now) {
+			continue
+		}
+		due = append(due, v)
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].RunAt.Before(due[j].RunAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	tasks := make([]task.Task, 0, len(due))
+	for _, v := range due {
+		v.Status = task.StatusRunning
+		v.Attempts++
+		v.RunAt = now.Add(lease)
+		if err := r.taskPut(v); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task.Task(v))
+	}
+
+	return tasks, nil
+}
+
+// GetTask implements getting the deferred task.
+func (r *repo) GetTask(_ context.Context, id uuid.UUID) (task.Task, error) {
+	r.taskMu.Lock()
+	defer r.taskMu.Unlock()
+
+	v, ok := r.tasks[id]
+	if !ok {
+		return task.Task{}, task.ErrNotFound
+	}
+	return task.Task(v), nil
+}
+
+// CompleteTask implements marking the task of the claimed attempt processed with the outcomes for its short URLs.
+func (r *repo) CompleteTask(_ context.Context, id uuid.UUID, attempt int,
+	outcomes map[uuid.UUID]task.Outcome,
+) error {
+	r.taskMu.Lock()
+	defer r.taskMu.Unlock()
+
+	v, err := r.claimedTask(id, attempt)
+	if err != nil {
+		return err
+	}
+
+	v.Status = task.StatusDone
+	v.RunAt = time.Now()
+	v.LastError = ""
+	v.Outcomes = outcomes
+	return r.taskPut(v)
+}
+
+// RetryTask implements returning the failed task of the claimed attempt to the queue until the run time.
+func (r *repo) RetryTask(_ context.Context, id uuid.UUID, attempt int, runAt time.Time, reason string) error {
+	return r.setTaskStatus(id, attempt, task.StatusPending, runAt, reason)
+}
+
+// FailTask implements moving the task of the claimed attempt whose attempts are exhausted to the dead state.
+func (r *repo) FailTask(_ context.Context, id uuid.UUID, attempt int, reason string) error {
+	return r.setTaskStatus(id, attempt, task.StatusDead, time.Now(), reason)
+}
+
+// CountTasks implements getting the number of the tasks in the processing state.
+func (r *repo) CountTasks(_ context.Context, status task.Status) (int, error) {
+	r.taskMu.Lock()
+	defer r.taskMu.Unlock()
+
+	var count int
+	for _, v := range r.tasks {
+		if v.Status == status {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// PurgeTasks implements deleting the tasks processed before the time, the number of deleted tasks is returned.
+// The removals are appended to the journal file before they are applied.
+func (r *repo) PurgeTasks(_ context.Context, before time.Time) (int, error) {
+	r.taskMu.Lock()
+	defer r.taskMu.Unlock()
+
+	ids := make([]uuid.UUID, 0)
+	for id, v := range r.tasks {
+		if v.Status == task.StatusDone && v.RunAt.Before(before) {
+			ids = append(ids, id)
+		}
+	}
+
+	if err := r.taskRemove(ids); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// setTaskStatus implements changing the processing state of the task of the claimed attempt.
+func (r *repo) setTaskStatus(id uuid.UUID, attempt int, status task.Status, runAt time.Time, reason string) error {
+	r.taskMu.Lock()
+	defer r.taskMu.Unlock()
+
+	v, err := r.claimedTask(id, attempt)
+	if err != nil {
+		return err
+	}
+
+	v.Status = status
+	v.RunAt = runAt
+	v.LastError = reason
+	return r.taskPut(v)
+}
+
+// claimedTask implements getting the running task of the claimed attempt, task.ErrLeaseLost is returned
+// when the task was claimed again. The lock is held by the caller.
+func (r *repo) claimedTask(id uuid.UUID, attempt int) (storageTask, error) {
+	v, ok := r.tasks[id]
+	if !ok {
+		return storageTask{}, task.ErrNotFound
+	}
+
+	if v.Status != task.StatusRunning || v.Attempts != attempt {
+		return storageTask{}, task.ErrLeaseLost
+	}
+	return v, nil
+}
+
+// taskPut implements saving the task state, the state is appended to the journal file when it is set.
+func (r *repo) taskPut(v storageTask) error {
+	if r.taskJournal != nil {
+		if err := json.NewEncoder(r.taskJournal).Encode(v); err != nil {
+			return err
+		}
+		if err := r.taskJournal.Sync(); err != nil {
+			return err
+		}
+	}
+
+	r.tasks[v.ID] = v
+	return nil
+}
+
+// taskRemove implements removing the tasks, the removals are appended to the journal file with a single write
+// when it is set. The lock is held by the caller.
+func (r *repo) taskRemove(ids []uuid.UUID) error {
+	if r.taskJournal != nil && len(ids) > 0 {
+		buf := new(bytes.Buffer)
+		encoder := json.NewEncoder(buf)
+		for _, id := range ids {
+			if err := encoder.Encode(taskRecord{storageTask: storageTask{ID: id}, Removed: true}); err != nil {
+				return err
+			}
+		}
+		if _, err := r.taskJournal.Write(buf.Bytes()); err != nil {
+			return err
+		}
+		if err := r.taskJournal.Sync(); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range ids {
+		delete(r.tasks, id)
+	}
+	return nil
+}
+
+// taskOpen implements the opening of the task journal file and replaying the task states from it.
+func (r *repo) taskOpen(path string) error {
+	tasks, err := taskLoad(path)
+	if err != nil {
+		return err
+	}
+
+	flag := os.O_WRONLY | os.O_APPEND | os.O_CREATE
+	file, err := os.OpenFile(path, flag, 0o644)
+	if err != nil {
+		return err
+	}
+
+	r.taskJournal = file
+	r.tasks = tasks
+	return nil
+}
+
+// taskCompact implements rewriting the task journal file with the latest state of every task
+// and closing it. Processed tasks are kept.
+func (r *repo) taskCompact() error {
+	r.taskMu.Lock()
+	defer r.taskMu.Unlock()
+
+	path := r.taskJournal.Name()
+	if err := r.taskJournal.Close(); err != nil {
+		return err
+	}
+	r.taskJournal = nil
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tasks-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	encoder := json.NewEncoder(tmp)
+	for _, v := range r.tasks {
+		if err = encoder.Encode(v); err != nil {
+			_ = tmp.Close()
+			return err
+		}
+	}
+
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// taskLoad implements replaying the task states and removals from the journal file, the last record of the task wins.
+// A torn record left by the interrupted write is skipped.
+func taskLoad(path string) (map[uuid.UUID]storageTask, error) {
+	tasks := map[uuid.UUID]storageTask{}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return tasks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxTaskRecordSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var v taskRecord
+		if err = json.Unmarshal(scanner.Bytes(), &v); err != nil {
+			continue
+		}
+		if v.Removed {
+			delete(tasks, v.ID)
+			continue
+		}
+		tasks[v.ID] = v.storageTask
+	}
+
+	return tasks, scanner.Err()
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+)
+
+func Test_repo_PurgeTasks(t *testing.T) {
+	tests := []struct {
+		name      string
+		purge     bool
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name: "positive replay task (not purged)",
+		},
+		{
+			name:      "positive replay task (purged)",
+			purge:     true,
+			wantCount: 1,
+			wantErr:   task.ErrNotFound,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tasks.jsonl")
+			r := New(TaskJournal(path))
+
+			item := task.New(task.ActionDelete, uuid.New(), []uuid.UUID{uuid.New()})
+			assert.NoError(t, r.AddTask(ctx, item))
+			_, err := r.ClaimTasks(ctx, task.ActionDelete, 1, time.Minute)
+			assert.NoError(t, err)
+			assert.NoError(t, r.CompleteTask(ctx, item.ID, 1, map[uuid.UUID]task.Outcome{
+				item.URLIDs[0]: task.OutcomeDeleted,
+			}))
+
+			if tt.purge {
+				count, err := r.PurgeTasks(ctx, time.Now().Add(time.Second))
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCount, count)
+			}
+
+			// the journal is replayed without the compaction on close, as after a crash
+			replayed := New(TaskJournal(path))
+			got, err := replayed.GetTask(ctx, item.ID)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, task.StatusDone, got.Status)
+			}
+
+			assert.NoError(t, replayed.Close())
+			assert.NoError(t, r.Close())
+		})
+	}
+}
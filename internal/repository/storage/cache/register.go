@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/repository/storage/driver"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+const (
+	// DriverFile defines the name of the in-memory storage driver kept in the file.
+	DriverFile = "file"
+	// DriverMemory defines the name of the in-memory storage driver.
+	DriverMemory = "memory"
+)
+
+func init() {
+	driver.Register(DriverFile, driver.Driver{
+		Open: func(_ context.Context, cfg config.Storage) (storage.URL, error) {
+			c := cfg.GetCache()
+			if len(c.GetFilePath()) == 0 {
+				return nil, ErrEmptyPath
+			}
+
+			r := New(append(options(c), File(c.GetFilePath()))...)
+			if !r.fileUse {
+				_ = r.Close()
+				return nil, ErrFileLoad
+			}
+			return r, nil
+		},
+		Configured: func(cfg config.Storage) bool {
+			return len(cfg.GetCache().GetFilePath()) > 0
+		},
+		Priority: 40,
+	})
+
+	driver.Register(DriverMemory, driver.Driver{
+		Open: func(_ context.Context, cfg config.Storage) (storage.URL, error) {
+			return New(options(cfg.GetCache())...), nil
+		},
+		Priority: 100,
+	})
+}
+
+// options implements the options of the in-memory storage set by the configuration.
+func options(c config.Cache) []Option {
+	var opts []Option
+	if len(c.GetAuditFilePath()) > 0 {
+		opts = append(opts, AuditFile(c.GetAuditFilePath()))
+	}
+	if len(c.GetTaskJournalPath()) > 0 {
+		opts = append(opts, TaskJournal(c.GetTaskJournalPath()))
+	}
+	if len(c.GetJournalPath()) > 0 {
+		opts = append(opts, Journal(c.GetJournalPath()))
+	}
+	if len(c.GetFileFormat()) > 0 {
+		opts = append(opts, FileFormat(c.GetFileFormat()))
+	}
+	if c.GetCompactInterval() > 0 {
+		opts = append(opts, CompactInterval(c.GetCompactInterval()))
+	}
+	return opts
+}
@@ -1,23 +1,53 @@
 // Package cache implements a repository for storing short URLs in the in-memory storage.
+//
+// The storage state is kept in the storage file written on compaction and close. Only the short URL changes
+// are appended to the journal file, so accounts, workspaces, bans, reports and webhooks changed since the last
+// compaction are lost when the process is killed. The deferred tasks are kept in their own journal file.
 package cache
 
 import (
 	"context"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/exp/slog"
 
+	"github.com/sreway/shorturl/internal/domain/audit"
+	"github.com/sreway/shorturl/internal/domain/task"
 	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/workspace"
 )
 
 type repo struct {
-	data    map[uuid.UUID]storageURL
-	file    *os.File
-	fileUse bool
-	logger  *slog.Logger
-	mu      sync.RWMutex
+	data        map[uuid.UUID]storageURL
+	accounts    map[string]storageAccount
+	workspaces  map[uuid.UUID]storageWorkspace
+	banned      map[uuid.UUID]struct{}
+	reports     []storageReport
+	events      []storageEvent
+	webhooks    map[uuid.UUID]storageWebhook
+	dead        []storageDelivery
+	tasks       map[uuid.UUID]storageTask
+	filePath    string
+	fileUse     bool
+	fileFormat  string
+	journalPath string
+	journal     *os.File
+	// journalRecords defines the number of records appended to the journal file since the compaction.
+	journalRecords  int
+	compactInterval time.Duration
+	done            chan struct{}
+	wg              sync.WaitGroup
+	auditFile       *os.File
+	taskJournal     *os.File
+	logger          *slog.Logger
+	mu              sync.RWMutex
+	auditMu         sync.Mutex
+	taskMu          sync.Mutex
+	// compactMu serializes the compactions, the storage file is written without holding mu.
+	compactMu sync.Mutex
 }
 
 // Add implements saving short URL.
@@ -27,11 +57,13 @@ func (r *repo) Add(ctx context.Context, item entity.URL) error {
 
 	_ = ctx
 
-	r.data[item.ID()] = storageURL{
-		UserID: item.UserID(),
-		Value:  item.LongValue(),
-	}
-	return nil
+	return r.putURLs(map[uuid.UUID]storageURL{
+		item.ID(): {
+			UserID:      item.UserID(),
+			WorkspaceID: item.WorkspaceID(),
+			Value:       item.LongValue(),
+		},
+	})
 }
 
 // Get implements getting short URL.
@@ -44,10 +76,71 @@ func (r *repo) Get(_ context.Context, id uuid.UUID) (entity.URL, error) {
 		return nil, entity.ErrNotFound
 	}
 
-	u := entity.NewURL(id, i.UserID)
-	u.SetLongURL(i.Value)
-	u.SetDeleted(i.Deleted)
-	return u, nil
+	return newURL(id, i), nil
+}
+
+// ScanIDs implements passing the IDs of all short URLs to fn, the scan stops at the first error of fn.
+// The IDs are copied first, so fn is called without holding the lock.
+func (r *repo) ScanIDs(_ context.Context, fn func(id uuid.UUID) error) error {
+	r.mu.RLock()
+	ids := make([]uuid.UUID, 0, len(r.data))
+	for id := range r.data {
+		ids = append(ids, id)
+	}
+	r.mu.RUnlock()
+
+	for _, id := range ids {
+		if err := fn(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CountRecords implements counting the accounts, workspaces, bans, reports, webhooks and audit log events.
+func (r *repo) CountRecords(ctx context.Context) (map[string]int, error) {
+	r.mu.RLock()
+	counts := map[string]int{
+		"accounts":     len(r.accounts),
+		"workspaces":   len(r.workspaces),
+		"banned_users": len(r.banned),
+		"reports":      len(r.reports),
+		"webhooks":     len(r.webhooks),
+		"dead_letters": len(r.dead),
+	}
+	r.mu.RUnlock()
+
+	events, err := r.GetEvents(ctx, audit.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	counts["audit_events"] = len(events)
+
+	for name, n := range counts {
+		if n == 0 {
+			delete(counts, name)
+		}
+	}
+	return counts, nil
+}
+
+// Import implements saving short URLs copied from another storage with all their attributes,
+// the short URLs stored under the same ID are replaced.
+func (r *repo) Import(_ context.Context, urls []entity.URL) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := make(map[uuid.UUID]storageURL, len(urls))
+	for _, item := range urls {
+		items[item.ID()] = storageURL{
+			UserID:      item.UserID(),
+			WorkspaceID: item.WorkspaceID(),
+			Value:       item.LongValue(),
+			Deleted:     item.Deleted(),
+			Disabled:    item.Disabled(),
+		}
+	}
+	return r.putURLs(items)
 }
 
 // GetByUserID implements getting short URLs for user ID.
@@ -58,22 +151,81 @@ func (r *repo) GetByUserID(_ context.Context, userID uuid.UUID) ([]entity.URL, e
 
 	for k, v := range r.data {
 		if v.UserID == userID {
-			u := entity.NewURL(k, v.UserID)
-			u.SetLongURL(v.Value)
-			u.SetDeleted(v.Deleted)
-			result = append(result, u)
+			result = append(result, newURL(k, v))
+		}
+	}
+
+	return result, nil
+}
+
+// GetByWorkspaceID implements getting short URLs of the workspace visible to the user ID.
+func (r *repo) GetByWorkspaceID(_ context.Context, workspaceID, userID uuid.UUID) ([]entity.URL, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	role, err := r.role(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !role.Allows(workspace.RoleViewer) {
+		return nil, workspace.ErrForbidden
+	}
+
+	result := []entity.URL{}
+	for k, v := range r.data {
+		if v.WorkspaceID == workspaceID {
+			result = append(result, newURL(k, v))
 		}
 	}
 
 	return result, nil
 }
 
+// Update implements changing the long URL and the owning workspace of the short URL.
+func (r *repo) Update(_ context.Context, item entity.URL) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, ok := r.data[item.ID()]
+	if !ok {
+		return entity.NewURLErr(item.ID(), item.UserID(), entity.ErrNotFound)
+	}
+
+	for _, workspaceID := range []uuid.UUID{v.WorkspaceID, item.WorkspaceID()} {
+		if !r.allows(workspaceID, item.UserID(), workspace.RoleEditor) {
+			return entity.NewURLErr(item.ID(), item.UserID(), workspace.ErrForbidden)
+		}
+	}
+
+	v.WorkspaceID = item.WorkspaceID()
+	v.Value = item.LongValue()
+	return r.putURLs(map[uuid.UUID]storageURL{item.ID(): v})
+}
+
 // Close implements closing the connection to the file storage.
 func (r *repo) Close() error {
+	if r.auditFile != nil {
+		if err := r.auditFile.Close(); err != nil {
+			r.logger.Error("failed close audit log file", err)
+		}
+	}
+
+	if r.taskJournal != nil {
+		if err := r.taskCompact(); err != nil {
+			r.logger.Error("failed compact task journal file", err)
+		}
+	}
+
 	if !r.fileUse {
 		return nil
 	}
 
+	if r.done != nil {
+		close(r.done)
+		r.wg.Wait()
+	}
+
 	err := r.fileClose()
 	if err != nil {
 		r.logger.Error("failed close url repository file", err)
@@ -93,31 +245,81 @@ func (r *repo) Batch(_ context.Context, urls []entity.URL) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	items := make(map[uuid.UUID]storageURL, len(urls))
 	for _, item := range urls {
-		r.data[item.ID()] = storageURL{
-			UserID: item.UserID(),
-			Value:  item.LongValue(),
+		items[item.ID()] = storageURL{
+			UserID:      item.UserID(),
+			WorkspaceID: item.WorkspaceID(),
+			Value:       item.LongValue(),
 		}
 	}
-	return nil
+	return r.putURLs(items)
 }
 
 // BatchDelete implements the deletion multiple short URLs.
-func (r *repo) BatchDelete(_ context.Context, urls []entity.URL) error {
+func (r *repo) BatchDelete(_ context.Context, urls []entity.URL) (map[uuid.UUID]task.Outcome, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	outcomes := make(map[uuid.UUID]task.Outcome, len(urls))
+	deleted := make(map[uuid.UUID]storageURL, len(urls))
 	for _, item := range urls {
 		v, ok := r.data[item.ID()]
 		if !ok {
 			r.logger.Error("url not found", entity.ErrNotFound, slog.String("func", "BatchDelete"))
+			outcomes[item.ID()] = task.OutcomeNotFound
+			continue
+		}
+
+		if !r.allows(v.WorkspaceID, item.UserID(), workspace.RoleEditor) {
+			r.logger.Error("url not allowed", workspace.ErrForbidden, slog.String("func", "BatchDelete"),
+				slog.String("id", item.ID().String()), slog.String("userID", item.UserID().String()))
+			outcomes[item.ID()] = task.OutcomeNotOwned
+			continue
 		}
 
 		v.Deleted = true
-		r.data[item.ID()] = v
+		deleted[item.ID()] = v
+		outcomes[item.ID()] = task.OutcomeDeleted
 	}
 
-	return nil
+	if err := r.putURLs(deleted); err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// ChangeOwner implements moving short URLs to another user ID.
+// URLs already shortened by the new owner stay with the previous one,
+// URLs of the personal workspace are moved to the personal workspace of the new owner.
+func (r *repo) ChangeOwner(_ context.Context, from, to uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	owned := map[string]struct{}{}
+	for _, v := range r.data {
+		if v.UserID == to {
+			owned[v.Value.String()] = struct{}{}
+		}
+	}
+
+	moved := map[uuid.UUID]storageURL{}
+	for k, v := range r.data {
+		if v.UserID != from {
+			continue
+		}
+		if _, ok := owned[v.Value.String()]; ok {
+			continue
+		}
+		v.UserID = to
+		if workspace.Personal(v.WorkspaceID, from) {
+			v.WorkspaceID = to
+		}
+		moved[k] = v
+	}
+
+	return r.putURLs(moved)
 }
 
 // GetUserCount implements the getting user count.
@@ -144,8 +346,14 @@ func New(opts ...Option) *repo {
 		WithAttrs([]slog.Attr{slog.String("repository", "cache")}))
 
 	r := &repo{
-		data:   map[uuid.UUID]storageURL{},
-		logger: log,
+		data:       map[uuid.UUID]storageURL{},
+		accounts:   map[string]storageAccount{},
+		workspaces: map[uuid.UUID]storageWorkspace{},
+		banned:     map[uuid.UUID]struct{}{},
+		webhooks:   map[uuid.UUID]storageWebhook{},
+		tasks:      map[uuid.UUID]storageTask{},
+		fileFormat: FormatJSON,
+		logger:     log,
 	}
 
 	for _, opt := range opts {
@@ -155,5 +363,11 @@ func New(opts ...Option) *repo {
 		}
 	}
 
+	if r.fileUse && r.compactInterval > 0 {
+		r.done = make(chan struct{})
+		r.wg.Add(1)
+		go r.compactLoop(r.compactInterval)
+	}
+
 	return r
 }
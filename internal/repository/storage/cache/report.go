@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sreway/shorturl/internal/domain/report"
+)
+
+// storageReport describes the abuse report type used in repository.
+type storageReport struct {
+	ID         uuid.UUID `json:"id"`
+	URLID      uuid.UUID `json:"url_id"`
+	ReporterID uuid.UUID `json:"reporter_id"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+	Resolved   bool      `json:"resolved,omitempty"`
+}
+
+// AddReport implements saving abuse report.
+func (r *repo) AddReport(_ context.Context, item report.Report) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, v := range r.reports {
+		if !v.Resolved && v.URLID == item.URLID() && v.ReporterID == item.ReporterID() {
+			return report.ErrAlreadyExist
+		}
+	}
+
+	r.reports = append(r.reports, storageReport{
+		ID:         item.ID(),
+		URLID:      item.URLID(),
+		ReporterID: item.ReporterID(),
+		Reason:     item.Reason(),
+		CreatedAt:  item.CreatedAt(),
+	})
+	return nil
+}
+
+// CountReports implements getting the number of open reports of the short URL.
+func (r *repo) CountReports(_ context.Context, urlID uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int
+	for _, v := range r.reports {
+		if !v.Resolved && v.URLID == urlID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetReportQueue implements getting open reports aggregated by short URL, the most reported first.
+func (r *repo) GetReportQueue(_ context.Context, limit, offset int) ([]report.Summary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summaries := map[uuid.UUID]*report.Summary{}
+	for _, v := range r.reports {
+		if v.Resolved {
+			continue
+		}
+
+		u, ok := r.data[v.URLID]
+		if !ok {
+			continue
+		}
+
+		s, ok := summaries[v.URLID]
+		if !ok {
+			s = &report.Summary{URLID: v.URLID, LongURL: u.Value.String(), Disabled: u.Disabled}
+			summaries[v.URLID] = s
+		}
+
+		s.Count++
+		s.Reasons = append(s.Reasons, v.Reason)
+		if v.CreatedAt.After(s.LastReportedAt) {
+			s.LastReportedAt = v.CreatedAt
+		}
+	}
+
+	queue := make([]report.Summary, 0, len(summaries))
+	for _, s := range summaries {
+		queue = append(queue, *s)
+	}
+
+	sort.Slice(queue, func(i, j int) bool {
+		if queue[i].Count != queue[j].Count {
+			return queue[i].Count > queue[j].Count
+		}
+		return queue[i].LastReportedAt.After(queue[j].LastReportedAt)
+	})
+
+	if offset >= len(queue) {
+		return []report.Summary{}, nil
+	}
+	queue = queue[offset:]
+	if limit > 0 && limit < len(queue) {
+		queue = queue[:limit]
+	}
+
+	return queue, nil
+}
+
+// ResolveReports implements closing open reports of the short URL after the moderator review.
+func (r *repo) ResolveReports(_ context.Context, urlID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for idx, v := range r.reports {
+		if v.URLID == urlID {
+			r.reports[idx].Resolved = true
+		}
+	}
+	return nil
+}
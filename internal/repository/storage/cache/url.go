@@ -1,50 +1,91 @@
 package cache
 
 import (
-	"encoding/json"
 	"net/url"
 
 	"github.com/google/uuid"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
 )
 
 // storageURL describes the short URL type used in repository.
 type storageURL struct {
-	UserID  uuid.UUID
-	Value   url.URL
-	Deleted bool
+	UserID      uuid.UUID
+	WorkspaceID uuid.UUID
+	Value       url.URL
+	Deleted     bool
+	Disabled    bool
 }
 
-// MarshalJSON implements the "MarshalJSON" method for the short URL type used in repository.
-func (s storageURL) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		UserID uuid.UUID `json:"user_id"`
-		Value  string    `json:"value"`
-	}
-	aliasValue := alias{}
-	aliasValue.UserID = s.UserID
-	aliasValue.Value = s.Value.String()
-	return json.Marshal(aliasValue)
+// newURL implements the creation of the short URL type from the stored value.
+func newURL(id uuid.UUID, v storageURL) entity.URL {
+	u := entity.NewURL(id, v.UserID)
+	u.SetWorkspaceID(v.WorkspaceID)
+	u.SetLongURL(v.Value)
+	u.SetDeleted(v.Deleted)
+	u.SetDisabled(v.Disabled)
+	return u
 }
 
-// UnmarshalJSON implements the "UnmarshalJSON" method for the short URL type used in repository.
-func (s *storageURL) UnmarshalJSON(data []byte) error {
-	type alias struct {
-		UserID uuid.UUID `json:"user_id"`
-		Value  string    `json:"value"`
-	}
+// fileURL describes the short URL type stored in the file, every field of the short URL is kept.
+type fileURL struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Value       string    `json:"value"`
+	Deleted     bool      `json:"deleted,omitempty"`
+	Disabled    bool      `json:"disabled,omitempty"`
+}
 
-	aliasValue := alias{}
-	if err := json.Unmarshal(data, &aliasValue); err != nil {
-		return err
+// newFileURL implements the creation of the stored short URL type from the repository value.
+func newFileURL(id uuid.UUID, v storageURL) fileURL {
+	return fileURL{
+		ID:          id,
+		UserID:      v.UserID,
+		WorkspaceID: v.WorkspaceID,
+		Value:       v.Value.String(),
+		Deleted:     v.Deleted,
+		Disabled:    v.Disabled,
 	}
+}
 
-	parsedValue, err := url.ParseRequestURI(aliasValue.Value)
+// storageURL implements the creation of the repository value from the stored short URL.
+func (f fileURL) storageURL() (storageURL, error) {
+	value, err := url.ParseRequestURI(f.Value)
 	if err != nil {
-		return err
+		return storageURL{}, err
 	}
 
-	s.UserID = aliasValue.UserID
-	s.Value = *parsedValue
+	return storageURL{
+		UserID:      f.UserID,
+		WorkspaceID: f.WorkspaceID,
+		Value:       *value,
+		Deleted:     f.Deleted,
+		Disabled:    f.Disabled,
+	}, nil
+}
+
+// legacyURL describes the short URL type stored in the files of the first version,
+// the deletion of the short URL was not kept.
+type legacyURL struct {
+	UserID      uuid.UUID `json:"user_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Value       string    `json:"value"`
+	Disabled    bool      `json:"disabled,omitempty"`
+}
 
-	return nil
+// fileURL implements upgrading the short URL stored in the files of the first version.
+func (l legacyURL) fileURL(id uuid.UUID) fileURL {
+	f := fileURL{
+		ID:          id,
+		UserID:      l.UserID,
+		WorkspaceID: l.WorkspaceID,
+		Value:       l.Value,
+		Disabled:    l.Disabled,
+	}
+	// files written before workspaces were introduced keep short URLs in the personal workspace
+	if f.WorkspaceID == uuid.Nil {
+		f.WorkspaceID = f.UserID
+	}
+	return f
 }
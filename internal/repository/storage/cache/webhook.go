@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sreway/shorturl/internal/domain/webhook"
+)
+
+type (
+	// storageWebhook describes the webhook type used in repository.
+	storageWebhook struct {
+		UserID    uuid.UUID           `json:"user_id"`
+		URL       string              `json:"url"`
+		Secret    string              `json:"secret"`
+		Events    []webhook.EventType `json:"events"`
+		CreatedAt time.Time           `json:"created_at"`
+	}
+
+	// storageDelivery describes the failed webhook delivery type used in repository.
+	storageDelivery struct {
+		ID        uuid.UUID         `json:"id"`
+		WebhookID uuid.UUID         `json:"webhook_id"`
+		UserID    uuid.UUID         `json:"user_id"`
+		Event     webhook.EventType `json:"event"`
+		Payload   []byte            `json:"payload"`
+		Attempts  int               `json:"attempts"`
+		LastError string            `json:"last_error"`
+		CreatedAt time.Time         `json:"created_at"`
+	}
+)
+
+// AddWebhook implements saving webhook.
+func (r *repo) AddWebhook(_ context.Context, item webhook.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.webhooks[item.ID()] = storageWebhook{
+		UserID:    item.UserID(),
+		URL:       item.URL(),
+		Secret:    item.Secret(),
+		Events:    item.Events(),
+		CreatedAt: time.Now().UTC(),
+	}
+	return nil
+}
+
+// GetWebhooks implements getting webhooks of the user ordered by creation time.
+func (r *repo) GetWebhooks(_ context.Context, userID uuid.UUID) ([]webhook.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]uuid.UUID, 0)
+	for k, v := range r.webhooks {
+		if v.UserID == userID {
+			ids = append(ids, k)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return r.webhooks[ids[i]].CreatedAt.Before(r.webhooks[ids[j]].CreatedAt)
+	})
+
+	result := make([]webhook.Webhook, len(ids))
+	for idx, id := range ids {
+		v := r.webhooks[id]
+		item := webhook.NewWebhook(id, v.UserID)
+		item.SetURL(v.URL)
+		item.SetSecret(v.Secret)
+		item.SetEvents(v.Events)
+		result[idx] = item
+	}
+
+	return result, nil
+}
+
+// DeleteWebhook implements the removal of the webhook owned by the user.
+func (r *repo) DeleteWebhook(_ context.Context, id, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, ok := r.webhooks[id]
+	if !ok || v.UserID != userID {
+		return webhook.ErrNotFound
+	}
+
+	delete(r.webhooks, id)
+	return nil
+}
+
+// AddDeadLetter implements saving the failed webhook delivery.
+func (r *repo) AddDeadLetter(_ context.Context, item webhook.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dead = append(r.dead, storageDelivery{
+		ID:        item.ID,
+		WebhookID: item.WebhookID,
+		UserID:    item.UserID,
+		Event:     item.Event,
+		Payload:   item.Payload,
+		Attempts:  item.Attempts,
+		LastError: item.LastError,
+		CreatedAt: item.CreatedAt,
+	})
+	return nil
+}
+
+// GetDeadLetters implements getting failed webhook deliveries of the user.
+func (r *repo) GetDeadLetters(_ context.Context, userID uuid.UUID) ([]webhook.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]webhook.Delivery, 0)
+	for _, v := range r.dead {
+		if v.UserID != userID {
+			continue
+		}
+		result = append(result, webhook.Delivery{
+			ID:        v.ID,
+			WebhookID: v.WebhookID,
+			UserID:    v.UserID,
+			Event:     v.Event,
+			Payload:   v.Payload,
+			Attempts:  v.Attempts,
+			LastError: v.LastError,
+			CreatedAt: v.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/sreway/shorturl/internal/domain/workspace"
+)
+
+// storageWorkspace describes the workspace type used in repository.
+type storageWorkspace struct {
+	Name    string                       `json:"name"`
+	Members map[uuid.UUID]workspace.Role `json:"members"`
+}
+
+// AddWorkspace implements saving workspace, the owner becomes its administrator.
+func (r *repo) AddWorkspace(_ context.Context, item workspace.Workspace, ownerID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.workspaces[item.ID()] = storageWorkspace{
+		Name:    item.Name(),
+		Members: map[uuid.UUID]workspace.Role{ownerID: workspace.RoleAdmin},
+	}
+	return nil
+}
+
+// GetWorkspaces implements getting workspaces the user ID is a member of.
+func (r *repo) GetWorkspaces(_ context.Context, userID uuid.UUID) ([]workspace.Workspace, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []workspace.Workspace{}
+	for k, v := range r.workspaces {
+		role, ok := v.Members[userID]
+		if !ok {
+			continue
+		}
+		w := workspace.NewWorkspace(k, v.Name)
+		w.SetRole(role)
+		result = append(result, w)
+	}
+
+	return result, nil
+}
+
+// GetRole implements getting the role of the user ID in the workspace.
+func (r *repo) GetRole(_ context.Context, workspaceID, userID uuid.UUID) (workspace.Role, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.role(workspaceID, userID)
+}
+
+// SetMember implements adding the workspace member or changing the member role.
+func (r *repo) SetMember(_ context.Context, workspaceID, userID uuid.UUID, role workspace.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.workspaces[workspaceID]
+	if !ok {
+		return workspace.ErrNotFound
+	}
+
+	w.Members[userID] = role
+	return nil
+}
+
+// DeleteMember implements removing the workspace member.
+func (r *repo) DeleteMember(_ context.Context, workspaceID, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.workspaces[workspaceID]
+	if !ok {
+		return workspace.ErrNotFound
+	}
+
+	delete(w.Members, userID)
+	return nil
+}
+
+// role implements getting the role of the user ID in the workspace, the caller must hold the lock.
+func (r *repo) role(workspaceID, userID uuid.UUID) (workspace.Role, error) {
+	if workspace.Personal(workspaceID, userID) {
+		return workspace.RoleAdmin, nil
+	}
+
+	w, ok := r.workspaces[workspaceID]
+	if !ok {
+		return "", workspace.ErrNotFound
+	}
+
+	role, ok := w.Members[userID]
+	if !ok {
+		return "", workspace.ErrForbidden
+	}
+
+	return role, nil
+}
+
+// allows implements checking that the user ID has the required role in the workspace,
+// the caller must hold the lock.
+func (r *repo) allows(workspaceID, userID uuid.UUID, required workspace.Role) bool {
+	role, err := r.role(workspaceID, userID)
+	return err == nil && role.Allows(required)
+}
@@ -0,0 +1,98 @@
+// Package driver implements the registry of the storage backends selected by the application configuration.
+//
+// The backends register themselves by name on import, the application imports the backends it is built with.
+package driver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// Driver implements the storage backend registered by name.
+type Driver struct {
+	// Open creates the storage from the configuration.
+	Open func(ctx context.Context, cfg config.Storage) (storage.URL, error)
+	// Configured reports the configuration sets up the storage, the configured drivers are selected
+	// automatically when the driver is not set.
+	Configured func(cfg config.Storage) bool
+	// Priority defines the order of the automatic selection, the lower priority is tried first.
+	Priority int
+}
+
+var (
+	mu      sync.RWMutex
+	drivers = map[string]Driver{}
+)
+
+// Register implements registering the storage driver by name, registering the name twice panics.
+func Register(name string, d Driver) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if d.Open == nil {
+		panic("driver: register nil open of the storage driver " + name)
+	}
+
+	if _, ok := drivers[name]; ok {
+		panic("driver: register the storage driver twice " + name)
+	}
+
+	drivers[name] = d
+}
+
+// Drivers implements getting the sorted names of the registered storage drivers.
+func Drivers() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Candidates implements getting the names of the storage drivers to try in order. It is the driver
+// set by the configuration or the configured drivers in the order of the priority otherwise.
+func Candidates(cfg config.Storage) []string {
+	if name := cfg.GetDriver(); len(name) > 0 {
+		return []string{name}
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for name, d := range drivers {
+		if d.Configured == nil || d.Configured(cfg) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if drivers[names[i]].Priority != drivers[names[j]].Priority {
+			return drivers[names[i]].Priority < drivers[names[j]].Priority
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// Open implements the creation of the storage by the registered driver name.
+func Open(ctx context.Context, name string, cfg config.Storage) (storage.URL, error) {
+	mu.RLock()
+	d, ok := drivers[name]
+	mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDriver, name)
+	}
+
+	return d.Open(ctx, cfg)
+}
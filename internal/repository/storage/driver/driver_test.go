@@ -0,0 +1,162 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// testStorage implements the storage configuration with the driver set by the test.
+type testStorage struct {
+	config.Storage
+	driver string
+}
+
+func (s *testStorage) GetDriver() string {
+	return s.driver
+}
+
+func register(t *testing.T, ds map[string]Driver) {
+	t.Helper()
+
+	mu.Lock()
+	prev := drivers
+	drivers = ds
+	mu.Unlock()
+
+	t.Cleanup(func() {
+		mu.Lock()
+		drivers = prev
+		mu.Unlock()
+	})
+}
+
+func open(_ context.Context, _ config.Storage) (storage.URL, error) {
+	return nil, nil
+}
+
+func configured(v bool) func(cfg config.Storage) bool {
+	return func(_ config.Storage) bool {
+		return v
+	}
+}
+
+func Test_Candidates(t *testing.T) {
+	ds := map[string]Driver{
+		"postgres": {Open: open, Configured: configured(true), Priority: 20},
+		"sqlite":   {Open: open, Configured: configured(false), Priority: 10},
+		"bolt":     {Open: open, Configured: configured(true), Priority: 20},
+		"file":     {Open: open, Configured: configured(true), Priority: 40},
+		"memory":   {Open: open, Priority: 100},
+	}
+
+	tests := []struct {
+		name   string
+		driver string
+		want   []string
+	}{
+		{
+			name: "configured drivers in order of priority",
+			want: []string{"bolt", "postgres", "file", "memory"},
+		},
+		{
+			name:   "driver set by configuration",
+			driver: "sqlite",
+			want:   []string{"sqlite"},
+		},
+		{
+			name:   "unknown driver set by configuration",
+			driver: "mysql",
+			want:   []string{"mysql"},
+		},
+	}
+
+	register(t, ds)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Candidates(&testStorage{driver: tt.driver}))
+		})
+	}
+}
+
+func Test_Open(t *testing.T) {
+	errOpen := errors.New("any error")
+	ds := map[string]Driver{
+		"memory": {Open: open},
+		"broken": {Open: func(_ context.Context, _ config.Storage) (storage.URL, error) {
+			return nil, errOpen
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		driver  string
+		wantErr error
+	}{
+		{
+			name:   "positive open",
+			driver: "memory",
+		},
+		{
+			name:    "negative open (unknown driver)",
+			driver:  "mysql",
+			wantErr: ErrUnknownDriver,
+		},
+		{
+			name:    "negative open (driver error)",
+			driver:  "broken",
+			wantErr: errOpen,
+		},
+	}
+
+	register(t, ds)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Open(context.Background(), tt.driver, &testStorage{})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_Register(t *testing.T) {
+	tests := []struct {
+		name      string
+		driver    string
+		d         Driver
+		wantPanic bool
+	}{
+		{
+			name:   "positive register",
+			driver: "file",
+			d:      Driver{Open: open},
+		},
+		{
+			name:      "negative register (registered twice)",
+			driver:    "memory",
+			d:         Driver{Open: open},
+			wantPanic: true,
+		},
+		{
+			name:      "negative register (nil open)",
+			driver:    "bolt",
+			wantPanic: true,
+		},
+	}
+
+	register(t, map[string]Driver{"memory": {Open: open}})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPanic {
+				assert.Panics(t, func() { Register(tt.driver, tt.d) })
+				return
+			}
+			assert.NotPanics(t, func() { Register(tt.driver, tt.d) })
+			assert.Contains(t, Drivers(), tt.driver)
+		})
+	}
+}
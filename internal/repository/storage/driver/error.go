@@ -0,0 +1,8 @@
+package driver
+
+import (
+	"errors"
+)
+
+// ErrUnknownDriver implements the storage driver not registered error.
+var ErrUnknownDriver = errors.New("unknown storage driver")
@@ -0,0 +1,476 @@
+// Package dual implements the storage writing short URLs to the primary and the secondary storage,
+// so the traffic is moved between the storages without downtime.
+//
+// Short URLs are read from the primary storage and optionally compared with the secondary storage in the background,
+// the differences are logged. The state of the changed short URLs is copied from the primary storage to the secondary
+// one after every write, the failed copy is logged and does not fail the write. Promote swaps the storages at runtime.
+//
+// The accounts, workspaces, reports, audit log, webhooks, deferred tasks and session locks are not copied,
+// they stay in the storage that was primary at the start and do not follow the promotion. The outbox messages
+// and the invalidations are written along with the short URLs, so they are read from both storages,
+// the promotion is refused when the secondary storage does not provide them or does not list the short URL IDs.
+package dual
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/task"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// metrics implements the published counters of the secondary writes, the shadow reads and the promotions.
+var metrics = expvar.NewMap("url_dual_write")
+
+type (
+	repo struct {
+		// mu is held for reading by the writes to both storages and for writing by the promotion,
+		// so the write is not split between the storages swapped in the middle.
+		mu        sync.RWMutex
+		primary   backend
+		secondary backend
+		// stores keeps the stores other than short URLs, it is the primary storage at the start.
+		stores        storage.URL
+		shadowReads   bool
+		shadowTimeout time.Duration
+		// shadow limits the number of the concurrent shadow reads.
+		shadow chan struct{}
+		wg     sync.WaitGroup
+		logger *slog.Logger
+	}
+
+	// backend implements the storage with the name of its driver.
+	backend struct {
+		name     string
+		url      storage.URL
+		importer storage.Importer
+	}
+)
+
+// Add implements saving the short URL to the primary storage and copying it to the secondary storage.
+func (r *repo) Add(ctx context.Context, item entity.URL) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.primary.url.Add(ctx, item); err != nil {
+		return err
+	}
+
+	r.mirror(ctx, "Add", r.secondary.importer.Import(ctx, []entity.URL{item}))
+	return nil
+}
+
+// Batch implements saving multiple short URLs to the primary storage and copying them to the secondary storage.
+func (r *repo) Batch(ctx context.Context, urls []entity.URL) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.primary.url.Batch(ctx, urls); err != nil {
+		return err
+	}
+
+	r.mirror(ctx, "Batch", r.secondary.importer.Import(ctx, urls))
+	return nil
+}
+
+// Update implements changing the short URL in the primary storage and copying it to the secondary storage.
+func (r *repo) Update(ctx context.Context, item entity.URL) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.primary.url.Update(ctx, item); err != nil {
+		return err
+	}
+
+	r.mirror(ctx, "Update", r.copyURLs(ctx, []uuid.UUID{item.ID()}))
+	return nil
+}
+
+// BatchDelete implements the deletion of short URLs in the primary storage and copying the deleted ones
+// to the secondary storage.
+func (r *repo) BatchDelete(ctx context.Context, urls []entity.URL) (map[uuid.UUID]task.Outcome, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	outcomes, err := r.primary.url.BatchDelete(ctx, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(outcomes))
+	for id, outcome := range outcomes {
+		if outcome == task.OutcomeDeleted {
+			ids = append(ids, id)
+		}
+	}
+
+	r.mirror(ctx, "BatchDelete", r.copyURLs(ctx, ids))
+	return outcomes, nil
+}
+
+// ChangeOwner implements moving short URLs to another user ID in both storages.
+func (r *repo) ChangeOwner(ctx context.Context, from, to uuid.UUID) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.primary.url.ChangeOwner(ctx, from, to); err != nil {
+		return err
+	}
+
+	r.mirror(ctx, "ChangeOwner", r.secondary.url.ChangeOwner(ctx, from, to))
+	return nil
+}
+
+// SetDisabled implements the setting of the moderation attribute in the primary storage and copying
+// the short URL to the secondary storage.
+func (r *repo) SetDisabled(ctx context.Context, id uuid.UUID, disabled bool) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.primary.url.SetDisabled(ctx, id, disabled); err != nil {
+		return err
+	}
+
+	r.mirror(ctx, "SetDisabled", r.copyURLs(ctx, []uuid.UUID{id}))
+	return nil
+}
+
+// ForceDelete implements the removal of short URLs from both storages.
+func (r *repo) ForceDelete(ctx context.Context, ids []uuid.UUID) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.primary.url.ForceDelete(ctx, ids); err != nil {
+		return err
+	}
+
+	r.mirror(ctx, "ForceDelete", r.secondary.url.ForceDelete(ctx, ids))
+	return nil
+}
+
+// BanUser implements the setting of the user ban in both storages.
+func (r *repo) BanUser(ctx context.Context, userID uuid.UUID, banned bool) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.primary.url.BanUser(ctx, userID, banned); err != nil {
+		return err
+	}
+
+	r.mirror(ctx, "BanUser", r.secondary.url.BanUser(ctx, userID, banned))
+	return nil
+}
+
+// Get implements getting the short URL from the primary storage, the short URL is compared with
+// the secondary storage in the background when the shadow reads are enabled.
+func (r *repo) Get(ctx context.Context, id uuid.UUID) (entity.URL, error) {
+	primary, secondary := r.backends()
+
+	u, err := primary.url.Get(ctx, id)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return nil, err
+	}
+
+	r.shadowRead("Get", func(ctx context.Context) error {
+		stored, err := secondary.url.Get(ctx, id)
+		if err != nil && !errors.Is(err, entity.ErrNotFound) {
+			return err
+		}
+
+		r.compare("Get", primary, secondary, urlsByID(u), urlsByID(stored))
+		return nil
+	})
+
+	return u, err
+}
+
+// GetByUserID implements getting short URLs for user ID from the primary storage, the short URLs are compared with
+// the secondary storage in the background when the shadow reads are enabled.
+func (r *repo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]entity.URL, error) {
+	primary, secondary := r.backends()
+
+	urls, err := primary.url.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.shadowRead("GetByUserID", func(ctx context.Context) error {
+		stored, err := secondary.url.GetByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		r.compare("GetByUserID", primary, secondary, urlsByID(urls...), urlsByID(stored...))
+		return nil
+	})
+
+	return urls, nil
+}
+
+// GetByWorkspaceID implements getting short URLs of the workspace visible to the user ID from the primary storage.
+func (r *repo) GetByWorkspaceID(ctx context.Context, workspaceID, userID uuid.UUID) ([]entity.URL, error) {
+	primary, _ := r.backends()
+	return primary.url.GetByWorkspaceID(ctx, workspaceID, userID)
+}
+
+// Search implements getting short URLs of all users matching the filter from the primary storage.
+func (r *repo) Search(ctx context.Context, filter entity.Filter) ([]entity.URL, error) {
+	primary, _ := r.backends()
+	return primary.url.Search(ctx, filter)
+}
+
+// IsBanned implements checking the user ban in the primary storage.
+func (r *repo) IsBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	primary, _ := r.backends()
+	return primary.url.IsBanned(ctx, userID)
+}
+
+// Ping implements health check of the primary storage.
+func (r *repo) Ping(ctx context.Context) error {
+	primary, _ := r.backends()
+	return primary.url.Ping(ctx)
+}
+
+// GetUserCount implements the getting user count stat of the primary storage.
+func (r *repo) GetUserCount(ctx context.Context) (int, error) {
+	primary, _ := r.backends()
+	return primary.url.GetUserCount(ctx)
+}
+
+// GetURLCount implements the getting url count stat of the primary storage.
+func (r *repo) GetURLCount(ctx context.Context) (int, error) {
+	primary, _ := r.backends()
+	return primary.url.GetURLCount(ctx)
+}
+
+// Close implements waiting for the shadow reads and closing both storages.
+func (r *repo) Close() error {
+	r.wg.Wait()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err := r.primary.url.Close()
+	if errSecondary := r.secondary.url.Close(); err == nil {
+		err = errSecondary
+	}
+	return err
+}
+
+// Promote implements making the secondary storage primary, the storage that is not available is not promoted.
+func (r *repo) Promote(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.secondary.url.Ping(ctx); err != nil {
+		r.logger.Error("failed check secondary storage", err, slog.String("func", "Promote"),
+			slog.String("driver", r.secondary.name))
+		return "", err
+	}
+
+	if names := unsupported(r.primary.url, r.secondary.url); len(names) > 0 {
+		r.logger.Error("failed check secondary storage", ErrStoreNotSupported, slog.String("func", "Promote"),
+			slog.String("driver", r.secondary.name), slog.Any("stores", names))
+		return "", ErrStoreNotSupported
+	}
+
+	r.primary, r.secondary = r.secondary, r.primary
+	metrics.Add("promotions", 1)
+	r.logger.Info("promote secondary storage", slog.String("primary", r.primary.name),
+		slog.String("secondary", r.secondary.name))
+	return r.primary.name, nil
+}
+
+// Primary implements getting the name of the driver of the primary storage.
+func (r *repo) Primary() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.primary.name
+}
+
+// backends implements getting the current primary and secondary storages.
+func (r *repo) backends() (backend, backend) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.primary, r.secondary
+}
+
+// copyURLs implements copying the state of the short URLs from the primary storage to the secondary storage,
+// the short URLs removed meanwhile are skipped. The lock is held by the caller.
+func (r *repo) copyURLs(ctx context.Context, ids []uuid.UUID) error {
+	urls := make([]entity.URL, 0, len(ids))
+	for _, id := range ids {
+		u, err := r.primary.url.Get(ctx, id)
+		if errors.Is(err, entity.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		urls = append(urls, u)
+	}
+
+	if len(urls) == 0 {
+		return nil
+	}
+	return r.secondary.importer.Import(ctx, urls)
+}
+
+// mirror implements logging the failed write to the secondary storage, the storages differ until the short URLs
+// are changed again or copied by the migration. The lock is held by the caller.
+func (r *repo) mirror(_ context.Context, op string, err error) {
+	if err == nil {
+		return
+	}
+
+	metrics.Add("secondary_errors", 1)
+	r.logger.Error("failed write secondary storage", err, slog.String("func", op),
+		slog.String("driver", r.secondary.name))
+}
+
+// shadowRead implements running the read from the secondary storage in the background, the read is skipped
+// when the shadow reads are disabled or too many of them are running.
+func (r *repo) shadowRead(op string, fn func(ctx context.Context) error) {
+	if !r.shadowReads {
+		return
+	}
+
+	select {
+	case r.shadow <- struct{}{}:
+	default:
+		metrics.Add("shadow_skipped", 1)
+		return
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer func() {
+			<-r.shadow
+			r.wg.Done()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), r.shadowTimeout)
+		defer cancel()
+
+		metrics.Add("shadow_reads", 1)
+		if err := fn(ctx); err != nil {
+			metrics.Add("shadow_errors", 1)
+			r.logger.Error("failed shadow read", err, slog.String("func", op))
+		}
+	}()
+}
+
+// compare implements logging the short URLs missing or differing in the secondary storage.
+func (r *repo) compare(op string, primary, secondary backend, expected, stored map[uuid.UUID]entity.URL) {
+	for id, u := range expected {
+		fields := diff(u, stored[id])
+		if len(fields) == 0 {
+			continue
+		}
+
+		metrics.Add("shadow_mismatches", 1)
+		r.logger.Warn("shadow read mismatch", slog.String("func", op), slog.String("id", id.String()),
+			slog.String("primary", primary.name), slog.String("secondary", secondary.name),
+			slog.Any("fields", fields))
+	}
+
+	for id := range stored {
+		if _, ok := expected[id]; ok {
+			continue
+		}
+
+		metrics.Add("shadow_mismatches", 1)
+		r.logger.Warn("shadow read mismatch", slog.String("func", op), slog.String("id", id.String()),
+			slog.String("primary", primary.name), slog.String("secondary", secondary.name),
+			slog.Any("fields", []string{"missing"}))
+	}
+}
+
+// diff implements getting the names of the differing attributes of the short URLs,
+// the missing short URL is nil.
+func diff(a, b entity.URL) []string {
+	switch {
+	case a == nil && b == nil:
+		return nil
+	case a == nil || b == nil:
+		return []string{"missing"}
+	}
+
+	var fields []string
+	if a.UserID() != b.UserID() {
+		fields = append(fields, "user_id")
+	}
+	if a.WorkspaceID() != b.WorkspaceID() {
+		fields = append(fields, "workspace_id")
+	}
+	if a.LongURL() != b.LongURL() {
+		fields = append(fields, "original_url")
+	}
+	if a.Deleted() != b.Deleted() {
+		fields = append(fields, "deleted")
+	}
+	if a.Disabled() != b.Disabled() {
+		fields = append(fields, "disabled")
+	}
+	return fields
+}
+
+// urlsByID implements indexing the short URLs by ID, nil short URLs are skipped.
+func urlsByID(urls ...entity.URL) map[uuid.UUID]entity.URL {
+	items := make(map[uuid.UUID]entity.URL, len(urls))
+	for _, u := range urls {
+		if u != nil {
+			items[u.ID()] = u
+		}
+	}
+	return items
+}
+
+// New implements the creation of the storage writing short URLs to the primary and the secondary storage.
+func New(primary storage.URL, primaryName string, secondary storage.URL, secondaryName string,
+	cfg config.DualWrite,
+) (*repo, error) {
+	primaryImporter, ok := primary.(storage.Importer)
+	if !ok {
+		return nil, ErrImportNotSupported
+	}
+
+	secondaryImporter, ok := secondary.(storage.Importer)
+	if !ok {
+		return nil, ErrImportNotSupported
+	}
+
+	timeout := cfg.GetShadowTimeout()
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+
+	concurrency := cfg.GetShadowConcurrency()
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	log := slog.New(slog.NewJSONHandler(os.Stdout).
+		WithAttrs([]slog.Attr{slog.String("service", "dual_write")}))
+
+	return &repo{
+		primary:       backend{name: primaryName, url: primary, importer: primaryImporter},
+		secondary:     backend{name: secondaryName, url: secondary, importer: secondaryImporter},
+		stores:        primary,
+		shadowReads:   cfg.GetShadowReads(),
+		shadowTimeout: timeout,
+		shadow:        make(chan struct{}, concurrency),
+		logger:        log,
+	}, nil
+}
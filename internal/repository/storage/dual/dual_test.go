@@ -0,0 +1,230 @@
+package dual
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+// testConfig implements the dual-write configuration.
+type testConfig struct {
+	shadowReads bool
+}
+
+func (c testConfig) GetSecondaryURL() string         { return "bolt:///tmp/urls.db" }
+func (c testConfig) GetShadowReads() bool            { return c.shadowReads }
+func (c testConfig) GetShadowTimeout() time.Duration { return time.Second }
+func (c testConfig) GetShadowConcurrency() int       { return 10 }
+
+// testStorage implements the storage importing short URLs.
+type testStorage struct {
+	*repoMock.MockURL
+	*repoMock.MockImporter
+}
+
+func newTestStorage(ctl *gomock.Controller) testStorage {
+	return testStorage{MockURL: repoMock.NewMockURL(ctl), MockImporter: repoMock.NewMockImporter(ctl)}
+}
+
+func newURL(id uuid.UUID, disabled bool) entity.URL {
+	value, _ := url.Parse("https://example.com")
+	u := entity.NewURL(id, uuid.MustParse("6f1c2d3e-4a5b-4c6d-8e7f-9a0b1c2d3e4f"))
+	u.SetWorkspaceID(u.UserID())
+	u.SetLongURL(*value)
+	u.SetDisabled(disabled)
+	return u
+}
+
+func Test_repo_Add(t *testing.T) {
+	errStorage := errors.New("connection refused")
+	tests := []struct {
+		name         string
+		primaryErr   error
+		secondaryErr error
+		wantErr      error
+	}{
+		{
+			name: "positive add url (mirrored)",
+		},
+		{
+			name:         "positive add url (secondary failed)",
+			secondaryErr: errStorage,
+		},
+		{
+			name:       "negative add url (primary failed)",
+			primaryErr: entity.ErrAlreadyExist,
+			wantErr:    entity.ErrAlreadyExist,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		item := newURL(uuid.MustParse("8a9b0c1d-2e3f-4a5b-9c6d-7e8f9a0b1c2d"), false)
+		primary, secondary := newTestStorage(ctl), newTestStorage(ctl)
+		primary.MockURL.EXPECT().Add(anyMock, item).Return(tt.primaryErr)
+		if tt.primaryErr == nil {
+			secondary.MockImporter.EXPECT().Import(anyMock, []entity.URL{item}).Return(tt.secondaryErr)
+		}
+
+		r, err := New(primary, "file", secondary, "postgres", testConfig{})
+		assert.NoError(t, err)
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, r.Add(ctx, item), tt.wantErr)
+		})
+	}
+}
+
+func Test_repo_SetDisabled(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	id := uuid.MustParse("9b0c1d2e-3f4a-4b5c-8d7e-8f9a0b1c2d3e")
+	stored := newURL(id, true)
+	primary, secondary := newTestStorage(ctl), newTestStorage(ctl)
+	primary.MockURL.EXPECT().SetDisabled(anyMock, id, true).Return(nil)
+	primary.MockURL.EXPECT().Get(anyMock, id).Return(stored, nil)
+	secondary.MockImporter.EXPECT().Import(anyMock, []entity.URL{stored}).Return(nil)
+
+	r, err := New(primary, "file", secondary, "postgres", testConfig{})
+	assert.NoError(t, err)
+	assert.NoError(t, r.SetDisabled(context.Background(), id, true))
+}
+
+func Test_repo_Promote(t *testing.T) {
+	errStorage := errors.New("connection refused")
+	tests := []struct {
+		name        string
+		pingErr     error
+		wantPrimary string
+		wantErr     error
+	}{
+		{
+			name:        "positive promote secondary storage",
+			wantPrimary: "postgres",
+		},
+		{
+			name:        "negative promote secondary storage (unavailable)",
+			pingErr:     errStorage,
+			wantPrimary: "file",
+			wantErr:     errStorage,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		id := uuid.MustParse("0c1d2e3f-4a5b-4c6d-9e8f-9a0b1c2d3e4f")
+		primary, secondary := newTestStorage(ctl), newTestStorage(ctl)
+		secondary.MockURL.EXPECT().Ping(anyMock).Return(tt.pingErr)
+		if tt.pingErr == nil {
+			secondary.MockURL.EXPECT().Get(anyMock, id).Return(newURL(id, false), nil)
+		} else {
+			primary.MockURL.EXPECT().Get(anyMock, id).Return(newURL(id, false), nil)
+		}
+
+		r, err := New(primary, "file", secondary, "postgres", testConfig{})
+		assert.NoError(t, err)
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Promote(ctx)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantPrimary, r.Primary())
+
+			_, err = r.Get(ctx, id)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_repo_Get(t *testing.T) {
+	id := uuid.MustParse("1d2e3f4a-5b6c-4d7e-8f9a-0b1c2d3e4f5a")
+	tests := []struct {
+		name           string
+		shadowReads    bool
+		stored         entity.URL
+		wantMismatches int64
+	}{
+		{
+			name:        "positive get url (shadow read matched)",
+			shadowReads: true,
+			stored:      newURL(id, false),
+		},
+		{
+			name:           "positive get url (shadow read differs)",
+			shadowReads:    true,
+			stored:         newURL(id, true),
+			wantMismatches: 1,
+		},
+		{
+			name:           "positive get url (shadow read missing)",
+			shadowReads:    true,
+			wantMismatches: 1,
+		},
+		{
+			name: "positive get url (shadow reads disabled)",
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		primary, secondary := newTestStorage(ctl), newTestStorage(ctl)
+		primary.MockURL.EXPECT().Get(anyMock, id).Return(newURL(id, false), nil)
+		primary.MockURL.EXPECT().Close().Return(nil)
+		secondary.MockURL.EXPECT().Close().Return(nil)
+		if tt.shadowReads {
+			if tt.stored != nil {
+				secondary.MockURL.EXPECT().Get(anyMock, id).Return(tt.stored, nil)
+			} else {
+				secondary.MockURL.EXPECT().Get(anyMock, id).Return(nil,
+					entity.NewURLErr(id, uuid.UUID{}, entity.ErrNotFound))
+			}
+		}
+
+		r, err := New(primary, "file", secondary, "postgres", testConfig{shadowReads: tt.shadowReads})
+		assert.NoError(t, err)
+		t.Run(tt.name, func(t *testing.T) {
+			before := mismatches()
+			u, err := r.Get(ctx, id)
+			assert.NoError(t, err)
+			assert.Equal(t, id, u.ID())
+
+			// closing waits for the shadow reads
+			assert.NoError(t, r.Close())
+			assert.Equal(t, tt.wantMismatches, mismatches()-before)
+		})
+	}
+}
+
+func Test_New(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	_, err := New(newTestStorage(ctl), "file", repoMock.NewMockURL(ctl), "postgres", testConfig{})
+	assert.ErrorIs(t, err, ErrImportNotSupported)
+}
+
+// mismatches implements getting the published number of the shadow read mismatches.
+func mismatches() int64 {
+	if v, ok := metrics.Get("shadow_mismatches").(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
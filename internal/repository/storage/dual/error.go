@@ -0,0 +1,13 @@
+package dual
+
+import (
+	"errors"
+)
+
+// ErrImportNotSupported implements the storage not importing short URLs error, both storages mirror
+// the short URLs by importing them.
+var ErrImportNotSupported = errors.New("storage does not support importing urls")
+
+// ErrStoreNotSupported implements the secondary storage not providing the store of the primary storage error,
+// the store following the promotion would be lost.
+var ErrStoreNotSupported = errors.New("secondary storage does not support the stores of the primary storage")
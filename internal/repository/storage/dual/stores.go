@@ -0,0 +1,195 @@
+package dual
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sreway/shorturl/internal/domain/outbox"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+type (
+	// messages implements the outbox storage of both storages, the messages are written by the storage
+	// saving the short URLs, so the messages written before the promotion stay in the previous primary storage.
+	messages struct{ r *repo }
+	// invalidations implements listening the changes of short URLs published by both storages.
+	invalidations struct{ r *repo }
+	// scanner implements listing the IDs of the short URLs of the current primary storage.
+	scanner struct{ r *repo }
+)
+
+// Accounts implements getting the accounts storage, the accounts stay in the storage that was primary
+// at the start since they are not copied by the promotion.
+func (r *repo) Accounts() (storage.Account, bool) {
+	v, ok := r.stores.(storage.Account)
+	return v, ok
+}
+
+// Workspaces implements getting the workspaces storage, the workspaces stay in the storage that was primary
+// at the start since they are not copied by the promotion.
+func (r *repo) Workspaces() (storage.Workspace, bool) {
+	v, ok := r.stores.(storage.Workspace)
+	return v, ok
+}
+
+// Reports implements getting the abuse reports storage, the reports stay in the storage that was primary
+// at the start since they are not copied by the promotion.
+func (r *repo) Reports() (storage.Report, bool) {
+	v, ok := r.stores.(storage.Report)
+	return v, ok
+}
+
+// Audit implements getting the audit log storage, the audit log stays in the storage that was primary
+// at the start since it is not copied by the promotion.
+func (r *repo) Audit() (storage.Audit, bool) {
+	v, ok := r.stores.(storage.Audit)
+	return v, ok
+}
+
+// Webhooks implements getting the webhooks storage, the webhooks stay in the storage that was primary
+// at the start since they are not copied by the promotion.
+func (r *repo) Webhooks() (storage.Webhook, bool) {
+	v, ok := r.stores.(storage.Webhook)
+	return v, ok
+}
+
+// Queue implements getting the deferred tasks storage, the tasks stay in the storage that was primary
+// at the start since they are not copied by the promotion.
+func (r *repo) Queue() (storage.Queue, bool) {
+	v, ok := r.stores.(storage.Queue)
+	return v, ok
+}
+
+// Locker implements getting the session locks storage, the locks stay in the storage that was primary
+// at the start, so the leader holding the lock is not elected again in the promoted storage.
+func (r *repo) Locker() (storage.Locker, bool) {
+	v, ok := r.stores.(storage.Locker)
+	return v, ok
+}
+
+// Outbox implements getting the outbox storage dispatching the messages of both storages, false is returned
+// when the primary storage does not store the outbox.
+func (r *repo) Outbox() (storage.Outbox, bool) {
+	_, ok := r.primaryURL().(storage.Outbox)
+	return messages{r: r}, ok
+}
+
+// Invalidation implements getting the storage publishing the changes of short URLs of both storages,
+// false is returned when the primary storage does not publish them.
+func (r *repo) Invalidation() (storage.Invalidation, bool) {
+	_, ok := r.primaryURL().(storage.Invalidation)
+	return invalidations{r: r}, ok
+}
+
+// IDScanner implements getting the storage listing the IDs of the short URLs following the promotion,
+// false is returned when the primary storage does not list them.
+func (r *repo) IDScanner() (storage.IDScanner, bool) {
+	_, ok := r.primaryURL().(storage.IDScanner)
+	return scanner{r: r}, ok
+}
+
+// primaryURL implements getting the current primary storage.
+func (r *repo) primaryURL() storage.URL {
+	primary, _ := r.backends()
+	return primary.url
+}
+
+// unsupported implements getting the names of the stores following the promotion provided by the storage from
+// but not by the storage to, the promotion is refused while they are used.
+func unsupported(from, to storage.URL) []string {
+	checks := []struct {
+		name string
+		fn   func(s storage.URL) bool
+	}{
+		{"outbox", func(s storage.URL) bool { _, ok := s.(storage.Outbox); return ok }},
+		{"invalidation", func(s storage.URL) bool { _, ok := s.(storage.Invalidation); return ok }},
+		{"id_scanner", func(s storage.URL) bool { _, ok := s.(storage.IDScanner); return ok }},
+	}
+
+	names := make([]string, 0)
+	for _, check := range checks {
+		if check.fn(from) && !check.fn(to) {
+			names = append(names, check.name)
+		}
+	}
+	return names
+}
+
+// DispatchOutbox implements dispatching the messages of the current primary storage and then the messages
+// left in the previous primary storage, up to the limit in total.
+func (s messages) DispatchOutbox(ctx context.Context, limit int,
+	dispatch func(ctx context.Context, messages []outbox.Message) error,
+) (int, error) {
+	var total int
+	primary, secondary := s.r.backends()
+	for _, b := range []backend{primary, secondary} {
+		o, ok := b.url.(storage.Outbox)
+		if !ok || total >= limit {
+			continue
+		}
+
+		n, err := o.DispatchOutbox(ctx, limit-total, dispatch)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// PurgeOutbox implements deleting the dispatched messages from both storages.
+func (s messages) PurgeOutbox(ctx context.Context, before time.Time) (int, error) {
+	var total int
+	primary, secondary := s.r.backends()
+	for _, b := range []backend{primary, secondary} {
+		o, ok := b.url.(storage.Outbox)
+		if !ok {
+			continue
+		}
+
+		n, err := o.PurgeOutbox(ctx, before)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// ListenInvalidations implements listening the changes published by both storages until the context is done,
+// the replicas write to either storage while the promotion is rolled out.
+func (s invalidations) ListenInvalidations(ctx context.Context, evict func(ids []uuid.UUID), flush func()) error {
+	primary, secondary := s.r.backends()
+
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+		errs = make(chan error, 2)
+	)
+	for _, b := range []backend{primary, secondary} {
+		l, ok := b.url.(storage.Invalidation)
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.ListenInvalidations(ctx, evict, flush); err != nil {
+				once.Do(func() { errs <- err })
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+// ScanIDs implements listing the IDs of the short URLs of the current primary storage.
+func (s scanner) ScanIDs(ctx context.Context, fn func(id uuid.UUID) error) error {
+	return s.r.primaryURL().(storage.IDScanner).ScanIDs(ctx, fn)
+}
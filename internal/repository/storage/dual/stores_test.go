@@ -0,0 +1,157 @@
+package dual
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/outbox"
+	"github.com/sreway/shorturl/internal/domain/task"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+// testStoresStorage implements the storage importing short URLs, storing deferred tasks, keeping the outbox
+// and publishing the changes of short URLs.
+type testStoresStorage struct {
+	testStorage
+	*repoMock.MockQueue
+	*repoMock.MockOutbox
+	*repoMock.MockInvalidation
+}
+
+func newTestStoresStorage(ctl *gomock.Controller) testStoresStorage {
+	return testStoresStorage{
+		testStorage:      newTestStorage(ctl),
+		MockQueue:        repoMock.NewMockQueue(ctl),
+		MockOutbox:       repoMock.NewMockOutbox(ctl),
+		MockInvalidation: repoMock.NewMockInvalidation(ctl),
+	}
+}
+
+func Test_repo_Promote_stores(t *testing.T) {
+	tests := []struct {
+		name        string
+		noOutbox    bool
+		wantPrimary string
+		wantErr     error
+	}{
+		{
+			name:        "positive promote secondary storage (queue stays)",
+			wantPrimary: "postgres",
+		},
+		{
+			name:        "negative promote secondary storage (outbox not supported)",
+			noOutbox:    true,
+			wantPrimary: "file",
+			wantErr:     ErrStoreNotSupported,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.MustParse("2e3f4a5b-6c7d-4e8f-9a0b-1c2d3e4f5a6b")
+			primary := newTestStoresStorage(ctl)
+			primary.MockQueue.EXPECT().GetTask(anyMock, id).Return(task.Task{ID: id}, nil)
+
+			var r *repo
+			var err error
+			if tt.noOutbox {
+				secondary := newTestStorage(ctl)
+				secondary.MockURL.EXPECT().Ping(anyMock).Return(nil)
+				r, err = New(primary, "file", secondary, "postgres", testConfig{})
+			} else {
+				secondary := newTestStoresStorage(ctl)
+				secondary.MockURL.EXPECT().Ping(anyMock).Return(nil)
+				r, err = New(primary, "file", secondary, "postgres", testConfig{})
+			}
+			assert.NoError(t, err)
+
+			_, err = r.Promote(ctx)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantPrimary, r.Primary())
+
+			// the tasks stay in the storage that was primary at the start
+			q, ok := r.Queue()
+			assert.True(t, ok)
+			got, err := q.GetTask(ctx, id)
+			assert.NoError(t, err)
+			assert.Equal(t, id, got.ID)
+		})
+	}
+}
+
+func Test_messages_DispatchOutbox(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	primary, secondary := newTestStoresStorage(ctl), newTestStoresStorage(ctl)
+	r, err := New(primary, "file", secondary, "postgres", testConfig{})
+	assert.NoError(t, err)
+
+	// the messages left in the previous primary storage are dispatched after the promotion
+	secondary.MockURL.EXPECT().Ping(anyMock).Return(nil)
+	_, err = r.Promote(ctx)
+	assert.NoError(t, err)
+
+	gomock.InOrder(
+		secondary.MockOutbox.EXPECT().DispatchOutbox(anyMock, 10, anyMock).Return(4, nil),
+		primary.MockOutbox.EXPECT().DispatchOutbox(anyMock, 6, anyMock).Return(6, nil),
+	)
+
+	o, ok := r.Outbox()
+	assert.True(t, ok)
+	n, err := o.DispatchOutbox(ctx, 10, func(context.Context, []outbox.Message) error { return nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 10, n)
+}
+
+func Test_invalidations_ListenInvalidations(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ids := []uuid.UUID{uuid.MustParse("3f4a5b6c-7d8e-4f9a-8b0c-2d3e4f5a6b7c")}
+	primary, secondary := newTestStoresStorage(ctl), newTestStoresStorage(ctl)
+	for _, s := range []testStoresStorage{primary, secondary} {
+		s.MockInvalidation.EXPECT().ListenInvalidations(anyMock, anyMock, anyMock).DoAndReturn(
+			func(_ context.Context, evict func(ids []uuid.UUID), _ func()) error {
+				evict(ids)
+				return nil
+			})
+	}
+
+	r, err := New(primary, "file", secondary, "postgres", testConfig{})
+	assert.NoError(t, err)
+
+	l, ok := r.Invalidation()
+	assert.True(t, ok)
+
+	evicted := make(chan []uuid.UUID, 2)
+	err = l.ListenInvalidations(context.Background(), func(ids []uuid.UUID) { evicted <- ids }, func() {})
+	assert.NoError(t, err)
+	assert.Len(t, evicted, 2)
+}
+
+func Test_repo_Accounts(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	r, err := New(newTestStorage(ctl), "file", newTestStorage(ctl), "postgres", testConfig{})
+	assert.NoError(t, err)
+
+	_, ok := r.Accounts()
+	assert.False(t, ok)
+	_, ok = r.Queue()
+	assert.False(t, ok)
+	_, ok = r.Invalidation()
+	assert.False(t, ok)
+}
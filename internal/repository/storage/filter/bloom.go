@@ -0,0 +1,83 @@
+package filter
+
+import (
+	"hash/fnv"
+	"math"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// bloom implements the Bloom filter of short URL IDs, the bits are set and tested atomically,
+// so the filter is filled while it is read.
+type bloom struct {
+	bits []uint64
+	m    uint64
+	k    uint64
+}
+
+// add implements adding the ID to the filter.
+func (b *bloom) add(id uuid.UUID) {
+	h1, h2 := hashes(id)
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		word, mask := &b.bits[pos/64], uint64(1)<<(pos%64)
+		for {
+			old := atomic.LoadUint64(word)
+			if old&mask != 0 || atomic.CompareAndSwapUint64(word, old, old|mask) {
+				break
+			}
+		}
+	}
+}
+
+// test implements checking the ID, false means the ID was never added.
+func (b *bloom) test(id uuid.UUID) bool {
+	h1, h2 := hashes(id)
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		if atomic.LoadUint64(&b.bits[pos/64])&(uint64(1)<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hashes implements the pair of hashes of the ID, the positions of the filter are derived by double hashing.
+func hashes(id uuid.UUID) (uint64, uint64) {
+	h := fnv.New128a()
+	_, _ = h.Write(id[:])
+	sum := h.Sum(nil)
+
+	var h1, h2 uint64
+	for i := 0; i < 8; i++ {
+		h1 = h1<<8 | uint64(sum[i])
+		h2 = h2<<8 | uint64(sum[8+i])
+	}
+	return h1, h2 | 1
+}
+
+// newBloom implements the creation of the Bloom filter sized for n IDs with the false positive rate p.
+func newBloom(n int, p float64) *bloom {
+	if n < 1 {
+		n = 1
+	}
+	if p <= 0 || p >= 1 {
+		p = 0.01
+	}
+
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	if m < 64 {
+		m = 64
+	}
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+
+	return &bloom{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
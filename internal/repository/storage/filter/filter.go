@@ -0,0 +1,270 @@
+// Package filter implements the storage decorator rejecting the lookups of unknown short URLs
+// before they reach the storage.
+//
+// The Bloom filter of existing IDs is built from the storage and extended on creation, the IDs created
+// by other replicas are added by the invalidation listener from the notification the storage sends when
+// the creation commits. The IDs missed by the Bloom filter are not looked up in the storage, since the lookups
+// of unknown IDs are the ones rejected, so the short URL created by another replica is not found until
+// its notification is received, usually within milliseconds of the creation. The notifications sent while
+// the listener reconnects are lost, the filter is flushed and rebuilt then and passes every lookup meanwhile.
+// The filter is meant for storages publishing invalidations only, without the listener the IDs created
+// by other replicas are rejected until the next rebuild. The lookups passed by the Bloom filter and not found
+// in the storage are remembered in the short-lived negative cache.
+package filter
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/config"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// metrics implements the published counters of the rejected and passed lookups.
+var metrics = expvar.NewMap("url_filter")
+
+type repo struct {
+	storage.URL
+	scanner storage.IDScanner
+	mu      sync.RWMutex
+	// ready defines the filter of every existing ID, nil passes every lookup.
+	ready *bloom
+	// building defines the filter being built, the created IDs are added to it as well.
+	building *bloom
+	// flushes defines the number of flushes, the filter built during a flush may miss creations and is rebuilt.
+	flushes    uint64
+	rebuilding atomic.Bool
+	negMu      sync.Mutex
+	negative   map[uuid.UUID]time.Time
+	// generation defines the number of invalidations, lookups started before an invalidation are not remembered.
+	generation    uint64
+	expected      int
+	falsePositive float64
+	negativeSize  int
+	negativeTTL   time.Duration
+	logger        *slog.Logger
+}
+
+// Get implements getting the short URL from the storage unless it is definitely absent.
+func (r *repo) Get(ctx context.Context, id uuid.UUID) (entity.URL, error) {
+	if r.absent(id) {
+		return nil, entity.NewURLErr(id, uuid.UUID{}, entity.ErrNotFound)
+	}
+	metrics.Add("passed", 1)
+
+	r.negMu.Lock()
+	generation := r.generation
+	r.negMu.Unlock()
+
+	u, err := r.URL.Get(ctx, id)
+	if errors.Is(err, entity.ErrNotFound) {
+		r.remember(generation, id)
+	}
+	return u, err
+}
+
+// Add implements adding the ID to the filter and the short URL to the storage.
+func (r *repo) Add(ctx context.Context, url entity.URL) error {
+	r.Evict([]uuid.UUID{url.ID()})
+	return r.URL.Add(ctx, url)
+}
+
+// Batch implements adding the IDs to the filter and the short URLs to the storage.
+func (r *repo) Batch(ctx context.Context, urls []entity.URL) error {
+	ids := make([]uuid.UUID, len(urls))
+	for idx, u := range urls {
+		ids[idx] = u.ID()
+	}
+
+	r.Evict(ids)
+	return r.URL.Batch(ctx, urls)
+}
+
+// Evict implements adding the IDs created by any replica to the filter and dropping them from the negative cache.
+func (r *repo) Evict(ids []uuid.UUID) {
+	r.mu.RLock()
+	for _, id := range ids {
+		if r.ready != nil {
+			r.ready.add(id)
+		}
+		if r.building != nil {
+			r.building.add(id)
+		}
+	}
+	r.mu.RUnlock()
+
+	r.negMu.Lock()
+	defer r.negMu.Unlock()
+
+	r.generation++
+	for _, id := range ids {
+		delete(r.negative, id)
+	}
+}
+
+// Flush implements dropping the negative cache and rebuilding the filter, the creations may have been missed,
+// so every lookup is passed until the filter is rebuilt.
+func (r *repo) Flush() {
+	r.mu.Lock()
+	r.ready = nil
+	r.flushes++
+	r.mu.Unlock()
+
+	r.negMu.Lock()
+	r.generation++
+	r.negative = make(map[uuid.UUID]time.Time)
+	r.negMu.Unlock()
+
+	go func() {
+		if err := r.Build(context.Background()); err != nil {
+			r.logger.Error("failed rebuild url filter", err, slog.String("func", "Flush"))
+		}
+	}()
+}
+
+// Build implements filling the filter with the IDs of the storage, the filter is used once it is built.
+// Build returns immediately when the filter is already being built, the filter flushed during the build
+// is built again.
+func (r *repo) Build(ctx context.Context) error {
+	for r.rebuilding.CompareAndSwap(false, true) {
+		err := r.build(ctx)
+		r.rebuilding.Store(false)
+		if err != nil {
+			return err
+		}
+
+		r.mu.RLock()
+		built := r.ready != nil
+		r.mu.RUnlock()
+
+		if built {
+			return nil
+		}
+	}
+	return nil
+}
+
+// build implements filling the new filter with the IDs of the storage, the filter is dropped
+// when it was flushed during the build.
+func (r *repo) build(ctx context.Context) error {
+	count, err := r.URL.GetURLCount(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the filter is sized with the headroom for the short URLs created until the next rebuild
+	n := r.expected
+	if 2*count > n {
+		n = 2 * count
+	}
+	b := newBloom(n, r.falsePositive)
+
+	r.mu.Lock()
+	r.building = b
+	flushes := r.flushes
+	r.mu.Unlock()
+
+	err = r.scanner.ScanIDs(ctx, func(id uuid.UUID) error {
+		b.add(id)
+		return nil
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.building = nil
+	if err != nil || flushes != r.flushes {
+		return err
+	}
+
+	r.ready = b
+	r.logger.Info("url filter is built", slog.Int("items", count))
+	return nil
+}
+
+// absent implements checking the ID by the filter and the negative cache.
+func (r *repo) absent(id uuid.UUID) bool {
+	r.mu.RLock()
+	b := r.ready
+	r.mu.RUnlock()
+
+	if b != nil && !b.test(id) {
+		metrics.Add("rejected", 1)
+		return true
+	}
+
+	r.negMu.Lock()
+	defer r.negMu.Unlock()
+
+	expires, ok := r.negative[id]
+	if !ok {
+		return false
+	}
+
+	if time.Now().After(expires) {
+		delete(r.negative, id)
+		return false
+	}
+
+	metrics.Add("negative_hits", 1)
+	return true
+}
+
+// remember implements adding the unknown ID to the negative cache unless it was created during the lookup,
+// the expired entries are dropped when the cache is full, then the arbitrary ones.
+func (r *repo) remember(generation uint64, id uuid.UUID) {
+	if r.negativeSize <= 0 || r.negativeTTL <= 0 {
+		return
+	}
+
+	r.negMu.Lock()
+	defer r.negMu.Unlock()
+
+	if generation != r.generation {
+		return
+	}
+
+	now := time.Now()
+	if len(r.negative) >= r.negativeSize {
+		for k, expires := range r.negative {
+			if now.After(expires) {
+				delete(r.negative, k)
+			}
+		}
+	}
+	for k := range r.negative {
+		if len(r.negative) < r.negativeSize {
+			break
+		}
+		delete(r.negative, k)
+	}
+
+	r.negative[id] = now.Add(r.negativeTTL)
+}
+
+// New implements the creation of the storage decorator rejecting the lookups of unknown short URLs,
+// every lookup is passed until the filter is built.
+func New(s storage.URL, scanner storage.IDScanner, cfg config.URLFilter) *repo {
+	log := slog.New(slog.NewJSONHandler(os.Stdout).
+		WithAttrs([]slog.Attr{slog.String("service", "url_filter")}))
+
+	return &repo{
+		URL:           s,
+		scanner:       scanner,
+		negative:      make(map[uuid.UUID]time.Time),
+		expected:      cfg.GetExpectedItems(),
+		falsePositive: cfg.GetFalsePositiveRate(),
+		negativeSize:  cfg.GetNegativeCacheSize(),
+		negativeTTL:   cfg.GetNegativeCacheTTL(),
+		logger:        log,
+	}
+}
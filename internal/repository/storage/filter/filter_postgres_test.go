@@ -0,0 +1,101 @@
+//go:build postgres
+
+package filter
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/repository/storage/postgres"
+	"github.com/sreway/shorturl/internal/usecases/invalidation"
+)
+
+// postgresConfig implements the PostgreSQL storage configuration with the database set by TEST_DATABASE_DSN.
+type postgresConfig struct {
+	dsn string
+}
+
+func (c postgresConfig) GetDSN() string        { return c.dsn }
+func (c postgresConfig) GetMigrateURL() string { return "file://../../../../migrations/postgres" }
+
+func newPostgresURL() entity.URL {
+	value, _ := url.Parse("https://example.com/filter")
+	u := entity.NewURL(uuid.New(), uuid.New())
+	u.SetWorkspaceID(u.UserID())
+	u.SetLongURL(*value)
+	return u
+}
+
+// Test_repo_Get_notified implements checking that the short URLs created by another replica are passed
+// by the filter once their notification is received.
+func Test_repo_Get_notified(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if len(dsn) == 0 {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	local, err := postgres.New(ctx, postgresConfig{dsn: dsn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer local.Close()
+	remote, err := postgres.New(ctx, postgresConfig{dsn: dsn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer remote.Close()
+
+	r := New(local, local, testConfig{negativeSize: 100, negativeTTL: time.Minute})
+	assert.NoError(t, r.Build(ctx))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- invalidation.New(local, r).Run(ctx)
+	}()
+
+	// the short URLs created before the listener listens the channel stay rejected, the creation is repeated
+	found := func(u entity.URL) bool {
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if _, err = r.Get(ctx, u.ID()); err == nil {
+				return true
+			}
+			assert.ErrorIs(t, err, entity.ErrNotFound)
+			time.Sleep(20 * time.Millisecond)
+		}
+		return false
+	}
+	listening := false
+	for i := 0; i < 10 && !listening; i++ {
+		u := newPostgresURL()
+		assert.NoError(t, remote.Add(ctx, u))
+		listening = found(u)
+	}
+	assert.True(t, listening, "created short url is not notified")
+
+	// the unknown short URL is rejected, the short URL created by another replica is passed once notified
+	_, err = r.Get(ctx, uuid.New())
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+
+	u := newPostgresURL()
+	assert.NoError(t, remote.Add(ctx, u))
+	assert.True(t, found(u))
+
+	cancel()
+	select {
+	case err = <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("listener is not stopped")
+	}
+}
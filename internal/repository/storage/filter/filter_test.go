@@ -0,0 +1,296 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+// testConfig implements unknown short URLs filter configuration.
+type testConfig struct {
+	negativeSize int
+	negativeTTL  time.Duration
+}
+
+func (c testConfig) GetExpectedItems() int              { return 1000 }
+func (c testConfig) GetFalsePositiveRate() float64      { return 0.01 }
+func (c testConfig) GetNegativeCacheSize() int          { return c.negativeSize }
+func (c testConfig) GetNegativeCacheTTL() time.Duration { return c.negativeTTL }
+
+// scanIDs implements the scan of the storage holding the passed IDs.
+func scanIDs(ids ...uuid.UUID) func(context.Context, func(uuid.UUID) error) error {
+	return func(_ context.Context, fn func(uuid.UUID) error) error {
+		for _, id := range ids {
+			if err := fn(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func Test_bloom(t *testing.T) {
+	b := newBloom(1000, 0.01)
+	added := make([]uuid.UUID, 1000)
+	for idx := range added {
+		added[idx] = uuid.New()
+		b.add(added[idx])
+	}
+
+	for _, id := range added {
+		assert.True(t, b.test(id), "added id %s is absent", id)
+	}
+
+	var falsePositives int
+	for i := 0; i < 10000; i++ {
+		if b.test(uuid.New()) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, 300, "false positive rate is too high")
+}
+
+func Test_repo_Get(t *testing.T) {
+	known := uuid.MustParse("c9f7c7a6-2a44-4d6a-9e18-bc0e1b37e5a1")
+	notFound := entity.NewURLErr(known, uuid.UUID{}, entity.ErrNotFound)
+	errStorage := errors.New("connection refused")
+	type fields struct {
+		built       bool
+		negativeTTL time.Duration
+		wait        time.Duration
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		id        uuid.UUID
+		repoErr   error
+		wantLoads int
+		wantErr   error
+	}{
+		{
+			name:      "positive get url (known)",
+			fields:    fields{built: true, negativeTTL: time.Minute},
+			id:        known,
+			wantLoads: 2,
+		},
+		{
+			name:    "negative get url (rejected by filter)",
+			fields:  fields{built: true, negativeTTL: time.Minute},
+			id:      uuid.MustParse("5b0e2a8e-7d1c-4f3a-8f5e-2c6d9a1b7e40"),
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name:      "negative get url (negative cache)",
+			fields:    fields{negativeTTL: time.Minute},
+			id:        known,
+			repoErr:   notFound,
+			wantLoads: 1,
+			wantErr:   entity.ErrNotFound,
+		},
+		{
+			name:      "negative get url (negative cache expired)",
+			fields:    fields{negativeTTL: time.Millisecond, wait: 2 * time.Millisecond},
+			id:        known,
+			repoErr:   notFound,
+			wantLoads: 2,
+			wantErr:   entity.ErrNotFound,
+		},
+		{
+			name:      "negative get url (errors are not remembered)",
+			fields:    fields{negativeTTL: time.Minute},
+			id:        known,
+			repoErr:   errStorage,
+			wantLoads: 2,
+			wantErr:   errStorage,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		storage := repoMock.NewMockURL(ctl)
+		scanner := repoMock.NewMockIDScanner(ctl)
+		r := New(storage, scanner, testConfig{negativeSize: 10, negativeTTL: tt.fields.negativeTTL})
+
+		if tt.fields.built {
+			storage.EXPECT().GetURLCount(anyMock).Return(1, nil)
+			scanner.EXPECT().ScanIDs(anyMock, anyMock).DoAndReturn(scanIDs(known))
+			assert.NoError(t, r.Build(ctx))
+		}
+
+		item := entity.NewURL(tt.id, uuid.New())
+		storage.EXPECT().Get(anyMock, tt.id).DoAndReturn(func(context.Context, uuid.UUID) (entity.URL, error) {
+			if tt.repoErr != nil {
+				return nil, tt.repoErr
+			}
+			return item, nil
+		}).Times(tt.wantLoads)
+
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				got, err := r.Get(ctx, tt.id)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, item, got)
+				}
+				time.Sleep(tt.fields.wait)
+			}
+		})
+	}
+}
+
+func Test_repo_create(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		create func(r *repo, storage *repoMock.MockURL, id uuid.UUID)
+	}{
+		{
+			name: "positive add url",
+			create: func(r *repo, storage *repoMock.MockURL, id uuid.UUID) {
+				u := entity.NewURL(id, uuid.New())
+				storage.EXPECT().Add(anyMock, u).Return(nil)
+				assert.NoError(t, r.Add(ctx, u))
+			},
+		},
+		{
+			name: "positive batch urls",
+			create: func(r *repo, storage *repoMock.MockURL, id uuid.UUID) {
+				urls := []entity.URL{entity.NewURL(id, uuid.New())}
+				storage.EXPECT().Batch(anyMock, urls).Return(nil)
+				assert.NoError(t, r.Batch(ctx, urls))
+			},
+		},
+		{
+			name: "positive created by another replica",
+			create: func(r *repo, _ *repoMock.MockURL, id uuid.UUID) {
+				r.Evict([]uuid.UUID{id})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		storage := repoMock.NewMockURL(ctl)
+		scanner := repoMock.NewMockIDScanner(ctl)
+		r := New(storage, scanner, testConfig{negativeSize: 10, negativeTTL: time.Minute})
+
+		storage.EXPECT().GetURLCount(anyMock).Return(0, nil)
+		scanner.EXPECT().ScanIDs(anyMock, anyMock).DoAndReturn(scanIDs())
+		assert.NoError(t, r.Build(ctx))
+
+		id := uuid.New()
+		item := entity.NewURL(id, uuid.New())
+		storage.EXPECT().Get(anyMock, id).Return(item, nil)
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Get(ctx, id)
+			assert.ErrorIs(t, err, entity.ErrNotFound)
+
+			tt.create(r, storage, id)
+
+			got, err := r.Get(ctx, id)
+			assert.NoError(t, err)
+			assert.Equal(t, item, got)
+		})
+	}
+}
+
+func Test_repo_Evict_negative(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	storage := repoMock.NewMockURL(ctl)
+	r := New(storage, repoMock.NewMockIDScanner(ctl), testConfig{negativeSize: 10, negativeTTL: time.Minute})
+
+	id := uuid.New()
+	item := entity.NewURL(id, uuid.New())
+	gomock.InOrder(
+		storage.EXPECT().Get(anyMock, id).Return(nil, entity.NewURLErr(id, uuid.UUID{}, entity.ErrNotFound)),
+		storage.EXPECT().Get(anyMock, id).Return(item, nil),
+	)
+
+	_, err := r.Get(ctx, id)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+	_, err = r.Get(ctx, id)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+
+	r.Evict([]uuid.UUID{id})
+
+	got, err := r.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, item, got)
+}
+
+func Test_repo_remember_bounded(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	r := New(repoMock.NewMockURL(ctl), repoMock.NewMockIDScanner(ctl), testConfig{negativeSize: 3, negativeTTL: time.Minute})
+	for i := 0; i < 10; i++ {
+		r.remember(r.generation, uuid.New())
+	}
+	assert.Len(t, r.negative, 3)
+
+	generation := r.generation
+	r.Evict(nil)
+	id := uuid.New()
+	r.remember(generation, id)
+	assert.NotContains(t, r.negative, id, "lookup started before invalidation is remembered")
+}
+
+func Test_repo_Flush(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	storage := repoMock.NewMockURL(ctl)
+	scanner := repoMock.NewMockIDScanner(ctl)
+	r := New(storage, scanner, testConfig{negativeSize: 10, negativeTTL: time.Minute})
+
+	missed := uuid.New()
+	storage.EXPECT().GetURLCount(anyMock).Return(0, nil)
+	scanner.EXPECT().ScanIDs(anyMock, anyMock).DoAndReturn(scanIDs())
+	assert.NoError(t, r.Build(ctx))
+
+	_, err := r.Get(ctx, missed)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+
+	built := make(chan struct{})
+	storage.EXPECT().GetURLCount(anyMock).Return(1, nil)
+	scanner.EXPECT().ScanIDs(anyMock, anyMock).DoAndReturn(
+		func(ctx context.Context, fn func(uuid.UUID) error) error {
+			defer close(built)
+			return scanIDs(missed)(ctx, fn)
+		})
+	storage.EXPECT().Get(anyMock, missed).Return(entity.NewURL(missed, uuid.New()), nil).MinTimes(1)
+
+	r.Flush()
+	<-built
+	assert.Eventually(t, func() bool {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		return r.ready != nil
+	}, time.Second, time.Millisecond)
+
+	_, err = r.Get(ctx, missed)
+	assert.NoError(t, err)
+}
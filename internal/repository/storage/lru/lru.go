@@ -0,0 +1,257 @@
+// Package lru implements the read-through cache of short URLs decorating the storage,
+// the least recently used short URLs are dropped when the cache is full.
+package lru
+
+import (
+	"container/list"
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/task"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// loadTimeout defines the time limit of loading the short URL shared by the concurrent misses.
+const loadTimeout = 10 * time.Second
+
+// metrics implements the published counters of the cache hits, misses and evictions.
+var metrics = expvar.NewMap("url_cache")
+
+type (
+	repo struct {
+		storage.URL
+		mu    sync.Mutex
+		items map[uuid.UUID]*list.Element
+		order *list.List
+		// generation defines the number of invalidations, loads started before an invalidation are not cached.
+		generation uint64
+		size       int
+		ttl        time.Duration
+		group      singleflight.Group
+	}
+
+	// detached implements the context keeping the values of the parent without its cancellation and deadline.
+	detached struct {
+		parent context.Context
+	}
+
+	// entry implements the cached short URL.
+	entry struct {
+		url     entity.URL
+		expires time.Time
+	}
+)
+
+// Get implements getting the short URL from the cache, the concurrent misses of the short URL
+// are loaded from the storage once. The load is not canceled with the caller that started it,
+// every caller stops waiting for it when its own context is done.
+func (r *repo) Get(ctx context.Context, id uuid.UUID) (entity.URL, error) {
+	if u, ok := r.lookup(id); ok {
+		metrics.Add("hits", 1)
+		return u, nil
+	}
+	metrics.Add("misses", 1)
+
+	ch := r.group.DoChan(id.String(), func() (interface{}, error) {
+		r.mu.Lock()
+		generation := r.generation
+		r.mu.Unlock()
+
+		loadCtx, cancel := context.WithTimeout(detached{ctx}, loadTimeout)
+		defer cancel()
+
+		u, err := r.URL.Get(loadCtx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		r.store(generation, u)
+		return u, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return clone(res.Val.(entity.URL)), nil
+	}
+}
+
+// Deadline implements the context without the deadline.
+func (detached) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done implements the context that is never canceled.
+func (detached) Done() <-chan struct{} {
+	return nil
+}
+
+// Err implements the context that is never canceled.
+func (detached) Err() error {
+	return nil
+}
+
+// Value implements getting the value of the parent context.
+func (d detached) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
+
+// Update implements changing the short URL in the storage and evicting it from the cache.
+func (r *repo) Update(ctx context.Context, item entity.URL) error {
+	defer r.Evict([]uuid.UUID{item.ID()})
+	return r.URL.Update(ctx, item)
+}
+
+// BatchDelete implements the deletion of short URLs in the storage and evicting them from the cache.
+func (r *repo) BatchDelete(ctx context.Context, urls []entity.URL) (map[uuid.UUID]task.Outcome, error) {
+	ids := make([]uuid.UUID, len(urls))
+	for idx, u := range urls {
+		ids[idx] = u.ID()
+	}
+
+	defer r.Evict(ids)
+	return r.URL.BatchDelete(ctx, urls)
+}
+
+// ChangeOwner implements moving short URLs to another user ID in the storage
+// and evicting the short URLs of the previous owner from the cache.
+func (r *repo) ChangeOwner(ctx context.Context, from, to uuid.UUID) error {
+	defer func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.generation++
+		for id, el := range r.items {
+			if el.Value.(*entry).url.UserID() == from {
+				r.remove(id, el)
+			}
+		}
+	}()
+	return r.URL.ChangeOwner(ctx, from, to)
+}
+
+// SetDisabled implements the setting of the moderation attribute in the storage and evicting
+// the short URL from the cache.
+func (r *repo) SetDisabled(ctx context.Context, id uuid.UUID, disabled bool) error {
+	defer r.Evict([]uuid.UUID{id})
+	return r.URL.SetDisabled(ctx, id, disabled)
+}
+
+// ForceDelete implements the removal of short URLs from the storage and evicting them from the cache.
+func (r *repo) ForceDelete(ctx context.Context, ids []uuid.UUID) error {
+	defer r.Evict(ids)
+	return r.URL.ForceDelete(ctx, ids)
+}
+
+// Evict implements dropping the short URLs from the cache.
+func (r *repo) Evict(ids []uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	for _, id := range ids {
+		if el, ok := r.items[id]; ok {
+			r.remove(id, el)
+		}
+	}
+}
+
+// Flush implements dropping every short URL from the cache.
+func (r *repo) Flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	r.items = make(map[uuid.UUID]*list.Element, r.size)
+	r.order.Init()
+}
+
+// lookup implements getting the copy of the cached short URL that has not expired.
+func (r *repo) lookup(id uuid.UUID) (entity.URL, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	el, ok := r.items[id]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*entry)
+	if time.Now().After(e.expires) {
+		r.remove(id, el)
+		return nil, false
+	}
+
+	r.order.MoveToFront(el)
+	return clone(e.url), true
+}
+
+// store implements caching the copy of the loaded short URL unless it was invalidated during the load,
+// the least recently used short URL is dropped when the cache is full.
+func (r *repo) store(generation uint64, u entity.URL) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if generation != r.generation {
+		return
+	}
+
+	e := &entry{url: clone(u), expires: time.Now().Add(r.ttl)}
+	if el, ok := r.items[u.ID()]; ok {
+		el.Value = e
+		r.order.MoveToFront(el)
+		return
+	}
+
+	r.items[u.ID()] = r.order.PushFront(e)
+	if r.order.Len() > r.size {
+		oldest := r.order.Back()
+		r.remove(oldest.Value.(*entry).url.ID(), oldest)
+	}
+}
+
+// remove implements dropping the cached short URL, the lock is held by the caller.
+func (r *repo) remove(id uuid.UUID, el *list.Element) {
+	r.order.Remove(el)
+	delete(r.items, id)
+	metrics.Add("evictions", 1)
+}
+
+// clone implements copying the short URL, so callers changing it do not change the cached one.
+func clone(u entity.URL) entity.URL {
+	c := entity.NewURL(u.ID(), u.UserID())
+	c.SetWorkspaceID(u.WorkspaceID())
+	c.SetLongURL(u.LongValue())
+	c.SetShortURL(u.ShortValue())
+	c.SetCorrelationID(u.CorrelationID())
+	c.SetDeleted(u.Deleted())
+	c.SetDisabled(u.Disabled())
+	return c
+}
+
+// New implements the creation of the storage decorator caching the short URLs read by ID.
+func New(s storage.URL, cfg config.URLCache) *repo {
+	size := cfg.GetSize()
+	if size <= 0 {
+		size = 1
+	}
+
+	return &repo{
+		URL:   s,
+		items: make(map[uuid.UUID]*list.Element, size),
+		order: list.New(),
+		size:  size,
+		ttl:   cfg.GetTTL(),
+	}
+}
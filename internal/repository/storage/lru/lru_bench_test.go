@@ -0,0 +1,101 @@
+package lru
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// benchLatency defines the simulated round trip to the storage.
+const benchLatency = 200 * time.Microsecond
+
+// remoteStorage implements the storage answering reads after the network round trip.
+type remoteStorage struct {
+	storage.URL
+	urls map[uuid.UUID]entity.URL
+}
+
+func (s *remoteStorage) Get(_ context.Context, id uuid.UUID) (entity.URL, error) {
+	time.Sleep(benchLatency)
+	return s.urls[id], nil
+}
+
+func newRemoteStorage(n int) (*remoteStorage, []uuid.UUID) {
+	s := &remoteStorage{urls: make(map[uuid.UUID]entity.URL, n)}
+	ids := make([]uuid.UUID, n)
+	for idx := range ids {
+		u := newURL("https://example.com")
+		s.urls[u.ID()] = u
+		ids[idx] = u.ID()
+	}
+	return s, ids
+}
+
+func Benchmark_Get(b *testing.B) {
+	ctx := context.Background()
+	remote, ids := newRemoteStorage(100)
+	benchmarks := []struct {
+		name string
+		repo storage.URL
+	}{
+		{name: "storage", repo: remote},
+		{name: "lru", repo: New(remote, testConfig{size: len(ids), ttl: time.Minute})},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			warm(ctx, b, bm.repo, ids)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := bm.repo.Get(ctx, ids[i%len(ids)]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func Benchmark_Get_parallel(b *testing.B) {
+	ctx := context.Background()
+	remote, ids := newRemoteStorage(100)
+	benchmarks := []struct {
+		name string
+		repo storage.URL
+	}{
+		{name: "storage", repo: remote},
+		{name: "lru", repo: New(remote, testConfig{size: len(ids), ttl: time.Minute})},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			warm(ctx, b, bm.repo, ids)
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if _, err := bm.repo.Get(ctx, ids[i%len(ids)]); err != nil {
+						b.Fatal(err)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+// warm implements reading every short URL once, so the cache serves the benchmark reads.
+func warm(ctx context.Context, b *testing.B, repo storage.URL, ids []uuid.UUID) {
+	b.Helper()
+	for _, id := range ids {
+		if _, err := repo.Get(ctx, id); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+// testConfig implements read-through short URLs cache configuration.
+type testConfig struct {
+	size int
+	ttl  time.Duration
+}
+
+func (c testConfig) GetSize() int          { return c.size }
+func (c testConfig) GetTTL() time.Duration { return c.ttl }
+
+func newURL(rawURL string) entity.URL {
+	longURL, _ := url.Parse(rawURL)
+	u := entity.NewURL(uuid.New(), uuid.New())
+	u.SetLongURL(*longURL)
+	return u
+}
+
+func Test_repo_Get(t *testing.T) {
+	errStorage := errors.New("connection refused")
+	type fields struct {
+		size int
+		ttl  time.Duration
+		wait time.Duration
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		repoErr   error
+		wantLoads int
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "positive get url (cached)",
+			fields:    fields{size: 10, ttl: time.Minute},
+			wantLoads: 1,
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "positive get url (expired)",
+			fields:    fields{size: 10, ttl: time.Millisecond, wait: 2 * time.Millisecond},
+			wantLoads: 2,
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "negative get url (errors are not cached)",
+			fields:    fields{size: 10, ttl: time.Minute},
+			repoErr:   errStorage,
+			wantLoads: 2,
+			wantErr:   assert.Error,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		storage := repoMock.NewMockURL(ctl)
+		r := New(storage, testConfig{size: tt.fields.size, ttl: tt.fields.ttl})
+		item := newURL("https://example.com")
+
+		storage.EXPECT().Get(anyMock, item.ID()).DoAndReturn(func(context.Context, uuid.UUID) (entity.URL, error) {
+			if tt.repoErr != nil {
+				return nil, tt.repoErr
+			}
+			return item, nil
+		}).Times(tt.wantLoads)
+
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				got, err := r.Get(ctx, item.ID())
+				if !tt.wantErr(t, err, "Get(%s)", item.ID()) {
+					return
+				}
+				if err == nil {
+					assert.Equal(t, item.LongURL(), got.LongURL())
+				}
+				time.Sleep(tt.fields.wait)
+			}
+		})
+	}
+}
+
+func Test_repo_Get_lru(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	storage := repoMock.NewMockURL(ctl)
+	r := New(storage, testConfig{size: 2, ttl: time.Minute})
+
+	first, second, third := newURL("https://a.example.com"), newURL("https://b.example.com"),
+		newURL("https://c.example.com")
+	for _, u := range []entity.URL{first, second, third} {
+		storage.EXPECT().Get(anyMock, u.ID()).Return(u, nil)
+	}
+
+	for _, u := range []entity.URL{first, second, first, third} {
+		_, err := r.Get(ctx, u.ID())
+		assert.NoError(t, err)
+	}
+
+	// the second url is the least recently used one
+	_, ok := r.lookup(second.ID())
+	assert.False(t, ok)
+	_, ok = r.lookup(first.ID())
+	assert.True(t, ok)
+	_, ok = r.lookup(third.ID())
+	assert.True(t, ok)
+}
+
+func Test_repo_Get_singleflight(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	storage := repoMock.NewMockURL(ctl)
+	r := New(storage, testConfig{size: 10, ttl: time.Minute})
+	item := newURL("https://example.com")
+
+	release := make(chan struct{})
+	storage.EXPECT().Get(anyMock, item.ID()).DoAndReturn(func(context.Context, uuid.UUID) (entity.URL, error) {
+		<-release
+		return item, nil
+	}).Times(1)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := r.Get(context.Background(), item.ID())
+			assert.NoError(t, err)
+			assert.Equal(t, item.ID(), got.ID())
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func Test_repo_Get_canceled(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	type ctxKey struct{}
+	storage := repoMock.NewMockURL(ctl)
+	r := New(storage, testConfig{size: 10, ttl: time.Minute})
+	item := newURL("https://example.com")
+
+	started, release := make(chan struct{}), make(chan struct{})
+	storage.EXPECT().Get(anyMock, item.ID()).DoAndReturn(func(ctx context.Context, _ uuid.UUID) (entity.URL, error) {
+		close(started)
+		<-release
+		// the load keeps the values of the first caller and outlives its cancellation
+		assert.Equal(t, "value", ctx.Value(ctxKey{}))
+		assert.NoError(t, ctx.Err())
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		return item, nil
+	}).Times(1)
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := r.Get(ctx, item.ID())
+		canceled <- err
+	}()
+	<-started
+
+	waiting := make(chan error, 1)
+	go func() {
+		got, err := r.Get(context.Background(), item.ID())
+		if err == nil {
+			assert.Equal(t, item.ID(), got.ID())
+		}
+		waiting <- err
+	}()
+
+	// the canceled caller stops waiting while the load goes on
+	cancel()
+	select {
+	case err := <-canceled:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("canceled caller is still waiting")
+	}
+
+	close(release)
+	assert.NoError(t, <-waiting)
+	_, ok := r.lookup(item.ID())
+	assert.True(t, ok)
+}
+
+func Test_repo_invalidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(r *repo, storage *repoMock.MockURL, item entity.URL) error
+	}{
+		{
+			name: "positive evict url (update)",
+			mutate: func(r *repo, storage *repoMock.MockURL, item entity.URL) error {
+				storage.EXPECT().Update(gomock.Any(), item).Return(nil)
+				return r.Update(context.Background(), item)
+			},
+		},
+		{
+			name: "positive evict url (batch delete)",
+			mutate: func(r *repo, storage *repoMock.MockURL, item entity.URL) error {
+				storage.EXPECT().BatchDelete(gomock.Any(), gomock.Any()).Return(nil, nil)
+				_, err := r.BatchDelete(context.Background(), []entity.URL{item})
+				return err
+			},
+		},
+		{
+			name: "positive evict url (disable)",
+			mutate: func(r *repo, storage *repoMock.MockURL, item entity.URL) error {
+				storage.EXPECT().SetDisabled(gomock.Any(), item.ID(), true).Return(nil)
+				return r.SetDisabled(context.Background(), item.ID(), true)
+			},
+		},
+		{
+			name: "positive evict url (force delete)",
+			mutate: func(r *repo, storage *repoMock.MockURL, item entity.URL) error {
+				storage.EXPECT().ForceDelete(gomock.Any(), []uuid.UUID{item.ID()}).Return(nil)
+				return r.ForceDelete(context.Background(), []uuid.UUID{item.ID()})
+			},
+		},
+		{
+			name: "positive evict url (change owner)",
+			mutate: func(r *repo, storage *repoMock.MockURL, item entity.URL) error {
+				storage.EXPECT().ChangeOwner(gomock.Any(), item.UserID(), gomock.Any()).Return(nil)
+				return r.ChangeOwner(context.Background(), item.UserID(), uuid.New())
+			},
+		},
+		{
+			name: "positive evict url (invalidated by another replica)",
+			mutate: func(r *repo, _ *repoMock.MockURL, item entity.URL) error {
+				r.Evict([]uuid.UUID{item.ID()})
+				return nil
+			},
+		},
+		{
+			name: "positive evict url (flush)",
+			mutate: func(r *repo, _ *repoMock.MockURL, _ entity.URL) error {
+				r.Flush()
+				return nil
+			},
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		storage := repoMock.NewMockURL(ctl)
+		r := New(storage, testConfig{size: 10, ttl: time.Minute})
+		item := newURL("https://example.com")
+		storage.EXPECT().Get(anyMock, item.ID()).Return(item, nil)
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Get(ctx, item.ID())
+			assert.NoError(t, err)
+			_, ok := r.lookup(item.ID())
+			assert.True(t, ok)
+
+			assert.NoError(t, tt.mutate(r, storage, item))
+			_, ok = r.lookup(item.ID())
+			assert.False(t, ok)
+		})
+	}
+}
+
+func Test_repo_Get_invalidatedLoad(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	storage := repoMock.NewMockURL(ctl)
+	r := New(storage, testConfig{size: 10, ttl: time.Minute})
+	item := newURL("https://example.com")
+
+	// the url changed while it was loaded is not cached, so the stale value is not served
+	storage.EXPECT().Get(anyMock, item.ID()).DoAndReturn(func(context.Context, uuid.UUID) (entity.URL, error) {
+		r.Evict([]uuid.UUID{item.ID()})
+		return item, nil
+	})
+
+	_, err := r.Get(context.Background(), item.ID())
+	assert.NoError(t, err)
+	_, ok := r.lookup(item.ID())
+	assert.False(t, ok)
+}
+
+func Test_repo_Get_copy(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	storage := repoMock.NewMockURL(ctl)
+	r := New(storage, testConfig{size: 10, ttl: time.Minute})
+	item := newURL("https://example.com")
+	storage.EXPECT().Get(anyMock, item.ID()).Return(item, nil)
+
+	got, err := r.Get(context.Background(), item.ID())
+	assert.NoError(t, err)
+	got.SetDeleted(true)
+
+	got, err = r.Get(context.Background(), item.ID())
+	assert.NoError(t, err)
+	assert.False(t, got.Deleted())
+}
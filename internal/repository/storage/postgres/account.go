@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/account"
+)
+
+// AddAccount implements saving registered user account.
+func (r *repo) AddAccount(ctx context.Context, item account.Account) error {
+	var pgErr *pgconn.PgError
+
+	query := "INSERT INTO accounts (id, email, password_hash, created_at) VALUES ($1, $2, $3, $4)"
+	_, err := r.pool.Exec(ctx, query, item.ID(), item.Email(), item.PasswordHash(), item.CreatedAt())
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return account.ErrAlreadyExist
+		default:
+			r.logger.Error("postgres error", err, slog.String("code", pgErr.Code))
+			return err
+		}
+	}
+
+	return err
+}
+
+// GetAccount implements getting registered user account by email.
+func (r *repo) GetAccount(ctx context.Context, email string) (account.Account, error) {
+	var (
+		id           uuid.UUID
+		passwordHash []byte
+		createdAt    time.Time
+	)
+
+	query := "SELECT id, password_hash, created_at FROM accounts WHERE email = $1"
+	err := r.pool.QueryRow(ctx, query, email).Scan(&id, &passwordHash, &createdAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, account.ErrNotFound
+		}
+		return nil, err
+	}
+
+	a := account.NewAccount(id, email)
+	a.SetPasswordHash(passwordHash)
+	a.SetCreatedAt(createdAt)
+	return a, nil
+}
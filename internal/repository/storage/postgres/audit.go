@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/audit"
+)
+
+// AddEvents implements appending audit log events.
+func (r *repo) AddEvents(ctx context.Context, events []audit.Event) error {
+	batch := new(pgx.Batch)
+	query := `INSERT INTO audit_events (id, action, user_id, target_id, transport, actor, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	for _, item := range events {
+		batch.Queue(query, item.ID(), string(item.Action()), item.UserID(), item.TargetID(),
+			string(item.Transport()), item.Actor(), item.CreatedAt())
+	}
+
+	results := r.pool.SendBatch(ctx, batch)
+	defer results.Close()
+	for range events {
+		if _, err := results.Exec(); err != nil {
+			r.logger.Error("failed add audit event", err, slog.String("func", "AddEvents"))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetEvents implements getting audit log events matching the filter ordered by creation time.
+func (r *repo) GetEvents(ctx context.Context, filter audit.Filter) ([]audit.Event, error) {
+	events := make([]audit.Event, 0)
+
+	var (
+		filterUserID *uuid.UUID
+		from, to     *time.Time
+		limit        *int
+	)
+	if filter.UserID != uuid.Nil {
+		filterUserID = &filter.UserID
+	}
+	if !filter.From.IsZero() {
+		from = &filter.From
+	}
+	if !filter.To.IsZero() {
+		to = &filter.To
+	}
+	if filter.Limit > 0 {
+		limit = &filter.Limit
+	}
+
+	query := `SELECT id, action, user_id, target_id, transport, actor, created_at FROM audit_events
+		WHERE ($1::uuid IS NULL OR user_id = $1) AND ($2::timestamptz IS NULL OR created_at >= $2)
+		AND ($3::timestamptz IS NULL OR created_at < $3) ORDER BY created_at, id LIMIT $4 OFFSET $5`
+	rows, err := r.pool.Query(ctx, query, filterUserID, from, to, limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id        uuid.UUID
+			action    string
+			userID    uuid.UUID
+			targetID  uuid.UUID
+			transport string
+			actor     string
+			createdAt time.Time
+		)
+		if err = rows.Scan(&id, &action, &userID, &targetID, &transport, &actor, &createdAt); err != nil {
+			return nil, err
+		}
+
+		e := audit.NewEvent(id, audit.Action(action), userID, targetID)
+		e.SetTransport(audit.Transport(transport))
+		e.SetActor(actor)
+		e.SetCreatedAt(createdAt)
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
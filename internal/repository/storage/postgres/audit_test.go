@@ -0,0 +1,101 @@
+//go:build postgres
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/audit"
+)
+
+func newTestEvent(action audit.Action, userID uuid.UUID, createdAt time.Time) audit.Event {
+	e := audit.NewEvent(uuid.New(), action, userID, uuid.New())
+	e.SetTransport(audit.TransportHTTP)
+	e.SetActor("192.168.88.1")
+	e.SetCreatedAt(createdAt)
+	return e
+}
+
+func Test_repo_GetEvents(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	var (
+		userID = uuid.New()
+		now    = time.Now().UTC().Truncate(time.Millisecond)
+		events = []audit.Event{
+			newTestEvent(audit.ActionCreate, userID, now.Add(-2*time.Hour)),
+			newTestEvent(audit.ActionUpdate, userID, now.Add(-time.Hour)),
+			newTestEvent(audit.ActionDelete, userID, now),
+		}
+	)
+	assert.NoError(t, r.AddEvents(ctx, events))
+	assert.NoError(t, r.AddEvents(ctx, []audit.Event{newTestEvent(audit.ActionCreate, uuid.New(), now)}))
+
+	tests := []struct {
+		name   string
+		filter audit.Filter
+		want   []audit.Event
+	}{
+		{
+			name:   "positive get events (user)",
+			filter: audit.Filter{UserID: userID},
+			want:   events,
+		},
+		{
+			name:   "positive get events (period)",
+			filter: audit.Filter{UserID: userID, From: now.Add(-time.Hour), To: now},
+			want:   events[1:2],
+		},
+		{
+			name:   "positive get events (limit and offset)",
+			filter: audit.Filter{UserID: userID, Limit: 1, Offset: 2},
+			want:   events[2:],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.GetEvents(ctx, tt.filter)
+			assert.NoError(t, err)
+			assert.Len(t, got, len(tt.want))
+			for idx, e := range got {
+				assert.Equal(t, tt.want[idx].ID(), e.ID())
+				assert.Equal(t, tt.want[idx].Action(), e.Action())
+				assert.Equal(t, tt.want[idx].TargetID(), e.TargetID())
+				assert.Equal(t, audit.TransportHTTP, e.Transport())
+				assert.Equal(t, "192.168.88.1", e.Actor())
+				assert.True(t, tt.want[idx].CreatedAt().Equal(e.CreatedAt()))
+			}
+		})
+	}
+}
+
+func Test_repo_AddEvents_appendOnly(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	userID := uuid.New()
+	e := newTestEvent(audit.ActionBan, userID, time.Now())
+	assert.NoError(t, r.AddEvents(ctx, []audit.Event{e}))
+
+	// the rules of the table turn the changes of the events into no-op statements
+	tag, err := r.pool.Exec(ctx, "UPDATE audit_events SET action = $2 WHERE id = $1", e.ID(),
+		string(audit.ActionUnban))
+	assert.NoError(t, err)
+	assert.Zero(t, tag.RowsAffected())
+
+	tag, err = r.pool.Exec(ctx, "DELETE FROM audit_events WHERE id = $1", e.ID())
+	assert.NoError(t, err)
+	assert.Zero(t, tag.RowsAffected())
+
+	got, err := r.GetEvents(ctx, audit.Filter{UserID: userID})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, audit.ActionBan, got[0].Action())
+}
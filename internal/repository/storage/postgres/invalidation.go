@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"golang.org/x/exp/slog"
+)
+
+const (
+	// invalidationChannel defines the notification channel of the changed short URLs.
+	invalidationChannel = "url_invalidation"
+	// invalidationChunk defines the number of short URL IDs in one notification,
+	// the notification payload is limited to 8000 bytes.
+	invalidationChunk = 200
+	// listenBackoff defines the delay before the first reconnection of the listener, it doubles with every failure.
+	listenBackoff = time.Second
+	// listenMaxBackoff defines the maximum delay between reconnections of the listener.
+	listenMaxBackoff = 30 * time.Second
+)
+
+// execer describes the pool or the transaction executing the statements.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+}
+
+// notifyInvalidation implements publishing the IDs of the created or changed short URLs to the listening replicas,
+// notifications sent in the transaction are delivered when it commits.
+func notifyInvalidation(ctx context.Context, db execer, ids ...uuid.UUID) error {
+	for start := 0; start < len(ids); start += invalidationChunk {
+		end := start + invalidationChunk
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		payload := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			payload = append(payload, id.String())
+		}
+
+		_, err := db.Exec(ctx, "SELECT pg_notify($1, $2)", invalidationChannel, strings.Join(payload, ","))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidate implements publishing the IDs of the short URLs changed outside the transaction,
+// the failure is logged only since the change is applied already.
+func (r *repo) invalidate(ctx context.Context, fn string, ids ...uuid.UUID) {
+	if err := notifyInvalidation(ctx, r.pool, ids...); err != nil {
+		r.logger.Error("failed notify url invalidation", err, slog.String("func", fn))
+	}
+}
+
+// ListenInvalidations implements passing the IDs of the short URLs created or changed by any replica to evict
+// until the context is done. The dropped connection is restored with exponential backoff,
+// flush is called after the reconnection, since notifications sent meanwhile are lost.
+func (r *repo) ListenInvalidations(ctx context.Context, evict func(ids []uuid.UUID), flush func()) error {
+	delay := listenBackoff
+	reconnect := false
+	for {
+		connected, err := r.listen(ctx, evict, flush, reconnect)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if connected {
+			delay = listenBackoff
+			reconnect = true
+		}
+
+		r.logger.Error("invalidation listener disconnected", err, slog.Duration("retry", delay),
+			slog.String("func", "ListenInvalidations"))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > listenMaxBackoff {
+			delay = listenMaxBackoff
+		}
+	}
+}
+
+// listen implements listening the invalidation channel on the dedicated connection until it fails,
+// connected reports the channel was listened.
+func (r *repo) listen(ctx context.Context, evict func(ids []uuid.UUID), flush func(), reconnect bool,
+) (connected bool, err error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, "LISTEN "+invalidationChannel); err != nil {
+		_ = conn.Conn().Close(context.Background())
+		return false, err
+	}
+
+	if reconnect {
+		flush()
+	}
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			// the session still listens the channel, so it is not returned to the pool
+			_ = conn.Conn().Close(context.Background())
+			return true, err
+		}
+
+		ids := make([]uuid.UUID, 0, invalidationChunk)
+		for _, value := range strings.Split(n.Payload, ",") {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				r.logger.Error("invalid invalidation payload", err, slog.String("func", "listen"))
+				continue
+			}
+			ids = append(ids, id)
+		}
+
+		if len(ids) > 0 {
+			evict(ids)
+		}
+	}
+}
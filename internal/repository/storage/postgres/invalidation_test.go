@@ -0,0 +1,91 @@
+//go:build postgres
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitEvicted implements changing the short URL until its ID is evicted by the listener,
+// the listener misses the changes made before it listens the channel.
+func waitEvicted(t *testing.T, r *repo, id uuid.UUID, evicted <-chan uuid.UUID) bool {
+	ctx := context.Background()
+	timeout := time.After(10 * time.Second)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	disabled := false
+	for {
+		select {
+		case got := <-evicted:
+			if got == id {
+				return true
+			}
+		case <-ticker.C:
+			disabled = !disabled
+			assert.NoError(t, r.SetDisabled(ctx, id, disabled))
+		case <-timeout:
+			return false
+		}
+	}
+}
+
+func Test_repo_ListenInvalidations(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	listener, writer := newTestRepo(t), newTestRepo(t)
+
+	u := newTestURL(uuid.New(), uuid.New(), "https://example.com/invalidation")
+	assert.NoError(t, writer.Add(ctx, u))
+
+	evicted := make(chan uuid.UUID, 100)
+	flushed := make(chan struct{}, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- listener.ListenInvalidations(ctx,
+			func(ids []uuid.UUID) {
+				for _, id := range ids {
+					select {
+					case evicted <- id:
+					default:
+					}
+				}
+			},
+			func() {
+				select {
+				case flushed <- struct{}{}:
+				default:
+				}
+			})
+	}()
+
+	assert.True(t, waitEvicted(t, writer, u.ID(), evicted))
+
+	// the dropped connection is restored and the cache is flushed, since the changes made meanwhile are missed
+	query := "SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE query = $1 AND pid <> pg_backend_pid()"
+	_, err := writer.pool.Exec(ctx, query, "LISTEN "+invalidationChannel)
+	assert.NoError(t, err)
+
+	select {
+	case <-flushed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("listener is not reconnected")
+	}
+	for len(evicted) > 0 {
+		<-evicted
+	}
+	assert.True(t, waitEvicted(t, writer, u.ID(), evicted))
+
+	cancel()
+	select {
+	case err = <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("listener is not stopped")
+	}
+}
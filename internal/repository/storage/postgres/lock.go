@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// advisoryLock implements the session level advisory lock held on the dedicated pool connection.
+type advisoryLock struct {
+	conn   *pgxpool.Conn
+	key    int64
+	logger *slog.Logger
+}
+
+// TryLock implements acquiring the session level advisory lock without waiting.
+// The connection holding the lock is taken from the pool until the lock is released.
+func (r *repo) TryLock(ctx context.Context, key int64) (storage.Lock, bool, error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		r.logger.Error("failed acquire connection", err, slog.String("func", "TryLock"))
+		return nil, false, err
+	}
+
+	var locked bool
+	err = conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked)
+	if err != nil {
+		r.logger.Error("failed try advisory lock", err, slog.String("func", "TryLock"))
+		conn.Release()
+		return nil, false, err
+	}
+
+	if !locked {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	return &advisoryLock{conn: conn, key: key, logger: r.logger}, true, nil
+}
+
+// Check implements checking the session holding the lock is alive.
+func (l *advisoryLock) Check(ctx context.Context) error {
+	return l.conn.Ping(ctx)
+}
+
+// Release implements releasing the lock and returning the connection to the pool.
+// The connection is closed when unlocking fails, so the lock is released with the session.
+func (l *advisoryLock) Release(ctx context.Context) error {
+	defer l.conn.Release()
+
+	_, err := l.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", l.key)
+	if err != nil {
+		l.logger.Error("failed advisory unlock", err, slog.String("func", "Release"))
+		_ = l.conn.Conn().Close(ctx)
+		return err
+	}
+	return nil
+}
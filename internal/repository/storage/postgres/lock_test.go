@@ -0,0 +1,67 @@
+//go:build postgres
+
+package postgres
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_repo_TryLock(t *testing.T) {
+	ctx := context.Background()
+	leader, replica := newTestRepo(t), newTestRepo(t)
+
+	// the key of the run, so the lock is not held by the concurrent runs
+	key := rand.New(rand.NewSource(time.Now().UnixNano())).Int63()
+
+	lock, ok, err := leader.TryLock(ctx, key)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, lock.Check(ctx))
+
+	// the lock held by the session of another replica is not acquired
+	_, ok, err = replica.TryLock(ctx, key)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, lock.Release(ctx))
+
+	lock, ok, err = replica.TryLock(ctx, key)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	defer func() {
+		_ = lock.Release(ctx)
+	}()
+
+	_, ok, err = leader.TryLock(ctx, key)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func Test_repo_TryLock_sessionLost(t *testing.T) {
+	ctx := context.Background()
+	leader, replica := newTestRepo(t), newTestRepo(t)
+	key := rand.New(rand.NewSource(time.Now().UnixNano())).Int63()
+
+	lock, ok, err := leader.TryLock(ctx, key)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// the lock is released with the session, the lost session fails the check
+	query := "SELECT pg_terminate_backend(pid) FROM pg_locks WHERE locktype = 'advisory' AND " +
+		"((classid::bigint << 32) | objid::bigint) = $1"
+	_, err = replica.pool.Exec(ctx, query, key)
+	assert.NoError(t, err)
+
+	assert.Error(t, lock.Check(ctx))
+	_ = lock.Release(ctx)
+
+	lock, ok, err = replica.TryLock(ctx, key)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, lock.Release(ctx))
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+// Search implements getting short URLs of all users matching the filter.
+func (r *repo) Search(ctx context.Context, filter entity.Filter) ([]entity.URL, error) {
+	urls := make([]entity.URL, 0)
+
+	var (
+		filterUserID *uuid.UUID
+		limit        *int
+	)
+	if filter.UserID != uuid.Nil {
+		filterUserID = &filter.UserID
+	}
+	if filter.Limit > 0 {
+		limit = &filter.Limit
+	}
+
+	query := `SELECT id, user_id, workspace_id, original_url, deleted, disabled FROM urls
+		WHERE strpos(original_url, $1) > 0 AND ($2::uuid IS NULL OR user_id = $2)
+		ORDER BY id LIMIT $3 OFFSET $4`
+	rows, err := r.pool.Query(ctx, query, filter.Query, filterUserID, limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id          uuid.UUID
+			userID      uuid.UUID
+			workspaceID uuid.UUID
+			rawURL      string
+			deleted     bool
+			disabled    bool
+		)
+		if err = rows.Scan(&id, &userID, &workspaceID, &rawURL, &deleted, &disabled); err != nil {
+			return nil, err
+		}
+
+		value, err := url.ParseRequestURI(rawURL)
+		if err != nil {
+			r.logger.Error("failed parse raw url", err, slog.String("func", "Search"),
+				slog.String("url", rawURL))
+			return nil, err
+		}
+
+		u := entity.NewURL(id, userID)
+		u.SetWorkspaceID(workspaceID)
+		u.SetLongURL(*value)
+		u.SetDeleted(deleted)
+		u.SetDisabled(disabled)
+		urls = append(urls, u)
+	}
+
+	return urls, rows.Err()
+}
+
+// SetDisabled implements the setting of the moderation attribute of the short URL.
+func (r *repo) SetDisabled(ctx context.Context, id uuid.UUID, disabled bool) error {
+	query := "UPDATE urls SET disabled = $2 WHERE id = $1"
+	tag, err := r.pool.Exec(ctx, query, id, disabled)
+	if err != nil {
+		r.logger.Error("failed update url", err, slog.String("func", "SetDisabled"))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.NewURLErr(id, uuid.UUID{}, entity.ErrNotFound)
+	}
+
+	r.invalidate(ctx, "SetDisabled", id)
+	return nil
+}
+
+// ForceDelete implements the removal of short URLs and their reports regardless of the owner.
+func (r *repo) ForceDelete(ctx context.Context, ids []uuid.UUID) error {
+	query := "DELETE FROM urls WHERE id = ANY($1)"
+	_, err := r.pool.Exec(ctx, query, ids)
+	if err != nil {
+		r.logger.Error("failed delete urls", err, slog.String("func", "ForceDelete"))
+		return err
+	}
+
+	r.invalidate(ctx, "ForceDelete", ids...)
+	return nil
+}
+
+// BanUser implements the setting of the user ban.
+func (r *repo) BanUser(ctx context.Context, userID uuid.UUID, banned bool) error {
+	query := "DELETE FROM banned_users WHERE user_id = $1"
+	if banned {
+		query = "INSERT INTO banned_users (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING"
+	}
+
+	_, err := r.pool.Exec(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("failed update user ban", err, slog.String("func", "BanUser"))
+		return err
+	}
+	return nil
+}
+
+// IsBanned implements checking the user ban.
+func (r *repo) IsBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var banned bool
+	query := "SELECT EXISTS (SELECT 1 FROM banned_users WHERE user_id = $1)"
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&banned)
+	if err != nil {
+		return false, err
+	}
+	return banned, nil
+}
@@ -0,0 +1,145 @@
+//go:build postgres
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/report"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+func Test_repo_Search(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	// the rows of the previous runs stay in the database, so the long URLs of every run are unique
+	token := uuid.NewString()
+	var (
+		userID  = uuid.New()
+		otherID = uuid.New()
+		first   = newTestURL(uuid.New(), userID, "https://example.com/"+token+"/1")
+		second  = newTestURL(uuid.New(), userID, "https://example.com/"+token+"/2")
+		foreign = newTestURL(uuid.New(), otherID, "https://example.com/"+token+"/3")
+	)
+	for _, u := range []entity.URL{first, second, foreign} {
+		assert.NoError(t, r.Add(ctx, u))
+	}
+
+	tests := []struct {
+		name   string
+		filter entity.Filter
+		want   int
+	}{
+		{
+			name:   "positive search urls (query)",
+			filter: entity.Filter{Query: token},
+			want:   3,
+		},
+		{
+			name:   "positive search urls (user)",
+			filter: entity.Filter{Query: token, UserID: userID},
+			want:   2,
+		},
+		{
+			name:   "positive search urls (limit and offset)",
+			filter: entity.Filter{Query: token, Limit: 2, Offset: 2},
+			want:   1,
+		},
+		{
+			name:   "positive search urls (no match)",
+			filter: entity.Filter{Query: uuid.NewString()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urls, err := r.Search(ctx, tt.filter)
+			assert.NoError(t, err)
+			assert.Len(t, urls, tt.want)
+			for _, u := range urls {
+				assert.Contains(t, u.LongURL(), token)
+				if tt.filter.UserID != uuid.Nil {
+					assert.Equal(t, tt.filter.UserID, u.UserID())
+				}
+			}
+		})
+	}
+}
+
+func Test_repo_SetDisabled(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	u := newTestURL(uuid.New(), uuid.New(), "https://example.com/disabled")
+	assert.NoError(t, r.Add(ctx, u))
+
+	assert.NoError(t, r.SetDisabled(ctx, u.ID(), true))
+	stored, err := r.Get(ctx, u.ID())
+	assert.NoError(t, err)
+	assert.True(t, stored.Disabled())
+
+	assert.NoError(t, r.SetDisabled(ctx, u.ID(), false))
+	stored, err = r.Get(ctx, u.ID())
+	assert.NoError(t, err)
+	assert.False(t, stored.Disabled())
+
+	assert.ErrorIs(t, r.SetDisabled(ctx, uuid.New(), true), entity.ErrNotFound)
+}
+
+func Test_repo_BanUser(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+	userID := uuid.New()
+
+	banned, err := r.IsBanned(ctx, userID)
+	assert.NoError(t, err)
+	assert.False(t, banned)
+
+	// the repeated ban is not an error
+	assert.NoError(t, r.BanUser(ctx, userID, true))
+	assert.NoError(t, r.BanUser(ctx, userID, true))
+	banned, err = r.IsBanned(ctx, userID)
+	assert.NoError(t, err)
+	assert.True(t, banned)
+
+	assert.NoError(t, r.BanUser(ctx, userID, false))
+	banned, err = r.IsBanned(ctx, userID)
+	assert.NoError(t, err)
+	assert.False(t, banned)
+}
+
+func Test_repo_ForceDelete(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	var (
+		removed = newTestURL(uuid.New(), uuid.New(), "https://example.com/removed")
+		kept    = newTestURL(uuid.New(), uuid.New(), "https://example.com/kept")
+	)
+	assert.NoError(t, r.Add(ctx, removed))
+	assert.NoError(t, r.Add(ctx, kept))
+
+	item := report.NewReport(uuid.New(), removed.ID(), uuid.New())
+	item.SetReason("spam")
+	item.SetCreatedAt(time.Now())
+	assert.NoError(t, r.AddReport(ctx, item))
+
+	// the short URL of any owner is removed with its reports, the missing IDs are ignored
+	assert.NoError(t, r.ForceDelete(ctx, []uuid.UUID{removed.ID(), uuid.New()}))
+
+	_, err := r.Get(ctx, removed.ID())
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+
+	count, err := r.CountReports(ctx, removed.ID())
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+
+	_, err = r.Get(ctx, kept.ID())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/outbox"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+// addOutbox implements writing the short URL events to the outbox in the transaction changing the short URLs.
+func (r *repo) addOutbox(ctx context.Context, tx pgx.Tx, t outbox.Type, urls ...entity.URL) error {
+	if len(urls) == 0 {
+		return nil
+	}
+
+	batch := new(pgx.Batch)
+	query := `INSERT INTO outbox (id, type, url_id, user_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	for _, item := range urls {
+		m, err := outbox.NewURLMessage(t, item)
+		if err != nil {
+			return err
+		}
+		batch.Queue(query, m.ID, string(m.Type), m.URLID, m.UserID, m.Payload, m.CreatedAt)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	defer results.Close()
+	for range urls {
+		if _, err := results.Exec(); err != nil {
+			r.logger.Error("failed add outbox message", err, slog.String("func", "addOutbox"))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DispatchOutbox implements passing the oldest undispatched outbox messages to the dispatch function
+// and marking them dispatched when it succeeds.
+// Selected rows stay locked until the dispatch ends, concurrent relays skip them.
+func (r *repo) DispatchOutbox(ctx context.Context, limit int,
+	dispatch func(ctx context.Context, messages []outbox.Message) error,
+) (int, error) {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	if err != nil {
+		return 0, err
+	}
+
+	query := `SELECT id, type, url_id, user_id, payload, created_at FROM outbox
+		WHERE dispatched_at IS NULL ORDER BY created_at LIMIT $1 FOR UPDATE SKIP LOCKED`
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	messages := make([]outbox.Message, 0, limit)
+	for rows.Next() {
+		var (
+			m   outbox.Message
+			typ string
+		)
+		if err = rows.Scan(&m.ID, &typ, &m.URLID, &m.UserID, &m.Payload, &m.CreatedAt); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		m.Type = outbox.Type(typ)
+		messages = append(messages, m)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	if err = dispatch(ctx, messages); err != nil {
+		return 0, err
+	}
+
+	ids := make([]uuid.UUID, len(messages))
+	for idx, m := range messages {
+		ids[idx] = m.ID
+	}
+
+	query = "UPDATE outbox SET dispatched_at = now() WHERE id = ANY($1)"
+	if _, err = tx.Exec(ctx, query, ids); err != nil {
+		r.logger.Error("failed mark outbox messages dispatched", err, slog.String("func", "DispatchOutbox"))
+		return 0, err
+	}
+
+	return len(messages), tx.Commit(ctx)
+}
+
+// PurgeOutbox implements deleting the messages dispatched before the time, the number of deleted messages is returned.
+func (r *repo) PurgeOutbox(ctx context.Context, before time.Time) (int, error) {
+	query := "DELETE FROM outbox WHERE dispatched_at < $1"
+	tag, err := r.pool.Exec(ctx, query, before)
+	if err != nil {
+		r.logger.Error("failed purge outbox", err, slog.String("func", "PurgeOutbox"))
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
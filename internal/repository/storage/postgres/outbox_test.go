@@ -0,0 +1,131 @@
+//go:build postgres
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/outbox"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+// testOutboxMessage describes the outbox row written for the short URL.
+type testOutboxMessage struct {
+	typ        outbox.Type
+	dispatched bool
+}
+
+func getOutbox(t *testing.T, r *repo, urlID uuid.UUID) []testOutboxMessage {
+	query := "SELECT type, dispatched_at IS NOT NULL FROM outbox WHERE url_id = $1 ORDER BY created_at"
+	rows, err := r.pool.Query(context.Background(), query, urlID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	messages := make([]testOutboxMessage, 0)
+	for rows.Next() {
+		var (
+			m   testOutboxMessage
+			typ string
+		)
+		if err = rows.Scan(&typ, &m.dispatched); err != nil {
+			t.Fatal(err)
+		}
+		m.typ = outbox.Type(typ)
+		messages = append(messages, m)
+	}
+	assert.NoError(t, rows.Err())
+	return messages
+}
+
+func Test_repo_Add_outbox(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	userID := uuid.New()
+	u := newTestURL(uuid.New(), userID, "https://example.com/outbox")
+	assert.NoError(t, r.Add(ctx, u))
+	assert.Equal(t, []testOutboxMessage{{typ: outbox.TypeURLCreated}}, getOutbox(t, r, u.ID()))
+
+	// the message is not written when the short URL is not saved
+	conflict := newTestURL(uuid.New(), userID, "https://example.com/outbox")
+	assert.ErrorIs(t, r.Add(ctx, conflict), entity.ErrAlreadyExist)
+	assert.Empty(t, getOutbox(t, r, conflict.ID()))
+
+	_, err := r.BatchDelete(ctx, []entity.URL{entity.NewURL(u.ID(), userID)})
+	assert.NoError(t, err)
+	assert.Equal(t, []testOutboxMessage{{typ: outbox.TypeURLCreated}, {typ: outbox.TypeURLDeleted}},
+		getOutbox(t, r, u.ID()))
+}
+
+func Test_repo_DispatchOutbox(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	u := newTestURL(uuid.New(), uuid.New(), "https://example.com/dispatch")
+	assert.NoError(t, r.Add(ctx, u))
+
+	// the messages stay undispatched when the dispatch fails
+	errDispatch := errors.New("any error")
+	_, err := r.DispatchOutbox(ctx, 100, func(context.Context, []outbox.Message) error {
+		return errDispatch
+	})
+	assert.ErrorIs(t, err, errDispatch)
+	assert.Equal(t, []testOutboxMessage{{typ: outbox.TypeURLCreated}}, getOutbox(t, r, u.ID()))
+
+	// the messages of the previous runs stay in the outbox, so it is drained
+	dispatched := make(map[uuid.UUID]int)
+	for {
+		n, err := r.DispatchOutbox(ctx, 100, func(_ context.Context, messages []outbox.Message) error {
+			for _, m := range messages {
+				dispatched[m.URLID]++
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		if err != nil || n == 0 {
+			break
+		}
+	}
+
+	assert.Equal(t, 1, dispatched[u.ID()])
+	assert.Equal(t, []testOutboxMessage{{typ: outbox.TypeURLCreated, dispatched: true}}, getOutbox(t, r, u.ID()))
+}
+
+func Test_repo_DispatchOutbox_skipLocked(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	userID := uuid.New()
+	for _, rawURL := range []string{"https://example.com/locked/1", "https://example.com/locked/2"} {
+		assert.NoError(t, r.Add(ctx, newTestURL(uuid.New(), userID, rawURL)))
+	}
+
+	// the concurrent relay skips the messages locked by the running dispatch
+	outer := make(map[uuid.UUID]bool)
+	inner := make(map[uuid.UUID]bool)
+	_, err := r.DispatchOutbox(ctx, 1000, func(ctx context.Context, messages []outbox.Message) error {
+		for _, m := range messages {
+			outer[m.ID] = true
+		}
+
+		_, err := r.DispatchOutbox(ctx, 1000, func(_ context.Context, messages []outbox.Message) error {
+			for _, m := range messages {
+				inner[m.ID] = true
+			}
+			return nil
+		})
+		return err
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, outer)
+	for id := range inner {
+		assert.False(t, outer[id])
+	}
+}
@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+)
+
+// taskColumns defines the selected columns of the deferred task.
+const taskColumns = "id, action, user_id, url_ids, status, attempts, last_error, outcomes, run_at, created_at"
+
+// AddTask implements saving the deferred task.
+func (r *repo) AddTask(ctx context.Context, item task.Task) error {
+	query := `INSERT INTO tasks (id, action, user_id, url_ids, status, attempts, run_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.pool.Exec(ctx, query, item.ID, string(item.Action), item.UserID, item.URLIDs,
+		string(item.Status), item.Attempts, item.RunAt, item.CreatedAt)
+	if err != nil {
+		r.logger.Error("failed add task", err, slog.String("func", "AddTask"))
+		return err
+	}
+	return nil
+}
+
+// ClaimTasks implements marking the due tasks of the action running until the lease ends.
+// Rows claimed by concurrent workers are skipped.
+func (r *repo) ClaimTasks(ctx context.Context, action task.Action, limit int, lease time.Duration) ([]task.Task, error) {
+	tasks := make([]task.Task, 0)
+
+	query := `UPDATE tasks SET status = $3, attempts = attempts + 1, run_at = $2
+		WHERE id IN (SELECT id FROM tasks WHERE action = $5 AND status IN ($4, $3) AND run_at <= now()
+		ORDER BY run_at LIMIT $1 FOR UPDATE SKIP LOCKED)
+		RETURNING ` + taskColumns
+	rows, err := r.pool.Query(ctx, query, limit, time.Now().Add(lease), string(task.StatusRunning),
+		string(task.StatusPending), string(action))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+
+	return tasks, rows.Err()
+}
+
+// GetTask implements getting the deferred task.
+func (r *repo) GetTask(ctx context.Context, id uuid.UUID) (task.Task, error) {
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = $1"
+	t, err := scanTask(r.pool.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return task.Task{}, task.ErrNotFound
+	}
+	return t, err
+}
+
+// CompleteTask implements marking the task of the claimed attempt processed with the outcomes for its short URLs.
+func (r *repo) CompleteTask(ctx context.Context, id uuid.UUID, attempt int,
+	outcomes map[uuid.UUID]task.Outcome,
+) error {
+	data, err := json.Marshal(outcomes)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE tasks SET status = $2, run_at = now(), last_error = '', outcomes = $3
+		WHERE id = $1 AND status = $4 AND attempts = $5`
+	tag, err := r.pool.Exec(ctx, query, id, string(task.StatusDone), data, string(task.StatusRunning), attempt)
+	if err != nil {
+		r.logger.Error("failed complete task", err, slog.String("func", "CompleteTask"),
+			slog.String("id", id.String()))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return r.checkClaim(ctx, id)
+	}
+	return nil
+}
+
+// RetryTask implements returning the failed task of the claimed attempt to the queue until the run time.
+func (r *repo) RetryTask(ctx context.Context, id uuid.UUID, attempt int, runAt time.Time, reason string) error {
+	return r.setTaskStatus(ctx, id, attempt, task.StatusPending, runAt, reason)
+}
+
+// FailTask implements moving the task of the claimed attempt whose attempts are exhausted to the dead state.
+func (r *repo) FailTask(ctx context.Context, id uuid.UUID, attempt int, reason string) error {
+	return r.setTaskStatus(ctx, id, attempt, task.StatusDead, time.Now(), reason)
+}
+
+// CountTasks implements getting the number of the tasks in the processing state.
+func (r *repo) CountTasks(ctx context.Context, status task.Status) (int, error) {
+	var count int
+	query := "SELECT count(*) FROM tasks WHERE status = $1"
+	err := r.pool.QueryRow(ctx, query, string(status)).Scan(&count)
+	if err != nil {
+		r.logger.Error("failed count tasks", err, slog.String("func", "CountTasks"))
+		return 0, err
+	}
+	return count, nil
+}
+
+// PurgeTasks implements deleting the tasks processed before the time, the number of deleted tasks is returned.
+func (r *repo) PurgeTasks(ctx context.Context, before time.Time) (int, error) {
+	query := "DELETE FROM tasks WHERE status = $1 AND run_at < $2"
+	tag, err := r.pool.Exec(ctx, query, string(task.StatusDone), before)
+	if err != nil {
+		r.logger.Error("failed purge tasks", err, slog.String("func", "PurgeTasks"))
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// setTaskStatus implements changing the processing state of the task of the claimed attempt.
+func (r *repo) setTaskStatus(ctx context.Context, id uuid.UUID, attempt int, status task.Status, runAt time.Time,
+	reason string,
+) error {
+	query := `UPDATE tasks SET status = $2, run_at = $3, last_error = $4
+		WHERE id = $1 AND status = $5 AND attempts = $6`
+	tag, err := r.pool.Exec(ctx, query, id, string(status), runAt, reason, string(task.StatusRunning), attempt)
+	if err != nil {
+		r.logger.Error("failed set task status", err, slog.String("func", "setTaskStatus"),
+			slog.String("id", id.String()))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return r.checkClaim(ctx, id)
+	}
+	return nil
+}
+
+// checkClaim implements getting the error of the task left unchanged by the claimed attempt,
+// task.ErrLeaseLost is returned when the task exists since it was claimed again.
+func (r *repo) checkClaim(ctx context.Context, id uuid.UUID) error {
+	var exists bool
+	err := r.pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1)", id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return task.ErrNotFound
+	}
+	return task.ErrLeaseLost
+}
+
+// scanTask implements reading the deferred task from the row of the task columns.
+func scanTask(row pgx.Row) (task.Task, error) {
+	var (
+		t              task.Task
+		action, status string
+		outcomes       []byte
+	)
+	err := row.Scan(&t.ID, &action, &t.UserID, &t.URLIDs, &status, &t.Attempts, &t.LastError, &outcomes,
+		&t.RunAt, &t.CreatedAt)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	t.Action = task.Action(action)
+	t.Status = task.Status(status)
+	if err = json.Unmarshal(outcomes, &t.Outcomes); err != nil {
+		return task.Task{}, err
+	}
+	return t, nil
+}
@@ -0,0 +1,115 @@
+//go:build postgres
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+)
+
+// newTestAction implements getting the task action of the run, the tasks of the previous runs stay
+// in the database and are not claimed by the action.
+func newTestAction() task.Action {
+	return task.Action("test-" + uuid.NewString()[:8])
+}
+
+func Test_repo_ClaimTasks(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+	action := newTestAction()
+
+	due := task.New(action, uuid.New(), []uuid.UUID{uuid.New(), uuid.New()})
+	later := task.New(action, uuid.New(), []uuid.UUID{uuid.New()})
+	later.RunAt = time.Now().Add(time.Hour)
+	other := task.New(newTestAction(), uuid.New(), []uuid.UUID{uuid.New()})
+	for _, item := range []task.Task{due, later, other} {
+		assert.NoError(t, r.AddTask(ctx, item))
+	}
+
+	claimed, err := r.ClaimTasks(ctx, action, 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, due.ID, claimed[0].ID)
+	assert.Equal(t, due.URLIDs, claimed[0].URLIDs)
+	assert.Equal(t, task.StatusRunning, claimed[0].Status)
+	assert.Equal(t, 1, claimed[0].Attempts)
+
+	// the claimed task is not claimed again until the lease ends
+	claimed, err = r.ClaimTasks(ctx, action, 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+}
+
+func Test_repo_ClaimTasks_skipLocked(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+	action := newTestAction()
+
+	locked := task.New(action, uuid.New(), []uuid.UUID{uuid.New()})
+	free := task.New(action, uuid.New(), []uuid.UUID{uuid.New()})
+	free.RunAt = locked.RunAt.Add(time.Millisecond)
+	assert.NoError(t, r.AddTask(ctx, locked))
+	assert.NoError(t, r.AddTask(ctx, free))
+
+	// the row locked by the concurrent worker is skipped instead of waited for
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	assert.NoError(t, err)
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	_, err = tx.Exec(ctx, "SELECT id FROM tasks WHERE id = $1 FOR UPDATE", locked.ID)
+	assert.NoError(t, err)
+
+	claimCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	claimed, err := r.ClaimTasks(claimCtx, action, 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, free.ID, claimed[0].ID)
+
+	assert.NoError(t, tx.Rollback(ctx))
+	claimed, err = r.ClaimTasks(ctx, action, 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, locked.ID, claimed[0].ID)
+}
+
+func Test_repo_CompleteTask(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+	action := newTestAction()
+
+	item := task.New(action, uuid.New(), []uuid.UUID{uuid.New()})
+	assert.NoError(t, r.AddTask(ctx, item))
+
+	// the lease ends, so the task is claimed again by another worker
+	claimed, err := r.ClaimTasks(ctx, action, 1, -time.Second)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	claimed, err = r.ClaimTasks(ctx, action, 1, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, 2, claimed[0].Attempts)
+
+	outcomes := map[uuid.UUID]task.Outcome{item.URLIDs[0]: task.OutcomeDeleted}
+	assert.ErrorIs(t, r.CompleteTask(ctx, item.ID, 1, outcomes), task.ErrLeaseLost)
+	assert.ErrorIs(t, r.RetryTask(ctx, item.ID, 1, time.Now(), "any error"), task.ErrLeaseLost)
+	assert.ErrorIs(t, r.FailTask(ctx, item.ID, 1, "any error"), task.ErrLeaseLost)
+	assert.ErrorIs(t, r.CompleteTask(ctx, uuid.New(), 1, outcomes), task.ErrNotFound)
+
+	assert.NoError(t, r.CompleteTask(ctx, item.ID, 2, outcomes))
+	got, err := r.GetTask(ctx, item.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, task.StatusDone, got.Status)
+	assert.Equal(t, outcomes, got.Outcomes)
+
+	// the completed task is not changed by the attempt again
+	assert.ErrorIs(t, r.FailTask(ctx, item.ID, 2, "any error"), task.ErrLeaseLost)
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/repository/storage/driver"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// DriverName defines the name of the PostgreSQL storage driver.
+const DriverName = "postgres"
+
+func init() {
+	driver.Register(DriverName, driver.Driver{
+		Open: func(ctx context.Context, cfg config.Storage) (storage.URL, error) {
+			r, err := New(ctx, cfg.GetPostgres())
+			if err != nil {
+				return nil, err
+			}
+			return r, nil
+		},
+		Configured: func(cfg config.Storage) bool {
+			dsn := cfg.GetPostgres().GetDSN()
+			// the DSN is either the key/value string or the URL with the postgres scheme
+			return len(dsn) > 0 && (!strings.Contains(dsn, "://") ||
+				strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://"))
+		},
+		Priority: 20,
+	})
+}
@@ -20,9 +20,17 @@ import (
 	"golang.org/x/exp/slog"
 
 	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/outbox"
+	"github.com/sreway/shorturl/internal/domain/task"
 	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/workspace"
 )
 
+// editableURL defines the condition matching short URLs whose workspace the user ($2) may edit,
+// the roles granting the permission are passed as the third parameter.
+const editableURL = `(workspace_id = $2 OR workspace_id IN
+	(SELECT workspace_id FROM workspace_members WHERE user_id = $2 AND role = ANY($3)))`
+
 type repo struct {
 	pool   *pgxpool.Pool
 	logger *slog.Logger
@@ -56,8 +64,8 @@ func (r *repo) Add(ctx context.Context, item entity.URL) error {
 	id = item.ID()
 	userID = item.UserID()
 
-	query := "INSERT INTO urls (id, user_id, original_url) VALUES ($1, $2, $3)"
-	_, err = tx.Exec(ctx, query, id, userID, item.LongURL())
+	query := "INSERT INTO urls (id, user_id, workspace_id, original_url) VALUES ($1, $2, $3, $4)"
+	_, err = tx.Exec(ctx, query, id, userID, item.WorkspaceID(), item.LongURL())
 	if errors.As(err, &pgErr) {
 		switch pgErr.Code {
 		case pgerrcode.UniqueViolation:
@@ -80,18 +88,28 @@ func (r *repo) Add(ctx context.Context, item entity.URL) error {
 		return err
 	}
 
+	if err = r.addOutbox(ctx, tx, outbox.TypeURLCreated, item); err != nil {
+		return err
+	}
+
+	if err = notifyInvalidation(ctx, tx, id); err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
 // Get implements getting short URL.
 func (r *repo) Get(ctx context.Context, id uuid.UUID) (entity.URL, error) {
 	var (
-		userID  uuid.UUID
-		rawURL  string
-		deleted bool
+		userID      uuid.UUID
+		workspaceID uuid.UUID
+		rawURL      string
+		deleted     bool
+		disabled    bool
 	)
-	query := "SELECT user_id, original_url, deleted FROM urls WHERE id = $1"
-	err := r.pool.QueryRow(ctx, query, id).Scan(&userID, &rawURL, &deleted)
+	query := "SELECT user_id, workspace_id, original_url, deleted, disabled FROM urls WHERE id = $1"
+	err := r.pool.QueryRow(ctx, query, id).Scan(&userID, &workspaceID, &rawURL, &deleted, &disabled)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, entity.NewURLErr(id, uuid.UUID{}, entity.ErrNotFound)
@@ -107,8 +125,10 @@ func (r *repo) Get(ctx context.Context, id uuid.UUID) (entity.URL, error) {
 	}
 
 	u := entity.NewURL(id, userID)
+	u.SetWorkspaceID(workspaceID)
 	u.SetLongURL(*value)
 	u.SetDeleted(deleted)
+	u.SetDisabled(disabled)
 	return u, nil
 }
 
@@ -116,7 +136,7 @@ func (r *repo) Get(ctx context.Context, id uuid.UUID) (entity.URL, error) {
 func (r *repo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]entity.URL, error) {
 	urls := make([]entity.URL, 0)
 
-	query := "SELECT id, original_url, deleted FROM urls WHERE user_id = $1"
+	query := "SELECT id, workspace_id, original_url, deleted, disabled FROM urls WHERE user_id = $1"
 	rows, err := r.pool.Query(ctx, query, userID)
 	if err != nil {
 		return nil, err
@@ -124,11 +144,13 @@ func (r *repo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]entity.URL,
 	defer rows.Close()
 	for rows.Next() {
 		var (
-			id      uuid.UUID
-			rawURL  string
-			deleted bool
+			id          uuid.UUID
+			workspaceID uuid.UUID
+			rawURL      string
+			deleted     bool
+			disabled    bool
 		)
-		if err = rows.Scan(&id, &rawURL, &deleted); err != nil {
+		if err = rows.Scan(&id, &workspaceID, &rawURL, &deleted, &disabled); err != nil {
 			return nil, err
 		}
 
@@ -140,14 +162,180 @@ func (r *repo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]entity.URL,
 		}
 
 		u := entity.NewURL(id, userID)
+		u.SetWorkspaceID(workspaceID)
 		u.SetLongURL(*value)
 		u.SetDeleted(deleted)
+		u.SetDisabled(disabled)
 		urls = append(urls, u)
 	}
 
 	return urls, nil
 }
 
+// GetByWorkspaceID implements getting short URLs of the workspace visible to the user ID.
+func (r *repo) GetByWorkspaceID(ctx context.Context, workspaceID, userID uuid.UUID) ([]entity.URL, error) {
+	urls := make([]entity.URL, 0)
+
+	query := `SELECT id, user_id, original_url, deleted, disabled FROM urls WHERE workspace_id = $1 AND
+		($1 = $2 OR EXISTS (SELECT 1 FROM workspace_members WHERE workspace_id = $1 AND user_id = $2))`
+	rows, err := r.pool.Query(ctx, query, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id       uuid.UUID
+			ownerID  uuid.UUID
+			rawURL   string
+			deleted  bool
+			disabled bool
+		)
+		if err = rows.Scan(&id, &ownerID, &rawURL, &deleted, &disabled); err != nil {
+			return nil, err
+		}
+
+		value, err := url.ParseRequestURI(rawURL)
+		if err != nil {
+			r.logger.Error("failed parse raw url", err, slog.String("func", "GetByWorkspaceID"),
+				slog.String("url", rawURL))
+			return nil, err
+		}
+
+		u := entity.NewURL(id, ownerID)
+		u.SetWorkspaceID(workspaceID)
+		u.SetLongURL(*value)
+		u.SetDeleted(deleted)
+		u.SetDisabled(disabled)
+		urls = append(urls, u)
+	}
+
+	return urls, rows.Err()
+}
+
+// ScanIDs implements passing the IDs of all short URLs to fn, the scan stops at the first error of fn.
+func (r *repo) ScanIDs(ctx context.Context, fn func(id uuid.UUID) error) error {
+	rows, err := r.pool.Query(ctx, "SELECT id FROM urls")
+	if err != nil {
+		r.logger.Error("failed scan url ids", err, slog.String("func", "ScanIDs"))
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			return err
+		}
+		if err = fn(id); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// CountRecords implements counting the rows of the tables other than short URLs, the deferred tasks and the outbox
+// messages are not counted since they belong to the running application.
+func (r *repo) CountRecords(ctx context.Context) (map[string]int, error) {
+	tables := []string{
+		"accounts", "workspaces", "banned_users", "reports", "audit_events", "webhooks", "webhook_dead_letters",
+	}
+
+	counts := make(map[string]int)
+	for _, table := range tables {
+		var n int
+		if err := r.pool.QueryRow(ctx, "SELECT COUNT(*) FROM "+table).Scan(&n); err != nil {
+			r.logger.Error("failed count records", err, slog.String("func", "CountRecords"),
+				slog.String("table", table))
+			return nil, err
+		}
+		if n > 0 {
+			counts[table] = n
+		}
+	}
+	return counts, nil
+}
+
+// Import implements saving short URLs copied from another storage with all their attributes,
+// the short URLs stored under the same ID are replaced. Nothing is saved when one of them conflicts.
+func (r *repo) Import(ctx context.Context, urls []entity.URL) error {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO urls (id, user_id, workspace_id, original_url, deleted, disabled)
+		VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (id) DO UPDATE SET user_id = EXCLUDED.user_id,
+		workspace_id = EXCLUDED.workspace_id, original_url = EXCLUDED.original_url,
+		deleted = EXCLUDED.deleted, disabled = EXCLUDED.disabled`
+
+	ids := make([]uuid.UUID, len(urls))
+	for idx, item := range urls {
+		var pgErr *pgconn.PgError
+		_, err = tx.Exec(ctx, query, item.ID(), item.UserID(), item.WorkspaceID(), item.LongURL(),
+			item.Deleted(), item.Disabled())
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return entity.NewURLErr(item.ID(), item.UserID(), entity.ErrAlreadyExist)
+		}
+
+		if err != nil {
+			r.logger.Error("failed import url", err, slog.String("func", "Import"))
+			return entity.NewURLErr(item.ID(), item.UserID(), err)
+		}
+		ids[idx] = item.ID()
+	}
+
+	if err = notifyInvalidation(ctx, tx, ids...); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Update implements changing the long URL and the owning workspace of the short URL.
+func (r *repo) Update(ctx context.Context, item entity.URL) error {
+	var pgErr *pgconn.PgError
+
+	roles := editorRoles()
+	query := `UPDATE urls SET original_url = $4, workspace_id = $5 WHERE id = $1 AND ` + editableURL + ` AND
+		($5 = $2 OR $5 IN (SELECT workspace_id FROM workspace_members WHERE user_id = $2 AND role = ANY($3)))`
+	tag, err := r.pool.Exec(ctx, query, item.ID(), item.UserID(), roles, item.LongURL(), item.WorkspaceID())
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return entity.NewURLErr(item.ID(), item.UserID(), entity.ErrAlreadyExist)
+		default:
+			r.logger.Error("postgres error", err, slog.String("code", pgErr.Code))
+			return entity.NewURLErr(item.ID(), item.UserID(), err)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() > 0 {
+		r.invalidate(ctx, "Update", item.ID())
+		return nil
+	}
+
+	var exists bool
+	query = "SELECT EXISTS (SELECT 1 FROM urls WHERE id = $1)"
+	if err = r.pool.QueryRow(ctx, query, item.ID()).Scan(&exists); err != nil {
+		return err
+	}
+
+	if !exists {
+		return entity.NewURLErr(item.ID(), item.UserID(), entity.ErrNotFound)
+	}
+
+	return entity.NewURLErr(item.ID(), item.UserID(), workspace.ErrForbidden)
+}
+
 // Close implements closing the connection to the storage.
 func (r *repo) Close() error {
 	r.pool.Close()
@@ -155,6 +343,8 @@ func (r *repo) Close() error {
 }
 
 // Batch implements saving multiple short URLs.
+// Nothing is saved when some URLs are shortened already, the returned error keeps the existing IDs,
+// items repeating a URL of the batch get the ID of its first item.
 func (r *repo) Batch(ctx context.Context, urls []entity.URL) error {
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
 	defer func() {
@@ -163,50 +353,260 @@ func (r *repo) Batch(ctx context.Context, urls []entity.URL) error {
 	if err != nil {
 		return err
 	}
-	var pgErr *pgconn.PgError
 
-	query := "INSERT INTO urls (id, user_id, original_url) VALUES ($1, $2, $3)"
+	var (
+		ids          = make([]uuid.UUID, len(urls))
+		userIDs      = make([]uuid.UUID, len(urls))
+		workspaceIDs = make([]uuid.UUID, len(urls))
+		longURLs     = make([]string, len(urls))
+		pgErr        *pgconn.PgError
+	)
+	for idx, item := range urls {
+		ids[idx] = item.ID()
+		userIDs[idx] = item.UserID()
+		workspaceIDs[idx] = item.WorkspaceID()
+		longURLs[idx] = item.LongURL()
+	}
+
+	query := `INSERT INTO urls (id, user_id, workspace_id, original_url)
+		SELECT * FROM unnest($1::uuid[], $2::uuid[], $3::uuid[], $4::text[])
+		ON CONFLICT (user_id, original_url) DO NOTHING RETURNING id`
+	rows, err := tx.Query(ctx, query, ids, userIDs, workspaceIDs, longURLs)
+	if err != nil {
+		return err
+	}
+
+	inserted := make(map[uuid.UUID]bool, len(urls))
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		inserted[id] = true
+	}
+	rows.Close()
+	if err = rows.Err(); errors.As(err, &pgErr) {
+		r.logger.Error("postgres error", err, slog.String("code", pgErr.Code), slog.String("func", "Batch"))
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(inserted) < len(urls) {
+		return r.batchConflict(ctx, tx, urls, inserted)
+	}
+
+	if err = r.addOutbox(ctx, tx, outbox.TypeURLCreated, urls...); err != nil {
+		return err
+	}
+
+	if err = notifyInvalidation(ctx, tx, ids...); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// batchConflict implements looking up the short URLs existing for the batch items left not inserted.
+// The items repeating the long URL of another item of the batch conflict with the short URL inserted by the batch,
+// it is rolled back with the batch, so it is not reported as existing.
+func (r *repo) batchConflict(ctx context.Context, tx pgx.Tx, urls []entity.URL, inserted map[uuid.UUID]bool) error {
+	var (
+		ids      = make([]uuid.UUID, 0, len(urls)-len(inserted))
+		userIDs  = make([]uuid.UUID, 0, len(urls)-len(inserted))
+		longURLs = make([]string, 0, len(urls)-len(inserted))
+	)
 	for _, item := range urls {
-		_, err = tx.Exec(ctx, query, item.ID(), item.UserID(), item.LongURL())
-		if errors.As(err, &pgErr) {
-			switch pgErr.Code {
-			case pgerrcode.UniqueViolation:
-				return entity.NewURLErr(item.ID(), item.UserID(), entity.ErrAlreadyExist)
-			default:
-				r.logger.Error("postgres error", err, slog.String("code", pgErr.Code))
-				return entity.NewURLErr(item.ID(), item.UserID(), err)
-			}
+		if inserted[item.ID()] {
+			continue
 		}
+		ids = append(ids, item.ID())
+		userIDs = append(userIDs, item.UserID())
+		longURLs = append(longURLs, item.LongURL())
+	}
 
-		if err != nil {
+	query := `SELECT c.id, u.id FROM unnest($1::uuid[], $2::uuid[], $3::text[]) AS c(id, user_id, original_url)
+		JOIN urls u ON u.user_id = c.user_id AND u.original_url = c.original_url`
+	rows, err := tx.Query(ctx, query, ids, userIDs, longURLs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	existing := make(map[uuid.UUID]uuid.UUID, len(ids))
+	for rows.Next() {
+		var id, existingID uuid.UUID
+		if err = rows.Scan(&id, &existingID); err != nil {
 			return err
 		}
+		if inserted[existingID] {
+			continue
+		}
+		existing[id] = existingID
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.Error("failed get existing urls", err, slog.String("func", "Batch"))
+		return err
 	}
 
-	return tx.Commit(ctx)
+	return entity.NewBatchErr(existing, entity.ErrAlreadyExist)
 }
 
 // BatchDelete implements the deletion multiple short URLs.
-func (r *repo) BatchDelete(ctx context.Context, urls []entity.URL) error {
+func (r *repo) BatchDelete(ctx context.Context, urls []entity.URL) (map[uuid.UUID]task.Outcome, error) {
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
 	defer func() {
 		_ = tx.Rollback(ctx)
 	}()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	query := `UPDATE urls SET deleted = true WHERE id = $1 and user_id = $2`
-
+	// the short URLs are deleted by the acting user, usually the same one for the whole batch
+	userIDs := make([]uuid.UUID, 0, 1)
+	idsByUser := make(map[uuid.UUID][]uuid.UUID)
 	for _, item := range urls {
-		_, err = tx.Exec(ctx, query, item.ID(), item.UserID())
+		if _, ok := idsByUser[item.UserID()]; !ok {
+			userIDs = append(userIDs, item.UserID())
+		}
+		idsByUser[item.UserID()] = append(idsByUser[item.UserID()], item.ID())
+	}
+
+	outcomes := make(map[uuid.UUID]task.Outcome, len(urls))
+	deleted := make([]entity.URL, 0, len(urls))
+	for _, userID := range userIDs {
+		var changed []entity.URL
+		changed, err = r.deleteUserURLs(ctx, tx, userID, idsByUser[userID], outcomes)
 		if err != nil {
-			r.logger.Error("failed update url", err, slog.String("func", "BatchDelete"))
-			return entity.NewURLErr(item.ID(), item.UserID(), err)
+			return nil, err
 		}
+		deleted = append(deleted, changed...)
 	}
 
-	return tx.Commit(ctx)
+	if err = r.addOutbox(ctx, tx, outbox.TypeURLDeleted, deleted...); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(deleted))
+	for idx, u := range deleted {
+		ids[idx] = u.ID()
+	}
+	if err = notifyInvalidation(ctx, tx, ids...); err != nil {
+		return nil, err
+	}
+
+	return outcomes, tx.Commit(ctx)
+}
+
+// deleteUserURLs implements the deletion of the short URLs by the user and returns the deleted ones,
+// the outcome of every passed ID is set to the outcomes.
+func (r *repo) deleteUserURLs(ctx context.Context, tx pgx.Tx, userID uuid.UUID, ids []uuid.UUID,
+	outcomes map[uuid.UUID]task.Outcome,
+) ([]entity.URL, error) {
+	roles := editorRoles()
+	query := `UPDATE urls SET deleted = true WHERE id = ANY($1) AND NOT deleted AND ` + editableURL +
+		` RETURNING id, user_id, workspace_id`
+	rows, err := tx.Query(ctx, query, ids, userID, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make([]entity.URL, 0, len(ids))
+	for rows.Next() {
+		var id, ownerID, workspaceID uuid.UUID
+		if err = rows.Scan(&id, &ownerID, &workspaceID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		u := entity.NewURL(id, ownerID)
+		u.SetWorkspaceID(workspaceID)
+		deleted = append(deleted, u)
+		outcomes[id] = task.OutcomeDeleted
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		r.logger.Error("failed update urls", err, slog.String("func", "BatchDelete"))
+		return nil, err
+	}
+
+	rest := make([]uuid.UUID, 0, len(ids)-len(deleted))
+	for _, id := range ids {
+		if _, ok := outcomes[id]; !ok {
+			rest = append(rest, id)
+		}
+	}
+	if len(rest) == 0 {
+		return deleted, nil
+	}
+
+	// rows left unchanged by the update are either deleted already or not editable by the user
+	query = `SELECT id, ` + editableURL + ` FROM urls WHERE id = ANY($1)`
+	rows, err = tx.Query(ctx, query, rest, userID, roles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id       uuid.UUID
+			editable bool
+		)
+		if err = rows.Scan(&id, &editable); err != nil {
+			return nil, err
+		}
+		outcomes[id] = task.OutcomeNotOwned
+		if editable {
+			outcomes[id] = task.OutcomeDeleted
+		}
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.Error("failed check urls", err, slog.String("func", "BatchDelete"))
+		return nil, err
+	}
+
+	for _, id := range rest {
+		if _, ok := outcomes[id]; !ok {
+			outcomes[id] = task.OutcomeNotFound
+		}
+	}
+
+	return deleted, nil
+}
+
+// ChangeOwner implements moving short URLs to another user ID.
+// URLs already shortened by the new owner stay with the previous one,
+// URLs of the personal workspace are moved to the personal workspace of the new owner.
+func (r *repo) ChangeOwner(ctx context.Context, from, to uuid.UUID) error {
+	query := `UPDATE urls SET user_id = $2,
+		workspace_id = CASE WHEN workspace_id = $1 THEN $2 ELSE workspace_id END
+		WHERE user_id = $1 AND original_url NOT IN
+		(SELECT original_url FROM urls WHERE user_id = $2) RETURNING id`
+	rows, err := r.pool.Query(ctx, query, from, to)
+	if err != nil {
+		r.logger.Error("failed change url owner", err, slog.String("func", "ChangeOwner"))
+		return err
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("failed change url owner", err, slog.String("func", "ChangeOwner"))
+		return err
+	}
+
+	r.invalidate(ctx, "ChangeOwner", ids...)
+	return nil
 }
 
 // GetUserCount implements the getting user count stat.
@@ -0,0 +1,150 @@
+//go:build postgres
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+// testConfig implements the PostgreSQL storage configuration with the database set by TEST_DATABASE_DSN.
+type testConfig struct {
+	dsn string
+}
+
+func (c testConfig) GetDSN() string        { return c.dsn }
+func (c testConfig) GetMigrateURL() string { return "file://../../../../migrations/postgres" }
+
+func newTestRepo(t *testing.T) *repo {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if len(dsn) == 0 {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	r, err := New(context.Background(), testConfig{dsn: dsn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = r.Close()
+	})
+	return r
+}
+
+func newTestURL(id, userID uuid.UUID, rawURL string) entity.URL {
+	value, _ := url.Parse(rawURL)
+	u := entity.NewURL(id, userID)
+	u.SetWorkspaceID(userID)
+	u.SetLongURL(*value)
+	return u
+}
+
+func Test_repo_Batch(t *testing.T) {
+	tests := []struct {
+		name         string
+		longURLs     []string
+		wantExisting map[int]bool
+		wantErr      error
+	}{
+		{
+			name:     "positive batch urls",
+			longURLs: []string{"https://example.com/1", "https://example.com/2"},
+		},
+		{
+			name:         "negative batch urls (already exist)",
+			longURLs:     []string{"https://example.com/1", "https://example.com"},
+			wantExisting: map[int]bool{1: true},
+			wantErr:      entity.ErrAlreadyExist,
+		},
+		{
+			name:         "negative batch urls (repeated in batch)",
+			longURLs:     []string{"https://example.com/1", "https://example.com/1"},
+			wantExisting: map[int]bool{},
+			wantErr:      entity.ErrAlreadyExist,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			// the rows of the previous runs stay in the database, so every run uses a new user
+			userID := uuid.New()
+			existing := newTestURL(uuid.New(), userID, "https://example.com")
+			assert.NoError(t, r.Add(ctx, existing))
+
+			urls := make([]entity.URL, len(tt.longURLs))
+			for idx, rawURL := range tt.longURLs {
+				urls[idx] = newTestURL(uuid.New(), userID, rawURL)
+			}
+
+			err := r.Batch(ctx, urls)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				for _, u := range urls {
+					_, err = r.Get(ctx, u.ID())
+					assert.NoError(t, err)
+				}
+				return
+			}
+
+			var errBatch *entity.ErrBatch
+			assert.True(t, errors.As(err, &errBatch))
+			want := make(map[uuid.UUID]uuid.UUID, len(tt.wantExisting))
+			for idx := range tt.wantExisting {
+				want[urls[idx].ID()] = existing.ID()
+			}
+			assert.Equal(t, want, errBatch.Existing())
+
+			// nothing is saved when the batch conflicts
+			for _, u := range urls {
+				_, err = r.Get(ctx, u.ID())
+				assert.ErrorIs(t, err, entity.ErrNotFound)
+			}
+		})
+	}
+}
+
+func Test_repo_BatchDelete(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	var (
+		userID    = uuid.New()
+		ownedID   = uuid.New()
+		otherID   = uuid.New()
+		missingID = uuid.New()
+	)
+	assert.NoError(t, r.Add(ctx, newTestURL(ownedID, userID, "https://example.com/owned")))
+	assert.NoError(t, r.Add(ctx, newTestURL(otherID, uuid.New(), "https://example.com/other")))
+
+	outcomes, err := r.BatchDelete(ctx, []entity.URL{
+		entity.NewURL(ownedID, userID),
+		entity.NewURL(otherID, userID),
+		entity.NewURL(missingID, userID),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]task.Outcome{
+		ownedID:   task.OutcomeDeleted,
+		otherID:   task.OutcomeNotOwned,
+		missingID: task.OutcomeNotFound,
+	}, outcomes)
+
+	// the short URL deleted already keeps the deleted outcome
+	outcomes, err = r.BatchDelete(ctx, []entity.URL{entity.NewURL(ownedID, userID)})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]task.Outcome{ownedID: task.OutcomeDeleted}, outcomes)
+
+	u, err := r.Get(ctx, ownedID)
+	assert.NoError(t, err)
+	assert.True(t, u.Deleted())
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/report"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+// AddReport implements saving abuse report.
+func (r *repo) AddReport(ctx context.Context, item report.Report) error {
+	var pgErr *pgconn.PgError
+
+	query := "INSERT INTO reports (id, url_id, reporter_id, reason, created_at) VALUES ($1, $2, $3, $4, $5)"
+	_, err := r.pool.Exec(ctx, query, item.ID(), item.URLID(), item.ReporterID(), item.Reason(), item.CreatedAt())
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return report.ErrAlreadyExist
+		case pgerrcode.ForeignKeyViolation:
+			return entity.NewURLErr(item.URLID(), uuid.UUID{}, entity.ErrNotFound)
+		default:
+			r.logger.Error("postgres error", err, slog.String("code", pgErr.Code))
+			return err
+		}
+	}
+
+	return err
+}
+
+// CountReports implements getting the number of open reports of the short URL.
+func (r *repo) CountReports(ctx context.Context, urlID uuid.UUID) (int, error) {
+	var counter int
+	query := "SELECT COUNT(id) FROM reports WHERE url_id = $1 AND NOT resolved"
+	err := r.pool.QueryRow(ctx, query, urlID).Scan(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter, nil
+}
+
+// GetReportQueue implements getting open reports aggregated by short URL, the most reported first.
+func (r *repo) GetReportQueue(ctx context.Context, limit, offset int) ([]report.Summary, error) {
+	queue := make([]report.Summary, 0)
+
+	var limitValue *int
+	if limit > 0 {
+		limitValue = &limit
+	}
+
+	query := `SELECT r.url_id, u.original_url, u.disabled, COUNT(r.id), array_agg(r.reason ORDER BY r.created_at),
+		MAX(r.created_at) FROM reports r JOIN urls u ON u.id = r.url_id WHERE NOT r.resolved
+		GROUP BY r.url_id, u.original_url, u.disabled ORDER BY COUNT(r.id) DESC, MAX(r.created_at) DESC
+		LIMIT $1 OFFSET $2`
+	rows, err := r.pool.Query(ctx, query, limitValue, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			s              report.Summary
+			lastReportedAt time.Time
+		)
+		if err = rows.Scan(&s.URLID, &s.LongURL, &s.Disabled, &s.Count, &s.Reasons, &lastReportedAt); err != nil {
+			return nil, err
+		}
+		s.LastReportedAt = lastReportedAt
+		queue = append(queue, s)
+	}
+
+	return queue, rows.Err()
+}
+
+// ResolveReports implements closing open reports of the short URL after the moderator review.
+func (r *repo) ResolveReports(ctx context.Context, urlID uuid.UUID) error {
+	query := "UPDATE reports SET resolved = true WHERE url_id = $1 AND NOT resolved"
+	_, err := r.pool.Exec(ctx, query, urlID)
+	if err != nil {
+		r.logger.Error("failed resolve reports", err, slog.String("func", "ResolveReports"))
+		return err
+	}
+	return nil
+}
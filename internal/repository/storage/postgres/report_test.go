@@ -0,0 +1,114 @@
+//go:build postgres
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/report"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+func newTestReport(urlID uuid.UUID, reason string, createdAt time.Time) report.Report {
+	item := report.NewReport(uuid.New(), urlID, uuid.New())
+	item.SetReason(reason)
+	item.SetCreatedAt(createdAt)
+	return item
+}
+
+func Test_repo_AddReport(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	u := newTestURL(uuid.New(), uuid.New(), "https://example.com/reported")
+	assert.NoError(t, r.Add(ctx, u))
+
+	item := newTestReport(u.ID(), "spam", time.Now())
+	assert.NoError(t, r.AddReport(ctx, item))
+
+	// the reporter has one open report per short URL
+	repeated := report.NewReport(uuid.New(), u.ID(), item.ReporterID())
+	repeated.SetReason("phishing")
+	repeated.SetCreatedAt(time.Now())
+	assert.ErrorIs(t, r.AddReport(ctx, repeated), report.ErrAlreadyExist)
+
+	assert.ErrorIs(t, r.AddReport(ctx, newTestReport(uuid.New(), "spam", time.Now())), entity.ErrNotFound)
+
+	// the reporter reports the short URL again after the review
+	assert.NoError(t, r.ResolveReports(ctx, u.ID()))
+	assert.NoError(t, r.AddReport(ctx, repeated))
+
+	count, err := r.CountReports(ctx, u.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NoError(t, r.ResolveReports(ctx, u.ID()))
+}
+
+func Test_repo_GetReportQueue(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	var (
+		userID = uuid.New()
+		most   = newTestURL(uuid.New(), userID, "https://example.com/most")
+		least  = newTestURL(uuid.New(), userID, "https://example.com/least")
+		now    = time.Now()
+	)
+	assert.NoError(t, r.Add(ctx, most))
+	assert.NoError(t, r.Add(ctx, least))
+	assert.NoError(t, r.SetDisabled(ctx, most.ID(), true))
+
+	assert.NoError(t, r.AddReport(ctx, newTestReport(most.ID(), "spam", now.Add(-time.Minute))))
+	assert.NoError(t, r.AddReport(ctx, newTestReport(most.ID(), "phishing", now)))
+	assert.NoError(t, r.AddReport(ctx, newTestReport(least.ID(), "malware", now)))
+
+	count, err := r.CountReports(ctx, most.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// the open reports of the previous runs stay in the queue, so the summaries of the run are picked
+	summaries := func() map[uuid.UUID]report.Summary {
+		queue, err := r.GetReportQueue(ctx, 0, 0)
+		assert.NoError(t, err)
+
+		result := make(map[uuid.UUID]report.Summary)
+		for idx, s := range queue {
+			if idx > 0 {
+				assert.GreaterOrEqual(t, queue[idx-1].Count, s.Count)
+			}
+			if s.URLID == most.ID() || s.URLID == least.ID() {
+				result[s.URLID] = s
+			}
+		}
+		return result
+	}
+
+	got := summaries()
+	assert.Len(t, got, 2)
+	assert.Equal(t, 2, got[most.ID()].Count)
+	assert.Equal(t, []string{"spam", "phishing"}, got[most.ID()].Reasons)
+	assert.Equal(t, most.LongURL(), got[most.ID()].LongURL)
+	assert.True(t, got[most.ID()].Disabled)
+	assert.Equal(t, 1, got[least.ID()].Count)
+	assert.False(t, got[least.ID()].Disabled)
+
+	// the resolved reports leave the queue
+	assert.NoError(t, r.ResolveReports(ctx, most.ID()))
+	count, err = r.CountReports(ctx, most.ID())
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+
+	got = summaries()
+	assert.Len(t, got, 1)
+	assert.Contains(t, got, least.ID())
+	assert.NoError(t, r.ResolveReports(ctx, least.ID()))
+
+	queue, err := r.GetReportQueue(ctx, 1, 0)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(queue), 1)
+}
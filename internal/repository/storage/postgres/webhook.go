@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/webhook"
+)
+
+// AddWebhook implements saving webhook.
+func (r *repo) AddWebhook(ctx context.Context, item webhook.Webhook) error {
+	events := make([]string, len(item.Events()))
+	for idx, v := range item.Events() {
+		events[idx] = string(v)
+	}
+
+	query := "INSERT INTO webhooks (id, user_id, url, secret, events) VALUES ($1, $2, $3, $4, $5)"
+	_, err := r.pool.Exec(ctx, query, item.ID(), item.UserID(), item.URL(), item.Secret(), events)
+	if err != nil {
+		r.logger.Error("failed add webhook", err, slog.String("func", "AddWebhook"))
+		return err
+	}
+	return nil
+}
+
+// GetWebhooks implements getting webhooks of the user ordered by creation time.
+func (r *repo) GetWebhooks(ctx context.Context, userID uuid.UUID) ([]webhook.Webhook, error) {
+	webhooks := make([]webhook.Webhook, 0)
+
+	query := "SELECT id, url, secret, events FROM webhooks WHERE user_id = $1 ORDER BY created_at"
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id        uuid.UUID
+			url       string
+			secret    string
+			rawEvents []string
+		)
+		if err = rows.Scan(&id, &url, &secret, &rawEvents); err != nil {
+			return nil, err
+		}
+
+		events := make([]webhook.EventType, len(rawEvents))
+		for idx, v := range rawEvents {
+			events[idx] = webhook.EventType(v)
+		}
+
+		item := webhook.NewWebhook(id, userID)
+		item.SetURL(url)
+		item.SetSecret(secret)
+		item.SetEvents(events)
+		webhooks = append(webhooks, item)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// DeleteWebhook implements the removal of the webhook owned by the user.
+func (r *repo) DeleteWebhook(ctx context.Context, id, userID uuid.UUID) error {
+	query := "DELETE FROM webhooks WHERE id = $1 AND user_id = $2"
+	tag, err := r.pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		r.logger.Error("failed delete webhook", err, slog.String("func", "DeleteWebhook"))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return webhook.ErrNotFound
+	}
+
+	return nil
+}
+
+// AddDeadLetter implements saving the failed webhook delivery.
+func (r *repo) AddDeadLetter(ctx context.Context, item webhook.Delivery) error {
+	query := `INSERT INTO webhook_dead_letters (id, webhook_id, user_id, event, payload, attempts, last_error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.pool.Exec(ctx, query, item.ID, item.WebhookID, item.UserID, string(item.Event), item.Payload,
+		item.Attempts, item.LastError, item.CreatedAt)
+	if err != nil {
+		r.logger.Error("failed add dead letter", err, slog.String("func", "AddDeadLetter"))
+		return err
+	}
+	return nil
+}
+
+// GetDeadLetters implements getting failed webhook deliveries of the user.
+func (r *repo) GetDeadLetters(ctx context.Context, userID uuid.UUID) ([]webhook.Delivery, error) {
+	deliveries := make([]webhook.Delivery, 0)
+
+	query := `SELECT id, webhook_id, event, payload, attempts, last_error, created_at FROM webhook_dead_letters
+		WHERE user_id = $1 ORDER BY created_at`
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			item      webhook.Delivery
+			event     string
+			createdAt time.Time
+		)
+		if err = rows.Scan(&item.ID, &item.WebhookID, &event, &item.Payload, &item.Attempts, &item.LastError,
+			&createdAt); err != nil {
+			return nil, err
+		}
+		item.UserID = userID
+		item.Event = webhook.EventType(event)
+		item.CreatedAt = createdAt
+		deliveries = append(deliveries, item)
+	}
+
+	return deliveries, rows.Err()
+}
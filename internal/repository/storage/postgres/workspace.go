@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/workspace"
+)
+
+// AddWorkspace implements saving workspace, the owner becomes its administrator.
+func (r *repo) AddWorkspace(ctx context.Context, item workspace.Workspace, ownerID uuid.UUID) error {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	if err != nil {
+		return err
+	}
+
+	query := "INSERT INTO workspaces (id, name) VALUES ($1, $2)"
+	if _, err = tx.Exec(ctx, query, item.ID(), item.Name()); err != nil {
+		r.logger.Error("failed add workspace", err, slog.String("func", "AddWorkspace"))
+		return err
+	}
+
+	query = "INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)"
+	if _, err = tx.Exec(ctx, query, item.ID(), ownerID, string(workspace.RoleAdmin)); err != nil {
+		r.logger.Error("failed add workspace owner", err, slog.String("func", "AddWorkspace"))
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetWorkspaces implements getting workspaces the user ID is a member of.
+func (r *repo) GetWorkspaces(ctx context.Context, userID uuid.UUID) ([]workspace.Workspace, error) {
+	workspaces := make([]workspace.Workspace, 0)
+
+	query := `SELECT w.id, w.name, m.role FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id WHERE m.user_id = $1`
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id   uuid.UUID
+			name string
+			role string
+		)
+		if err = rows.Scan(&id, &name, &role); err != nil {
+			return nil, err
+		}
+
+		w := workspace.NewWorkspace(id, name)
+		w.SetRole(workspace.Role(role))
+		workspaces = append(workspaces, w)
+	}
+
+	return workspaces, rows.Err()
+}
+
+// GetRole implements getting the role of the user ID in the workspace.
+func (r *repo) GetRole(ctx context.Context, workspaceID, userID uuid.UUID) (workspace.Role, error) {
+	if workspace.Personal(workspaceID, userID) {
+		return workspace.RoleAdmin, nil
+	}
+
+	var role *string
+	query := `SELECT m.role FROM workspaces w
+		LEFT JOIN workspace_members m ON m.workspace_id = w.id AND m.user_id = $2 WHERE w.id = $1`
+	err := r.pool.QueryRow(ctx, query, workspaceID, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", workspace.ErrNotFound
+		}
+		return "", err
+	}
+
+	if role == nil {
+		return "", workspace.ErrForbidden
+	}
+
+	return workspace.Role(*role), nil
+}
+
+// SetMember implements adding the workspace member or changing the member role.
+func (r *repo) SetMember(ctx context.Context, workspaceID, userID uuid.UUID, role workspace.Role) error {
+	var pgErr *pgconn.PgError
+
+	query := `INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)
+		ON CONFLICT (workspace_id, user_id) DO UPDATE SET role = EXCLUDED.role`
+	_, err := r.pool.Exec(ctx, query, workspaceID, userID, string(role))
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.ForeignKeyViolation:
+			return workspace.ErrNotFound
+		default:
+			r.logger.Error("postgres error", err, slog.String("code", pgErr.Code))
+			return err
+		}
+	}
+
+	return err
+}
+
+// DeleteMember implements removing the workspace member.
+func (r *repo) DeleteMember(ctx context.Context, workspaceID, userID uuid.UUID) error {
+	query := "DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2"
+	_, err := r.pool.Exec(ctx, query, workspaceID, userID)
+	if err != nil {
+		r.logger.Error("failed delete workspace member", err, slog.String("func", "DeleteMember"))
+		return err
+	}
+	return nil
+}
+
+// editorRoles implements getting the roles allowed to change workspace short URLs as query parameter.
+func editorRoles() []string {
+	roles := workspace.RolesAllowing(workspace.RoleEditor)
+	result := make([]string, len(roles))
+	for idx, role := range roles {
+		result[idx] = string(role)
+	}
+	return result
+}
@@ -0,0 +1,183 @@
+//go:build postgres
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/workspace"
+)
+
+func Test_repo_Update(t *testing.T) {
+	tests := []struct {
+		name string
+		// role defines the role of the user in the workspace of the short URL, the empty role is not a member
+		role workspace.Role
+		// move defines the short URL is moved to the personal workspace of the user
+		move    bool
+		missing bool
+		wantErr error
+	}{
+		{
+			name: "positive update url (editor)",
+			role: workspace.RoleEditor,
+		},
+		{
+			name: "positive update url (admin moves url)",
+			role: workspace.RoleAdmin,
+			move: true,
+		},
+		{
+			name:    "negative update url (viewer)",
+			role:    workspace.RoleViewer,
+			wantErr: workspace.ErrForbidden,
+		},
+		{
+			name:    "negative update url (not a member)",
+			wantErr: workspace.ErrForbidden,
+		},
+		{
+			name:    "negative update url (not found)",
+			role:    workspace.RoleEditor,
+			missing: true,
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			var (
+				ownerID = uuid.New()
+				userID  = uuid.New()
+				ws      = workspace.NewWorkspace(uuid.New(), "team")
+			)
+			assert.NoError(t, r.AddWorkspace(ctx, ws, ownerID))
+			if len(tt.role) > 0 {
+				assert.NoError(t, r.SetMember(ctx, ws.ID(), userID, tt.role))
+			}
+
+			u := newTestURL(uuid.New(), ownerID, "https://example.com/team")
+			u.SetWorkspaceID(ws.ID())
+			if !tt.missing {
+				assert.NoError(t, r.Add(ctx, u))
+			}
+
+			changed := newTestURL(u.ID(), userID, "https://example.com/changed")
+			changed.SetWorkspaceID(ws.ID())
+			if tt.move {
+				changed.SetWorkspaceID(userID)
+			}
+
+			err := r.Update(ctx, changed)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.missing {
+				return
+			}
+
+			stored, err := r.Get(ctx, u.ID())
+			assert.NoError(t, err)
+			// the short URL keeps its owner whoever changes it
+			assert.Equal(t, ownerID, stored.UserID())
+			if tt.wantErr != nil {
+				assert.Equal(t, u.LongURL(), stored.LongURL())
+				assert.Equal(t, ws.ID(), stored.WorkspaceID())
+				return
+			}
+			assert.Equal(t, changed.LongURL(), stored.LongURL())
+			assert.Equal(t, changed.WorkspaceID(), stored.WorkspaceID())
+		})
+	}
+}
+
+func Test_repo_Update_foreignWorkspace(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	var (
+		userID  = uuid.New()
+		ws      = workspace.NewWorkspace(uuid.New(), "team")
+		foreign = workspace.NewWorkspace(uuid.New(), "foreign")
+	)
+	assert.NoError(t, r.AddWorkspace(ctx, ws, userID))
+	assert.NoError(t, r.AddWorkspace(ctx, foreign, uuid.New()))
+	assert.NoError(t, r.SetMember(ctx, foreign.ID(), userID, workspace.RoleViewer))
+
+	u := newTestURL(uuid.New(), userID, "https://example.com/foreign")
+	u.SetWorkspaceID(ws.ID())
+	assert.NoError(t, r.Add(ctx, u))
+
+	// the short URL is not moved to the workspace the user may only view
+	moved := newTestURL(u.ID(), userID, "https://example.com/foreign")
+	moved.SetWorkspaceID(foreign.ID())
+	assert.ErrorIs(t, r.Update(ctx, moved), workspace.ErrForbidden)
+
+	stored, err := r.Get(ctx, u.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, ws.ID(), stored.WorkspaceID())
+}
+
+func Test_repo_BatchDelete_workspace(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	var (
+		ownerID  = uuid.New()
+		editorID = uuid.New()
+		viewerID = uuid.New()
+		ws       = workspace.NewWorkspace(uuid.New(), "team")
+	)
+	assert.NoError(t, r.AddWorkspace(ctx, ws, ownerID))
+	assert.NoError(t, r.SetMember(ctx, ws.ID(), editorID, workspace.RoleEditor))
+	assert.NoError(t, r.SetMember(ctx, ws.ID(), viewerID, workspace.RoleViewer))
+
+	u := newTestURL(uuid.New(), ownerID, "https://example.com/shared")
+	u.SetWorkspaceID(ws.ID())
+	assert.NoError(t, r.Add(ctx, u))
+
+	outcomes, err := r.BatchDelete(ctx, []entity.URL{entity.NewURL(u.ID(), viewerID)})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]task.Outcome{u.ID(): task.OutcomeNotOwned}, outcomes)
+
+	outcomes, err = r.BatchDelete(ctx, []entity.URL{entity.NewURL(u.ID(), editorID)})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]task.Outcome{u.ID(): task.OutcomeDeleted}, outcomes)
+
+	stored, err := r.Get(ctx, u.ID())
+	assert.NoError(t, err)
+	assert.True(t, stored.Deleted())
+}
+
+func Test_repo_GetByWorkspaceID(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	var (
+		ownerID  = uuid.New()
+		viewerID = uuid.New()
+		ws       = workspace.NewWorkspace(uuid.New(), "team")
+	)
+	assert.NoError(t, r.AddWorkspace(ctx, ws, ownerID))
+	assert.NoError(t, r.SetMember(ctx, ws.ID(), viewerID, workspace.RoleViewer))
+
+	u := newTestURL(uuid.New(), ownerID, "https://example.com/visible")
+	u.SetWorkspaceID(ws.ID())
+	assert.NoError(t, r.Add(ctx, u))
+
+	urls, err := r.GetByWorkspaceID(ctx, ws.ID(), viewerID)
+	assert.NoError(t, err)
+	assert.Len(t, urls, 1)
+	assert.Equal(t, u.ID(), urls[0].ID())
+
+	// the short URLs are not listed to the user outside the workspace
+	urls, err = r.GetByWorkspaceID(ctx, ws.ID(), uuid.New())
+	assert.NoError(t, err)
+	assert.Empty(t, urls)
+}
@@ -0,0 +1,9 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	// sqlite driver and migrate tools
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "modernc.org/sqlite"
+)
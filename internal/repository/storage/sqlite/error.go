@@ -0,0 +1,11 @@
+package sqlite
+
+import (
+	"errors"
+)
+
+// ErrInvalidDSN implements SQLite storage invalid DSN scheme error.
+var ErrInvalidDSN = errors.New("invalid sqlite dsn")
+
+// ErrDriverNotBuilt implements SQLite storage missing driver error, the driver is built with the sqlite tag.
+var ErrDriverNotBuilt = errors.New("sqlite driver is not built in")
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+// Search implements getting short URLs of all users matching the filter.
+func (r *repo) Search(ctx context.Context, filter entity.Filter) ([]entity.URL, error) {
+	var (
+		filterUserID *uuid.UUID
+		limit        = -1
+	)
+	if filter.UserID != uuid.Nil {
+		filterUserID = &filter.UserID
+	}
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	query := `SELECT id, user_id, workspace_id, original_url, deleted, disabled FROM urls
+		WHERE instr(original_url, ?1) > 0 AND (?2 IS NULL OR user_id = ?2)
+		ORDER BY id LIMIT ?3 OFFSET ?4`
+	return r.queryURLs(ctx, query, filter.Query, filterUserID, limit, filter.Offset)
+}
+
+// SetDisabled implements the setting of the moderation attribute of the short URL.
+func (r *repo) SetDisabled(ctx context.Context, id uuid.UUID, disabled bool) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE urls SET disabled = ? WHERE id = ?", disabled, id)
+	if err != nil {
+		r.logger.Error("failed update url", err, slog.String("func", "SetDisabled"))
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return entity.NewURLErr(id, uuid.UUID{}, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// ForceDelete implements the removal of short URLs regardless of the owner.
+func (r *repo) ForceDelete(ctx context.Context, ids []uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, id := range ids {
+		if _, err = tx.ExecContext(ctx, "DELETE FROM urls WHERE id = ?", id); err != nil {
+			r.logger.Error("failed delete urls", err, slog.String("func", "ForceDelete"))
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// BanUser implements the setting of the user ban.
+func (r *repo) BanUser(ctx context.Context, userID uuid.UUID, banned bool) error {
+	query := "DELETE FROM banned_users WHERE user_id = ?"
+	if banned {
+		query = "INSERT INTO banned_users (user_id) VALUES (?) ON CONFLICT (user_id) DO NOTHING"
+	}
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("failed update user ban", err, slog.String("func", "BanUser"))
+		return err
+	}
+	return nil
+}
+
+// IsBanned implements checking the user ban.
+func (r *repo) IsBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var banned bool
+	query := "SELECT EXISTS (SELECT 1 FROM banned_users WHERE user_id = ?)"
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&banned)
+	if err != nil {
+		return false, err
+	}
+	return banned, nil
+}
@@ -0,0 +1,224 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+)
+
+// taskColumns defines the selected columns of the deferred task.
+const taskColumns = "id, action, user_id, url_ids, status, attempts, last_error, outcomes, run_at, created_at"
+
+// AddTask implements saving the deferred task.
+func (r *repo) AddTask(ctx context.Context, item task.Task) error {
+	urlIDs, err := json.Marshal(item.URLIDs)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO tasks (id, action, user_id, url_ids, status, attempts, run_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err = r.db.ExecContext(ctx, query, item.ID, string(item.Action), item.UserID, string(urlIDs),
+		string(item.Status), item.Attempts, item.RunAt.UnixNano(), item.CreatedAt.UnixNano())
+	if err != nil {
+		r.logger.Error("failed add task", err, slog.String("func", "AddTask"))
+		return err
+	}
+	return nil
+}
+
+// ClaimTasks implements marking the due tasks of the action running until the lease ends.
+// The single connection serializes the transactions, so the claim is exclusive.
+func (r *repo) ClaimTasks(ctx context.Context, action task.Action, limit int, lease time.Duration) ([]task.Task, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	now := time.Now()
+	query := "SELECT " + taskColumns + ` FROM tasks WHERE action = ? AND status IN (?, ?) AND run_at <= ?
+		ORDER BY run_at LIMIT ?`
+	rows, err := tx.QueryContext(ctx, query, string(action), string(task.StatusPending), string(task.StatusRunning),
+		now.UnixNano(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]task.Task, 0)
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	if err = rows.Close(); err != nil {
+		return nil, err
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	runAt := now.Add(lease)
+	query = "UPDATE tasks SET status = ?, attempts = attempts + 1, run_at = ? WHERE id = ?"
+	for idx := range tasks {
+		_, err = tx.ExecContext(ctx, query, string(task.StatusRunning), runAt.UnixNano(), tasks[idx].ID)
+		if err != nil {
+			return nil, err
+		}
+		tasks[idx].Status = task.StatusRunning
+		tasks[idx].Attempts++
+		tasks[idx].RunAt = runAt
+	}
+
+	return tasks, tx.Commit()
+}
+
+// GetTask implements getting the deferred task.
+func (r *repo) GetTask(ctx context.Context, id uuid.UUID) (task.Task, error) {
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = ?"
+	t, err := scanTask(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return task.Task{}, task.ErrNotFound
+	}
+	return t, err
+}
+
+// CompleteTask implements marking the task of the claimed attempt processed with the outcomes for its short URLs.
+func (r *repo) CompleteTask(ctx context.Context, id uuid.UUID, attempt int,
+	outcomes map[uuid.UUID]task.Outcome,
+) error {
+	data, err := json.Marshal(outcomes)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE tasks SET status = ?, run_at = ?, last_error = '', outcomes = ?
+		WHERE id = ? AND status = ? AND attempts = ?`
+	result, err := r.db.ExecContext(ctx, query, string(task.StatusDone), time.Now().UnixNano(), string(data), id,
+		string(task.StatusRunning), attempt)
+	if err != nil {
+		r.logger.Error("failed complete task", err, slog.String("func", "CompleteTask"),
+			slog.String("id", id.String()))
+		return err
+	}
+
+	return r.checkClaim(ctx, id, result)
+}
+
+// RetryTask implements returning the failed task of the claimed attempt to the queue until the run time.
+func (r *repo) RetryTask(ctx context.Context, id uuid.UUID, attempt int, runAt time.Time, reason string) error {
+	return r.setTaskStatus(ctx, id, attempt, task.StatusPending, runAt, reason)
+}
+
+// FailTask implements moving the task of the claimed attempt whose attempts are exhausted to the dead state.
+func (r *repo) FailTask(ctx context.Context, id uuid.UUID, attempt int, reason string) error {
+	return r.setTaskStatus(ctx, id, attempt, task.StatusDead, time.Now(), reason)
+}
+
+// CountTasks implements getting the number of the tasks in the processing state.
+func (r *repo) CountTasks(ctx context.Context, status task.Status) (int, error) {
+	var count int
+	query := "SELECT count(*) FROM tasks WHERE status = ?"
+	err := r.db.QueryRowContext(ctx, query, string(status)).Scan(&count)
+	if err != nil {
+		r.logger.Error("failed count tasks", err, slog.String("func", "CountTasks"))
+		return 0, err
+	}
+	return count, nil
+}
+
+// PurgeTasks implements deleting the tasks processed before the time, the number of deleted tasks is returned.
+func (r *repo) PurgeTasks(ctx context.Context, before time.Time) (int, error) {
+	query := "DELETE FROM tasks WHERE status = ? AND run_at < ?"
+	result, err := r.db.ExecContext(ctx, query, string(task.StatusDone), before.UnixNano())
+	if err != nil {
+		r.logger.Error("failed purge tasks", err, slog.String("func", "PurgeTasks"))
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
+// setTaskStatus implements changing the processing state of the task of the claimed attempt.
+func (r *repo) setTaskStatus(ctx context.Context, id uuid.UUID, attempt int, status task.Status, runAt time.Time,
+	reason string,
+) error {
+	query := "UPDATE tasks SET status = ?, run_at = ?, last_error = ? WHERE id = ? AND status = ? AND attempts = ?"
+	result, err := r.db.ExecContext(ctx, query, string(status), runAt.UnixNano(), reason, id,
+		string(task.StatusRunning), attempt)
+	if err != nil {
+		r.logger.Error("failed set task status", err, slog.String("func", "setTaskStatus"),
+			slog.String("id", id.String()))
+		return err
+	}
+
+	return r.checkClaim(ctx, id, result)
+}
+
+// checkClaim implements getting the error of the statement changing the task of the claimed attempt,
+// task.ErrLeaseLost is returned when the task exists but was left unchanged since it was claimed again.
+func (r *repo) checkClaim(ctx context.Context, id uuid.UUID, result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected > 0 {
+		return nil
+	}
+
+	var exists bool
+	err = r.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM tasks WHERE id = ?)", id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return task.ErrNotFound
+	}
+	return task.ErrLeaseLost
+}
+
+// scanTask implements reading the deferred task from the row of the task columns.
+func scanTask(row row) (task.Task, error) {
+	var (
+		t                task.Task
+		action, status   string
+		urlIDs, outcomes string
+		runAt, createdAt int64
+	)
+	err := row.Scan(&t.ID, &action, &t.UserID, &urlIDs, &status, &t.Attempts, &t.LastError, &outcomes,
+		&runAt, &createdAt)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	if err = json.Unmarshal([]byte(urlIDs), &t.URLIDs); err != nil {
+		return task.Task{}, err
+	}
+	if err = json.Unmarshal([]byte(outcomes), &t.Outcomes); err != nil {
+		return task.Task{}, err
+	}
+
+	t.Action = task.Action(action)
+	t.Status = task.Status(status)
+	t.RunAt = time.Unix(0, runAt).UTC()
+	t.CreatedAt = time.Unix(0, createdAt).UTC()
+	return t, nil
+}
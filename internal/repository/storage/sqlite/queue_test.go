@@ -0,0 +1,124 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+)
+
+func Test_repo_ClaimTasks(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	due := task.New(task.ActionDelete, uuid.New(), []uuid.UUID{uuid.New(), uuid.New()})
+	later := task.New(task.ActionDelete, uuid.New(), []uuid.UUID{uuid.New()})
+	later.RunAt = time.Now().Add(time.Hour)
+	other := task.New(task.Action("archive"), uuid.New(), []uuid.UUID{uuid.New()})
+	assert.NoError(t, r.AddTask(ctx, due))
+	assert.NoError(t, r.AddTask(ctx, later))
+	assert.NoError(t, r.AddTask(ctx, other))
+
+	claimed, err := r.ClaimTasks(ctx, task.ActionDelete, 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, due.ID, claimed[0].ID)
+	assert.Equal(t, due.URLIDs, claimed[0].URLIDs)
+	assert.Equal(t, task.StatusRunning, claimed[0].Status)
+	assert.Equal(t, 1, claimed[0].Attempts)
+
+	// the claimed task is not claimed again until the lease ends
+	claimed, err = r.ClaimTasks(ctx, task.ActionDelete, 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	count, err := r.CountTasks(ctx, task.StatusRunning)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func Test_repo_CompleteTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		complete   bool
+		wantStatus task.Status
+		wantCount  int
+		wantErr    error
+	}{
+		{
+			name:       "positive purge task (done)",
+			complete:   true,
+			wantStatus: task.StatusDone,
+			wantCount:  1,
+			wantErr:    task.ErrNotFound,
+		},
+		{
+			name:       "positive purge task (dead)",
+			wantStatus: task.StatusDead,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			item := task.New(task.ActionDelete, uuid.New(), []uuid.UUID{uuid.New()})
+			assert.NoError(t, r.AddTask(ctx, item))
+			_, err := r.ClaimTasks(ctx, task.ActionDelete, 1, time.Minute)
+			assert.NoError(t, err)
+
+			if tt.complete {
+				assert.NoError(t, r.CompleteTask(ctx, item.ID, 1, map[uuid.UUID]task.Outcome{
+					item.URLIDs[0]: task.OutcomeDeleted,
+				}))
+			} else {
+				assert.NoError(t, r.FailTask(ctx, item.ID, 1, "failed"))
+			}
+
+			got, err := r.GetTask(ctx, item.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, item.UserID, got.UserID)
+			if tt.complete {
+				assert.Equal(t, task.OutcomeDeleted, got.Outcome(item.URLIDs[0]))
+			}
+
+			count, err := r.PurgeTasks(ctx, time.Now().Add(time.Second))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCount, count)
+
+			_, err = r.GetTask(ctx, item.ID)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_repo_RetryTask(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepo(t)
+
+	assert.ErrorIs(t, r.RetryTask(ctx, uuid.New(), 1, time.Now(), "failed"), task.ErrNotFound)
+
+	item := task.New(task.ActionDelete, uuid.New(), []uuid.UUID{uuid.New()})
+	assert.NoError(t, r.AddTask(ctx, item))
+	_, err := r.ClaimTasks(ctx, task.ActionDelete, 1, time.Minute)
+	assert.NoError(t, err)
+
+	assert.NoError(t, r.RetryTask(ctx, item.ID, 1, time.Now(), "failed"))
+	claimed, err := r.ClaimTasks(ctx, task.ActionDelete, 1, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, 2, claimed[0].Attempts)
+	assert.Equal(t, "failed", claimed[0].LastError)
+
+	// the previous claim does not finish the task claimed again
+	assert.ErrorIs(t, r.CompleteTask(ctx, item.ID, 1, nil), task.ErrLeaseLost)
+	assert.ErrorIs(t, r.FailTask(ctx, item.ID, 1, "failed"), task.ErrLeaseLost)
+	assert.NoError(t, r.CompleteTask(ctx, item.ID, 2, nil))
+}
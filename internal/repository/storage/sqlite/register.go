@@ -0,0 +1,28 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/repository/storage/driver"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// DriverName defines the name of the SQLite storage driver.
+const DriverName = "sqlite"
+
+func init() {
+	driver.Register(DriverName, driver.Driver{
+		Open: func(ctx context.Context, cfg config.Storage) (storage.URL, error) {
+			r, err := New(ctx, cfg.GetSQLite())
+			if err != nil {
+				return nil, err
+			}
+			return r, nil
+		},
+		Configured: func(cfg config.Storage) bool {
+			return len(cfg.GetSQLite().GetDSN()) > 0
+		},
+		Priority: 10,
+	})
+}
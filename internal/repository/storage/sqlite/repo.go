@@ -0,0 +1,465 @@
+// Package sqlite implements a repository for storing short URLs in the embedded SQLite storage.
+//
+// The repository uses the pure Go SQLite driver registered by the build with the sqlite tag.
+// The deferred tasks are kept in the tasks table. Workspaces are not stored, so the short URLs are editable in the personal workspace of the user only.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	// migrate tools
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/task"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/workspace"
+)
+
+const (
+	// Scheme defines the scheme of the SQLite storage DSN, e.g. sqlite://./shorturl.db.
+	Scheme = "sqlite://"
+	// driverName defines the name of the registered SQLite driver.
+	driverName = "sqlite"
+)
+
+type (
+	repo struct {
+		db     *sql.DB
+		logger *slog.Logger
+	}
+
+	// row describes the selected row of sql.Row and sql.Rows.
+	row interface {
+		Scan(dest ...interface{}) error
+	}
+)
+
+// Ping implements health check storage.
+func (r *repo) Ping(ctx context.Context) error {
+	if err := r.db.PingContext(ctx); err != nil {
+		r.logger.Error("failed execute empty sql statement", err, slog.String("func", "Ping"))
+		return err
+	}
+	return nil
+}
+
+// Add implements saving short URL.
+func (r *repo) Add(ctx context.Context, item entity.URL) error {
+	query := "INSERT INTO urls (id, user_id, workspace_id, original_url) VALUES (?, ?, ?, ?)"
+	_, err := r.db.ExecContext(ctx, query, item.ID(), item.UserID(), item.WorkspaceID(), item.LongURL())
+	if isUniqueViolation(err) {
+		var id uuid.UUID
+		query = "SELECT id FROM urls WHERE user_id = ? AND original_url = ?"
+		err = r.db.QueryRowContext(ctx, query, item.UserID(), item.LongURL()).Scan(&id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.NewURLErr(item.ID(), item.UserID(), err)
+			}
+			return err
+		}
+		return entity.NewURLErr(id, uuid.UUID{}, entity.ErrAlreadyExist)
+	}
+
+	if err != nil {
+		r.logger.Error("sqlite error", err, slog.String("func", "Add"))
+		return entity.NewURLErr(item.ID(), item.UserID(), err)
+	}
+
+	return nil
+}
+
+// Get implements getting short URL.
+func (r *repo) Get(ctx context.Context, id uuid.UUID) (entity.URL, error) {
+	query := "SELECT id, user_id, workspace_id, original_url, deleted, disabled FROM urls WHERE id = ?"
+	u, err := r.scanURL(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.NewURLErr(id, uuid.UUID{}, entity.ErrNotFound)
+		}
+		return nil, err
+	}
+	return u, nil
+}
+
+// GetByUserID implements getting short URLs for user ID.
+func (r *repo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]entity.URL, error) {
+	query := "SELECT id, user_id, workspace_id, original_url, deleted, disabled FROM urls WHERE user_id = ?"
+	return r.queryURLs(ctx, query, userID)
+}
+
+// GetByWorkspaceID implements getting short URLs of the workspace visible to the user ID,
+// only the personal workspace of the user is visible.
+func (r *repo) GetByWorkspaceID(ctx context.Context, workspaceID, userID uuid.UUID) ([]entity.URL, error) {
+	if !workspace.Personal(workspaceID, userID) {
+		return make([]entity.URL, 0), nil
+	}
+
+	query := "SELECT id, user_id, workspace_id, original_url, deleted, disabled FROM urls WHERE workspace_id = ?"
+	return r.queryURLs(ctx, query, workspaceID)
+}
+
+// ScanIDs implements passing the IDs of all short URLs to fn, the scan stops at the first error of fn.
+func (r *repo) ScanIDs(ctx context.Context, fn func(id uuid.UUID) error) error {
+	rows, err := r.db.QueryContext(ctx, "SELECT id FROM urls")
+	if err != nil {
+		r.logger.Error("failed scan url ids", err, slog.String("func", "ScanIDs"))
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			return err
+		}
+		if err = fn(id); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// CountRecords implements counting the banned users, the storage keeps no other records besides short URLs.
+func (r *repo) CountRecords(ctx context.Context) (map[string]int, error) {
+	var n int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM banned_users").Scan(&n); err != nil {
+		r.logger.Error("failed count records", err, slog.String("func", "CountRecords"))
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	if n > 0 {
+		counts["banned_users"] = n
+	}
+	return counts, nil
+}
+
+// Import implements saving short URLs copied from another storage with all their attributes,
+// the short URLs stored under the same ID are replaced. Nothing is saved when one of them conflicts.
+func (r *repo) Import(ctx context.Context, urls []entity.URL) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query := `INSERT INTO urls (id, user_id, workspace_id, original_url, deleted, disabled)
+		VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT (id) DO UPDATE SET user_id = excluded.user_id,
+		workspace_id = excluded.workspace_id, original_url = excluded.original_url,
+		deleted = excluded.deleted, disabled = excluded.disabled`
+	for _, item := range urls {
+		_, err = tx.ExecContext(ctx, query, item.ID(), item.UserID(), item.WorkspaceID(), item.LongURL(),
+			item.Deleted(), item.Disabled())
+		if isUniqueViolation(err) {
+			return entity.NewURLErr(item.ID(), item.UserID(), entity.ErrAlreadyExist)
+		}
+
+		if err != nil {
+			r.logger.Error("sqlite error", err, slog.String("func", "Import"))
+			return entity.NewURLErr(item.ID(), item.UserID(), err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Update implements changing the long URL and the owning workspace of the short URL.
+func (r *repo) Update(ctx context.Context, item entity.URL) error {
+	if !workspace.Personal(item.WorkspaceID(), item.UserID()) {
+		return entity.NewURLErr(item.ID(), item.UserID(), workspace.ErrForbidden)
+	}
+
+	query := "UPDATE urls SET original_url = ?, workspace_id = ? WHERE id = ? AND workspace_id = ?"
+	result, err := r.db.ExecContext(ctx, query, item.LongURL(), item.WorkspaceID(), item.ID(), item.UserID())
+	if isUniqueViolation(err) {
+		return entity.NewURLErr(item.ID(), item.UserID(), entity.ErrAlreadyExist)
+	}
+
+	if err != nil {
+		r.logger.Error("sqlite error", err, slog.String("func", "Update"))
+		return entity.NewURLErr(item.ID(), item.UserID(), err)
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected > 0 {
+		return err
+	}
+
+	var exists bool
+	query = "SELECT EXISTS (SELECT 1 FROM urls WHERE id = ?)"
+	if err = r.db.QueryRowContext(ctx, query, item.ID()).Scan(&exists); err != nil {
+		return err
+	}
+
+	if !exists {
+		return entity.NewURLErr(item.ID(), item.UserID(), entity.ErrNotFound)
+	}
+
+	return entity.NewURLErr(item.ID(), item.UserID(), workspace.ErrForbidden)
+}
+
+// Close implements closing the connection to the storage.
+func (r *repo) Close() error {
+	return r.db.Close()
+}
+
+// Batch implements saving multiple short URLs, no short URL is saved when some of them exist.
+// The returned error keeps the existing IDs, items repeating a URL of the batch are not reported as existing.
+func (r *repo) Batch(ctx context.Context, urls []entity.URL) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		inserted = make(map[uuid.UUID]bool, len(urls))
+		existing = make(map[uuid.UUID]uuid.UUID)
+		conflict bool
+	)
+	query := "INSERT INTO urls (id, user_id, workspace_id, original_url) VALUES (?, ?, ?, ?)"
+	for _, item := range urls {
+		_, err = tx.ExecContext(ctx, query, item.ID(), item.UserID(), item.WorkspaceID(), item.LongURL())
+		if isUniqueViolation(err) {
+			var id uuid.UUID
+			err = tx.QueryRowContext(ctx, "SELECT id FROM urls WHERE user_id = ? AND original_url = ?",
+				item.UserID(), item.LongURL()).Scan(&id)
+			if err != nil {
+				return entity.NewURLErr(item.ID(), item.UserID(), err)
+			}
+
+			conflict = true
+			if !inserted[id] {
+				existing[item.ID()] = id
+			}
+			continue
+		}
+
+		if err != nil {
+			r.logger.Error("sqlite error", err, slog.String("func", "Batch"))
+			return entity.NewURLErr(item.ID(), item.UserID(), err)
+		}
+		inserted[item.ID()] = true
+	}
+
+	if conflict {
+		return entity.NewBatchErr(existing, entity.ErrAlreadyExist)
+	}
+	return tx.Commit()
+}
+
+// BatchDelete implements the deletion multiple short URLs.
+func (r *repo) BatchDelete(ctx context.Context, urls []entity.URL) (map[uuid.UUID]task.Outcome, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query := "SELECT workspace_id FROM urls WHERE id = ?"
+	outcomes := make(map[uuid.UUID]task.Outcome, len(urls))
+	for _, item := range urls {
+		var workspaceID uuid.UUID
+		err = tx.QueryRowContext(ctx, query, item.ID()).Scan(&workspaceID)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			outcomes[item.ID()] = task.OutcomeNotFound
+			continue
+		case err != nil:
+			r.logger.Error("failed check url", err, slog.String("func", "BatchDelete"))
+			return nil, entity.NewURLErr(item.ID(), item.UserID(), err)
+		case !workspace.Personal(workspaceID, item.UserID()):
+			outcomes[item.ID()] = task.OutcomeNotOwned
+			continue
+		}
+
+		if _, err = tx.ExecContext(ctx, "UPDATE urls SET deleted = true WHERE id = ?", item.ID()); err != nil {
+			r.logger.Error("failed update url", err, slog.String("func", "BatchDelete"))
+			return nil, entity.NewURLErr(item.ID(), item.UserID(), err)
+		}
+		outcomes[item.ID()] = task.OutcomeDeleted
+	}
+
+	return outcomes, tx.Commit()
+}
+
+// ChangeOwner implements moving short URLs to another user ID.
+// URLs already shortened by the new owner stay with the previous one,
+// URLs of the personal workspace are moved to the personal workspace of the new owner.
+func (r *repo) ChangeOwner(ctx context.Context, from, to uuid.UUID) error {
+	query := `UPDATE urls SET user_id = ?2,
+		workspace_id = CASE WHEN workspace_id = ?1 THEN ?2 ELSE workspace_id END
+		WHERE user_id = ?1 AND original_url NOT IN
+		(SELECT original_url FROM urls WHERE user_id = ?2)`
+	if _, err := r.db.ExecContext(ctx, query, from, to); err != nil {
+		r.logger.Error("failed change url owner", err, slog.String("func", "ChangeOwner"))
+		return err
+	}
+	return nil
+}
+
+// GetUserCount implements the getting user count stat.
+func (r *repo) GetUserCount(ctx context.Context) (int, error) {
+	var counter int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(DISTINCT user_id) FROM urls").Scan(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter, nil
+}
+
+// GetURLCount implements the getting url count stat.
+func (r *repo) GetURLCount(ctx context.Context) (int, error) {
+	var counter int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(id) FROM urls").Scan(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter, nil
+}
+
+// queryURLs implements getting short URLs selected by the query.
+func (r *repo) queryURLs(ctx context.Context, query string, args ...interface{}) ([]entity.URL, error) {
+	urls := make([]entity.URL, 0)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		u, err := r.scanURL(rows)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, u)
+	}
+
+	return urls, rows.Err()
+}
+
+// scanURL implements reading the short URL from the selected id, user_id, workspace_id,
+// original_url, deleted and disabled columns.
+func (r *repo) scanURL(row row) (entity.URL, error) {
+	var (
+		id          uuid.UUID
+		userID      uuid.UUID
+		workspaceID uuid.UUID
+		rawURL      string
+		deleted     bool
+		disabled    bool
+	)
+	if err := row.Scan(&id, &userID, &workspaceID, &rawURL, &deleted, &disabled); err != nil {
+		return nil, err
+	}
+
+	value, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		r.logger.Error("failed parse raw url", err, slog.String("func", "scanURL"),
+			slog.String("url", rawURL))
+		return nil, err
+	}
+
+	u := entity.NewURL(id, userID)
+	u.SetWorkspaceID(workspaceID)
+	u.SetLongURL(*value)
+	u.SetDeleted(deleted)
+	u.SetDisabled(disabled)
+	return u, nil
+}
+
+// isUniqueViolation implements checking the unique constraint error, the error code is not exposed
+// by the database/sql interface, so the message of SQLite is matched.
+func isUniqueViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// migrate implements run migrations.
+func (r *repo) migrate(migrateURL, dsn string) error {
+	m, err := migrate.New(migrateURL, dsn)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = m.Close()
+	}()
+
+	err = m.Up()
+	if errors.Is(err, migrate.ErrNoChange) {
+		r.logger.Info("no change", slog.String("func", "migrate"))
+		return nil
+	}
+
+	return err
+}
+
+// registered implements checking the SQLite driver is built in.
+func registered() bool {
+	for _, name := range sql.Drivers() {
+		if name == driverName {
+			return true
+		}
+	}
+	return false
+}
+
+// New implements the creation of storage from the DSN with the sqlite scheme.
+func New(ctx context.Context, cfg config.SQLite) (*repo, error) {
+	log := slog.New(slog.NewJSONHandler(os.Stdout).
+		WithAttrs([]slog.Attr{slog.String("repository", "sqlite")}))
+
+	dsn := cfg.GetDSN()
+	if !strings.HasPrefix(dsn, Scheme) {
+		return nil, ErrInvalidDSN
+	}
+
+	if !registered() {
+		return nil, ErrDriverNotBuilt
+	}
+
+	db, err := sql.Open(driverName, strings.TrimPrefix(dsn, Scheme))
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows a single writer, the single connection serializes the writes instead of failing them as busy
+	db.SetMaxOpenConns(1)
+
+	if err = db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	r := &repo{
+		db:     db,
+		logger: log,
+	}
+
+	if len(cfg.GetMigrateURL()) == 0 {
+		return r, nil
+	}
+
+	err = r.migrate(cfg.GetMigrateURL(), dsn)
+	if err != nil {
+		log.Error("failed apply migrations", err, slog.String("func", "migrate"))
+	}
+
+	return r, nil
+}
@@ -3,23 +3,159 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/sreway/shorturl/internal/domain/account"
+	"github.com/sreway/shorturl/internal/domain/audit"
+	"github.com/sreway/shorturl/internal/domain/outbox"
+	"github.com/sreway/shorturl/internal/domain/report"
+	"github.com/sreway/shorturl/internal/domain/task"
 	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/webhook"
+	"github.com/sreway/shorturl/internal/domain/workspace"
 )
 
 // URL describes the implementation of storage for storing short URLs.
 //
+// The user ID of the short URLs passed to Update and BatchDelete is the acting user,
+// the change is applied only when the user is an editor of the owning workspace.
+// BatchDelete reports the outcome for every passed short URL.
+//
 //go:generate mockgen -source=./internal/usecases/adapters/storage/interfaces.go -destination=./internal/usecases/adapters/storage/mock/mock_url.go -package=storageMock
 type URL interface {
 	Add(ctx context.Context, url entity.URL) error
 	Get(ctx context.Context, id uuid.UUID) (entity.URL, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]entity.URL, error)
+	GetByWorkspaceID(ctx context.Context, workspaceID, userID uuid.UUID) ([]entity.URL, error)
+	Update(ctx context.Context, url entity.URL) error
 	Batch(ctx context.Context, urls []entity.URL) error
-	BatchDelete(ctx context.Context, urls []entity.URL) error
+	BatchDelete(ctx context.Context, urls []entity.URL) (map[uuid.UUID]task.Outcome, error)
+	ChangeOwner(ctx context.Context, from, to uuid.UUID) error
+	Search(ctx context.Context, filter entity.Filter) ([]entity.URL, error)
+	SetDisabled(ctx context.Context, id uuid.UUID, disabled bool) error
+	ForceDelete(ctx context.Context, ids []uuid.UUID) error
+	BanUser(ctx context.Context, userID uuid.UUID, banned bool) error
+	IsBanned(ctx context.Context, userID uuid.UUID) (bool, error)
 	Ping(ctx context.Context) error
 	GetUserCount(ctx context.Context) (int, error)
 	GetURLCount(ctx context.Context) (int, error)
 	Close() error
 }
+
+// Account describes the implementation of storage for storing registered user accounts.
+type Account interface {
+	AddAccount(ctx context.Context, item account.Account) error
+	GetAccount(ctx context.Context, email string) (account.Account, error)
+}
+
+// Workspace describes the implementation of storage for storing workspaces and their members.
+type Workspace interface {
+	AddWorkspace(ctx context.Context, item workspace.Workspace, ownerID uuid.UUID) error
+	GetWorkspaces(ctx context.Context, userID uuid.UUID) ([]workspace.Workspace, error)
+	GetRole(ctx context.Context, workspaceID, userID uuid.UUID) (workspace.Role, error)
+	SetMember(ctx context.Context, workspaceID, userID uuid.UUID, role workspace.Role) error
+	DeleteMember(ctx context.Context, workspaceID, userID uuid.UUID) error
+}
+
+// Report describes the implementation of storage for storing short URL abuse reports.
+//
+// Reports stay open until a moderator resolves them, a reporter has at most one open report per short URL.
+type Report interface {
+	AddReport(ctx context.Context, item report.Report) error
+	CountReports(ctx context.Context, urlID uuid.UUID) (int, error)
+	GetReportQueue(ctx context.Context, limit, offset int) ([]report.Summary, error)
+	ResolveReports(ctx context.Context, urlID uuid.UUID) error
+}
+
+// Audit describes the implementation of append-only storage for storing audit log events.
+type Audit interface {
+	AddEvents(ctx context.Context, events []audit.Event) error
+	GetEvents(ctx context.Context, filter audit.Filter) ([]audit.Event, error)
+}
+
+// Webhook describes the implementation of storage for storing webhooks and their failed deliveries.
+type Webhook interface {
+	AddWebhook(ctx context.Context, item webhook.Webhook) error
+	GetWebhooks(ctx context.Context, userID uuid.UUID) ([]webhook.Webhook, error)
+	DeleteWebhook(ctx context.Context, id, userID uuid.UUID) error
+	AddDeadLetter(ctx context.Context, item webhook.Delivery) error
+	GetDeadLetters(ctx context.Context, userID uuid.UUID) ([]webhook.Delivery, error)
+}
+
+// Outbox describes the implementation of storage for storing short URL events written in the same transaction
+// as the short URLs until they are dispatched.
+type Outbox interface {
+	DispatchOutbox(ctx context.Context, limit int,
+		dispatch func(ctx context.Context, messages []outbox.Message) error) (int, error)
+	PurgeOutbox(ctx context.Context, before time.Time) (int, error)
+}
+
+// Queue describes the implementation of durable storage for storing deferred tasks.
+//
+// ClaimTasks marks due tasks of the action running until the lease ends and counts the attempt,
+// tasks whose lease ended without completion are claimed again. The attempt number set by the claim identifies it,
+// CompleteTask, RetryTask and FailTask change the running task of the attempt only and return task.ErrLeaseLost
+// when the task was claimed again meanwhile.
+type Queue interface {
+	AddTask(ctx context.Context, item task.Task) error
+	ClaimTasks(ctx context.Context, action task.Action, limit int, lease time.Duration) ([]task.Task, error)
+	GetTask(ctx context.Context, id uuid.UUID) (task.Task, error)
+	CompleteTask(ctx context.Context, id uuid.UUID, attempt int, outcomes map[uuid.UUID]task.Outcome) error
+	RetryTask(ctx context.Context, id uuid.UUID, attempt int, runAt time.Time, reason string) error
+	FailTask(ctx context.Context, id uuid.UUID, attempt int, reason string) error
+	CountTasks(ctx context.Context, status task.Status) (int, error)
+	PurgeTasks(ctx context.Context, before time.Time) (int, error)
+}
+
+// Locker describes the implementation of storage for the session locks shared by the application replicas.
+//
+// TryLock returns false when the lock is held by another session. The acquired lock is held until it is released
+// or its session ends, Check fails once the session is lost.
+type Locker interface {
+	TryLock(ctx context.Context, key int64) (Lock, bool, error)
+}
+
+// Lock describes the implementation of the session lock held by the replica.
+type Lock interface {
+	Check(ctx context.Context) error
+	Release(ctx context.Context) error
+}
+
+// Invalidation describes the implementation of storage publishing the changes of short URLs to the replicas.
+//
+// ListenInvalidations passes the IDs of short URLs created or changed by any replica to evict until the context is done.
+// The lost connection is restored automatically and flush is called then, since changes made meanwhile are missed.
+type Invalidation interface {
+	ListenInvalidations(ctx context.Context, evict func(ids []uuid.UUID), flush func()) error
+}
+
+// IDScanner describes the implementation of storage listing the IDs of all short URLs, including deleted ones.
+type IDScanner interface {
+	ScanIDs(ctx context.Context, fn func(id uuid.UUID) error) error
+}
+
+// Importer describes the implementation of storage accepting short URLs copied from another storage.
+//
+// Import keeps the ID, owner, workspace, deletion and moderation attributes of short URLs and replaces the short URLs
+// stored under the same ID, so the import can be repeated. Events of the imported short URLs are not published.
+type Importer interface {
+	Import(ctx context.Context, urls []entity.URL) error
+}
+
+// Inventory describes the implementation of storage counting the records it keeps besides short URLs.
+//
+// CountRecords returns the number of records by the name of the store, the stores without records are omitted.
+type Inventory interface {
+	CountRecords(ctx context.Context) (map[string]int, error)
+}
+
+// Switchover describes the implementation of storage writing short URLs to the primary and the secondary storage.
+//
+// Promote makes the secondary storage primary and returns the name of its driver, Primary returns the name
+// of the driver of the current primary storage.
+type Switchover interface {
+	Promote(ctx context.Context) (string, error)
+	Primary() string
+}
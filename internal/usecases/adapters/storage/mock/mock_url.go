@@ -7,10 +7,19 @@ package storageMock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
+	account "github.com/sreway/shorturl/internal/domain/account"
+	audit "github.com/sreway/shorturl/internal/domain/audit"
+	outbox "github.com/sreway/shorturl/internal/domain/outbox"
+	report "github.com/sreway/shorturl/internal/domain/report"
+	task "github.com/sreway/shorturl/internal/domain/task"
 	url "github.com/sreway/shorturl/internal/domain/url"
+	webhook "github.com/sreway/shorturl/internal/domain/webhook"
+	workspace "github.com/sreway/shorturl/internal/domain/workspace"
+	storage "github.com/sreway/shorturl/internal/usecases/adapters/storage"
 )
 
 // MockURL is a mock of URL interface.
@@ -50,6 +59,20 @@ func (mr *MockURLMockRecorder) Add(ctx, url interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockURL)(nil).Add), ctx, url)
 }
 
+// BanUser mocks base method.
+func (m *MockURL) BanUser(ctx context.Context, userID uuid.UUID, banned bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanUser", ctx, userID, banned)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BanUser indicates an expected call of BanUser.
+func (mr *MockURLMockRecorder) BanUser(ctx, userID, banned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanUser", reflect.TypeOf((*MockURL)(nil).BanUser), ctx, userID, banned)
+}
+
 // Batch mocks base method.
 func (m *MockURL) Batch(ctx context.Context, urls []url.URL) error {
 	m.ctrl.T.Helper()
@@ -65,11 +88,12 @@ func (mr *MockURLMockRecorder) Batch(ctx, urls interface{}) *gomock.Call {
 }
 
 // BatchDelete mocks base method.
-func (m *MockURL) BatchDelete(ctx context.Context, urls []url.URL) error {
+func (m *MockURL) BatchDelete(ctx context.Context, urls []url.URL) (map[uuid.UUID]task.Outcome, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "BatchDelete", ctx, urls)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(map[uuid.UUID]task.Outcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // BatchDelete indicates an expected call of BatchDelete.
@@ -78,6 +102,20 @@ func (mr *MockURLMockRecorder) BatchDelete(ctx, urls interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockURL)(nil).BatchDelete), ctx, urls)
 }
 
+// ChangeOwner mocks base method.
+func (m *MockURL) ChangeOwner(ctx context.Context, from, to uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeOwner", ctx, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeOwner indicates an expected call of ChangeOwner.
+func (mr *MockURLMockRecorder) ChangeOwner(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeOwner", reflect.TypeOf((*MockURL)(nil).ChangeOwner), ctx, from, to)
+}
+
 // Close mocks base method.
 func (m *MockURL) Close() error {
 	m.ctrl.T.Helper()
@@ -92,6 +130,20 @@ func (mr *MockURLMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockURL)(nil).Close))
 }
 
+// ForceDelete mocks base method.
+func (m *MockURL) ForceDelete(ctx context.Context, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceDelete", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceDelete indicates an expected call of ForceDelete.
+func (mr *MockURLMockRecorder) ForceDelete(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceDelete", reflect.TypeOf((*MockURL)(nil).ForceDelete), ctx, ids)
+}
+
 // Get mocks base method.
 func (m *MockURL) Get(ctx context.Context, id uuid.UUID) (url.URL, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +174,21 @@ func (mr *MockURLMockRecorder) GetByUserID(ctx, userID interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockURL)(nil).GetByUserID), ctx, userID)
 }
 
+// GetByWorkspaceID mocks base method.
+func (m *MockURL) GetByWorkspaceID(ctx context.Context, workspaceID, userID uuid.UUID) ([]url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByWorkspaceID", ctx, workspaceID, userID)
+	ret0, _ := ret[0].([]url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByWorkspaceID indicates an expected call of GetByWorkspaceID.
+func (mr *MockURLMockRecorder) GetByWorkspaceID(ctx, workspaceID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByWorkspaceID", reflect.TypeOf((*MockURL)(nil).GetByWorkspaceID), ctx, workspaceID, userID)
+}
+
 // GetURLCount mocks base method.
 func (m *MockURL) GetURLCount(ctx context.Context) (int, error) {
 	m.ctrl.T.Helper()
@@ -152,6 +219,21 @@ func (mr *MockURLMockRecorder) GetUserCount(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCount", reflect.TypeOf((*MockURL)(nil).GetUserCount), ctx)
 }
 
+// IsBanned mocks base method.
+func (m *MockURL) IsBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBanned", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBanned indicates an expected call of IsBanned.
+func (mr *MockURLMockRecorder) IsBanned(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBanned", reflect.TypeOf((*MockURL)(nil).IsBanned), ctx, userID)
+}
+
 // Ping mocks base method.
 func (m *MockURL) Ping(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -165,3 +247,904 @@ func (mr *MockURLMockRecorder) Ping(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockURL)(nil).Ping), ctx)
 }
+
+// Search mocks base method.
+func (m *MockURL) Search(ctx context.Context, filter url.Filter) ([]url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, filter)
+	ret0, _ := ret[0].([]url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockURLMockRecorder) Search(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockURL)(nil).Search), ctx, filter)
+}
+
+// SetDisabled mocks base method.
+func (m *MockURL) SetDisabled(ctx context.Context, id uuid.UUID, disabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDisabled", ctx, id, disabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDisabled indicates an expected call of SetDisabled.
+func (mr *MockURLMockRecorder) SetDisabled(ctx, id, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisabled", reflect.TypeOf((*MockURL)(nil).SetDisabled), ctx, id, disabled)
+}
+
+// Update mocks base method.
+func (m *MockURL) Update(ctx context.Context, url url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockURLMockRecorder) Update(ctx, url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockURL)(nil).Update), ctx, url)
+}
+
+// MockAccount is a mock of Account interface.
+type MockAccount struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountMockRecorder
+}
+
+// MockAccountMockRecorder is the mock recorder for MockAccount.
+type MockAccountMockRecorder struct {
+	mock *MockAccount
+}
+
+// NewMockAccount creates a new mock instance.
+func NewMockAccount(ctrl *gomock.Controller) *MockAccount {
+	mock := &MockAccount{ctrl: ctrl}
+	mock.recorder = &MockAccountMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccount) EXPECT() *MockAccountMockRecorder {
+	return m.recorder
+}
+
+// AddAccount mocks base method.
+func (m *MockAccount) AddAccount(ctx context.Context, item account.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAccount", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAccount indicates an expected call of AddAccount.
+func (mr *MockAccountMockRecorder) AddAccount(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccount", reflect.TypeOf((*MockAccount)(nil).AddAccount), ctx, item)
+}
+
+// GetAccount mocks base method.
+func (m *MockAccount) GetAccount(ctx context.Context, email string) (account.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, email)
+	ret0, _ := ret[0].(account.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccountMockRecorder) GetAccount(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccount)(nil).GetAccount), ctx, email)
+}
+
+// MockWorkspace is a mock of Workspace interface.
+type MockWorkspace struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceMockRecorder
+}
+
+// MockWorkspaceMockRecorder is the mock recorder for MockWorkspace.
+type MockWorkspaceMockRecorder struct {
+	mock *MockWorkspace
+}
+
+// NewMockWorkspace creates a new mock instance.
+func NewMockWorkspace(ctrl *gomock.Controller) *MockWorkspace {
+	mock := &MockWorkspace{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspace) EXPECT() *MockWorkspaceMockRecorder {
+	return m.recorder
+}
+
+// AddWorkspace mocks base method.
+func (m *MockWorkspace) AddWorkspace(ctx context.Context, item workspace.Workspace, ownerID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWorkspace", ctx, item, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWorkspace indicates an expected call of AddWorkspace.
+func (mr *MockWorkspaceMockRecorder) AddWorkspace(ctx, item, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkspace", reflect.TypeOf((*MockWorkspace)(nil).AddWorkspace), ctx, item, ownerID)
+}
+
+// DeleteMember mocks base method.
+func (m *MockWorkspace) DeleteMember(ctx context.Context, workspaceID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", ctx, workspaceID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockWorkspaceMockRecorder) DeleteMember(ctx, workspaceID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockWorkspace)(nil).DeleteMember), ctx, workspaceID, userID)
+}
+
+// GetRole mocks base method.
+func (m *MockWorkspace) GetRole(ctx context.Context, workspaceID, userID uuid.UUID) (workspace.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", ctx, workspaceID, userID)
+	ret0, _ := ret[0].(workspace.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockWorkspaceMockRecorder) GetRole(ctx, workspaceID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockWorkspace)(nil).GetRole), ctx, workspaceID, userID)
+}
+
+// GetWorkspaces mocks base method.
+func (m *MockWorkspace) GetWorkspaces(ctx context.Context, userID uuid.UUID) ([]workspace.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaces", ctx, userID)
+	ret0, _ := ret[0].([]workspace.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaces indicates an expected call of GetWorkspaces.
+func (mr *MockWorkspaceMockRecorder) GetWorkspaces(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaces", reflect.TypeOf((*MockWorkspace)(nil).GetWorkspaces), ctx, userID)
+}
+
+// SetMember mocks base method.
+func (m *MockWorkspace) SetMember(ctx context.Context, workspaceID, userID uuid.UUID, role workspace.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMember", ctx, workspaceID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMember indicates an expected call of SetMember.
+func (mr *MockWorkspaceMockRecorder) SetMember(ctx, workspaceID, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMember", reflect.TypeOf((*MockWorkspace)(nil).SetMember), ctx, workspaceID, userID, role)
+}
+
+// MockReport is a mock of Report interface.
+type MockReport struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportMockRecorder
+}
+
+// MockReportMockRecorder is the mock recorder for MockReport.
+type MockReportMockRecorder struct {
+	mock *MockReport
+}
+
+// NewMockReport creates a new mock instance.
+func NewMockReport(ctrl *gomock.Controller) *MockReport {
+	mock := &MockReport{ctrl: ctrl}
+	mock.recorder = &MockReportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReport) EXPECT() *MockReportMockRecorder {
+	return m.recorder
+}
+
+// AddReport mocks base method.
+func (m *MockReport) AddReport(ctx context.Context, item report.Report) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReport", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReport indicates an expected call of AddReport.
+func (mr *MockReportMockRecorder) AddReport(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReport", reflect.TypeOf((*MockReport)(nil).AddReport), ctx, item)
+}
+
+// CountReports mocks base method.
+func (m *MockReport) CountReports(ctx context.Context, urlID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReports", ctx, urlID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReports indicates an expected call of CountReports.
+func (mr *MockReportMockRecorder) CountReports(ctx, urlID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReports", reflect.TypeOf((*MockReport)(nil).CountReports), ctx, urlID)
+}
+
+// GetReportQueue mocks base method.
+func (m *MockReport) GetReportQueue(ctx context.Context, limit, offset int) ([]report.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportQueue", ctx, limit, offset)
+	ret0, _ := ret[0].([]report.Summary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportQueue indicates an expected call of GetReportQueue.
+func (mr *MockReportMockRecorder) GetReportQueue(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportQueue", reflect.TypeOf((*MockReport)(nil).GetReportQueue), ctx, limit, offset)
+}
+
+// ResolveReports mocks base method.
+func (m *MockReport) ResolveReports(ctx context.Context, urlID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveReports", ctx, urlID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveReports indicates an expected call of ResolveReports.
+func (mr *MockReportMockRecorder) ResolveReports(ctx, urlID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReports", reflect.TypeOf((*MockReport)(nil).ResolveReports), ctx, urlID)
+}
+
+// MockAudit is a mock of Audit interface.
+type MockAudit struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditMockRecorder
+}
+
+// MockAuditMockRecorder is the mock recorder for MockAudit.
+type MockAuditMockRecorder struct {
+	mock *MockAudit
+}
+
+// NewMockAudit creates a new mock instance.
+func NewMockAudit(ctrl *gomock.Controller) *MockAudit {
+	mock := &MockAudit{ctrl: ctrl}
+	mock.recorder = &MockAuditMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudit) EXPECT() *MockAuditMockRecorder {
+	return m.recorder
+}
+
+// AddEvents mocks base method.
+func (m *MockAudit) AddEvents(ctx context.Context, events []audit.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEvents indicates an expected call of AddEvents.
+func (mr *MockAuditMockRecorder) AddEvents(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvents", reflect.TypeOf((*MockAudit)(nil).AddEvents), ctx, events)
+}
+
+// GetEvents mocks base method.
+func (m *MockAudit) GetEvents(ctx context.Context, filter audit.Filter) ([]audit.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", ctx, filter)
+	ret0, _ := ret[0].([]audit.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockAuditMockRecorder) GetEvents(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockAudit)(nil).GetEvents), ctx, filter)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// AddDeadLetter mocks base method.
+func (m *MockWebhook) AddDeadLetter(ctx context.Context, item webhook.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeadLetter", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDeadLetter indicates an expected call of AddDeadLetter.
+func (mr *MockWebhookMockRecorder) AddDeadLetter(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeadLetter", reflect.TypeOf((*MockWebhook)(nil).AddDeadLetter), ctx, item)
+}
+
+// AddWebhook mocks base method.
+func (m *MockWebhook) AddWebhook(ctx context.Context, item webhook.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhook", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWebhook indicates an expected call of AddWebhook.
+func (mr *MockWebhookMockRecorder) AddWebhook(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhook", reflect.TypeOf((*MockWebhook)(nil).AddWebhook), ctx, item)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhook) DeleteWebhook(ctx context.Context, id, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookMockRecorder) DeleteWebhook(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhook)(nil).DeleteWebhook), ctx, id, userID)
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockWebhook) GetDeadLetters(ctx context.Context, userID uuid.UUID) ([]webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters", ctx, userID)
+	ret0, _ := ret[0].([]webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockWebhookMockRecorder) GetDeadLetters(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockWebhook)(nil).GetDeadLetters), ctx, userID)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhook) GetWebhooks(ctx context.Context, userID uuid.UUID) ([]webhook.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx, userID)
+	ret0, _ := ret[0].([]webhook.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookMockRecorder) GetWebhooks(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhook)(nil).GetWebhooks), ctx, userID)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// DispatchOutbox mocks base method.
+func (m *MockOutbox) DispatchOutbox(ctx context.Context, limit int, dispatch func(context.Context, []outbox.Message) error) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchOutbox", ctx, limit, dispatch)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DispatchOutbox indicates an expected call of DispatchOutbox.
+func (mr *MockOutboxMockRecorder) DispatchOutbox(ctx, limit, dispatch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchOutbox", reflect.TypeOf((*MockOutbox)(nil).DispatchOutbox), ctx, limit, dispatch)
+}
+
+// PurgeOutbox mocks base method.
+func (m *MockOutbox) PurgeOutbox(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOutbox", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeOutbox indicates an expected call of PurgeOutbox.
+func (mr *MockOutboxMockRecorder) PurgeOutbox(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOutbox", reflect.TypeOf((*MockOutbox)(nil).PurgeOutbox), ctx, before)
+}
+
+// MockQueue is a mock of Queue interface.
+type MockQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueueMockRecorder
+}
+
+// MockQueueMockRecorder is the mock recorder for MockQueue.
+type MockQueueMockRecorder struct {
+	mock *MockQueue
+}
+
+// NewMockQueue creates a new mock instance.
+func NewMockQueue(ctrl *gomock.Controller) *MockQueue {
+	mock := &MockQueue{ctrl: ctrl}
+	mock.recorder = &MockQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueue) EXPECT() *MockQueueMockRecorder {
+	return m.recorder
+}
+
+// AddTask mocks base method.
+func (m *MockQueue) AddTask(ctx context.Context, item task.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTask", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTask indicates an expected call of AddTask.
+func (mr *MockQueueMockRecorder) AddTask(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTask", reflect.TypeOf((*MockQueue)(nil).AddTask), ctx, item)
+}
+
+// ClaimTasks mocks base method.
+func (m *MockQueue) ClaimTasks(ctx context.Context, action task.Action, limit int, lease time.Duration) ([]task.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTasks", ctx, action, limit, lease)
+	ret0, _ := ret[0].([]task.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimTasks indicates an expected call of ClaimTasks.
+func (mr *MockQueueMockRecorder) ClaimTasks(ctx, action, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTasks", reflect.TypeOf((*MockQueue)(nil).ClaimTasks), ctx, action, limit, lease)
+}
+
+// CompleteTask mocks base method.
+func (m *MockQueue) CompleteTask(ctx context.Context, id uuid.UUID, attempt int, outcomes map[uuid.UUID]task.Outcome) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteTask", ctx, id, attempt, outcomes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteTask indicates an expected call of CompleteTask.
+func (mr *MockQueueMockRecorder) CompleteTask(ctx, id, attempt, outcomes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteTask", reflect.TypeOf((*MockQueue)(nil).CompleteTask), ctx, id, attempt, outcomes)
+}
+
+// CountTasks mocks base method.
+func (m *MockQueue) CountTasks(ctx context.Context, status task.Status) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTasks", ctx, status)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTasks indicates an expected call of CountTasks.
+func (mr *MockQueueMockRecorder) CountTasks(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTasks", reflect.TypeOf((*MockQueue)(nil).CountTasks), ctx, status)
+}
+
+// FailTask mocks base method.
+func (m *MockQueue) FailTask(ctx context.Context, id uuid.UUID, attempt int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailTask", ctx, id, attempt, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailTask indicates an expected call of FailTask.
+func (mr *MockQueueMockRecorder) FailTask(ctx, id, attempt, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailTask", reflect.TypeOf((*MockQueue)(nil).FailTask), ctx, id, attempt, reason)
+}
+
+// GetTask mocks base method.
+func (m *MockQueue) GetTask(ctx context.Context, id uuid.UUID) (task.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", ctx, id)
+	ret0, _ := ret[0].(task.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockQueueMockRecorder) GetTask(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockQueue)(nil).GetTask), ctx, id)
+}
+
+// PurgeTasks mocks base method.
+func (m *MockQueue) PurgeTasks(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTasks", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTasks indicates an expected call of PurgeTasks.
+func (mr *MockQueueMockRecorder) PurgeTasks(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTasks", reflect.TypeOf((*MockQueue)(nil).PurgeTasks), ctx, before)
+}
+
+// RetryTask mocks base method.
+func (m *MockQueue) RetryTask(ctx context.Context, id uuid.UUID, attempt int, runAt time.Time, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryTask", ctx, id, attempt, runAt, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryTask indicates an expected call of RetryTask.
+func (mr *MockQueueMockRecorder) RetryTask(ctx, id, attempt, runAt, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryTask", reflect.TypeOf((*MockQueue)(nil).RetryTask), ctx, id, attempt, runAt, reason)
+}
+
+// MockLocker is a mock of Locker interface.
+type MockLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockerMockRecorder
+}
+
+// MockLockerMockRecorder is the mock recorder for MockLocker.
+type MockLockerMockRecorder struct {
+	mock *MockLocker
+}
+
+// NewMockLocker creates a new mock instance.
+func NewMockLocker(ctrl *gomock.Controller) *MockLocker {
+	mock := &MockLocker{ctrl: ctrl}
+	mock.recorder = &MockLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocker) EXPECT() *MockLockerMockRecorder {
+	return m.recorder
+}
+
+// TryLock mocks base method.
+func (m *MockLocker) TryLock(ctx context.Context, key int64) (storage.Lock, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLock", ctx, key)
+	ret0, _ := ret[0].(storage.Lock)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TryLock indicates an expected call of TryLock.
+func (mr *MockLockerMockRecorder) TryLock(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLock", reflect.TypeOf((*MockLocker)(nil).TryLock), ctx, key)
+}
+
+// MockLock is a mock of Lock interface.
+type MockLock struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockMockRecorder
+}
+
+// MockLockMockRecorder is the mock recorder for MockLock.
+type MockLockMockRecorder struct {
+	mock *MockLock
+}
+
+// NewMockLock creates a new mock instance.
+func NewMockLock(ctrl *gomock.Controller) *MockLock {
+	mock := &MockLock{ctrl: ctrl}
+	mock.recorder = &MockLockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLock) EXPECT() *MockLockMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockLock) Check(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockLockMockRecorder) Check(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockLock)(nil).Check), ctx)
+}
+
+// Release mocks base method.
+func (m *MockLock) Release(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockLockMockRecorder) Release(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockLock)(nil).Release), ctx)
+}
+
+// MockInvalidation is a mock of Invalidation interface.
+type MockInvalidation struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvalidationMockRecorder
+}
+
+// MockInvalidationMockRecorder is the mock recorder for MockInvalidation.
+type MockInvalidationMockRecorder struct {
+	mock *MockInvalidation
+}
+
+// NewMockInvalidation creates a new mock instance.
+func NewMockInvalidation(ctrl *gomock.Controller) *MockInvalidation {
+	mock := &MockInvalidation{ctrl: ctrl}
+	mock.recorder = &MockInvalidationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvalidation) EXPECT() *MockInvalidationMockRecorder {
+	return m.recorder
+}
+
+// ListenInvalidations mocks base method.
+func (m *MockInvalidation) ListenInvalidations(ctx context.Context, evict func([]uuid.UUID), flush func()) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenInvalidations", ctx, evict, flush)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListenInvalidations indicates an expected call of ListenInvalidations.
+func (mr *MockInvalidationMockRecorder) ListenInvalidations(ctx, evict, flush interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenInvalidations", reflect.TypeOf((*MockInvalidation)(nil).ListenInvalidations), ctx, evict, flush)
+}
+
+// MockIDScanner is a mock of IDScanner interface.
+type MockIDScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDScannerMockRecorder
+}
+
+// MockIDScannerMockRecorder is the mock recorder for MockIDScanner.
+type MockIDScannerMockRecorder struct {
+	mock *MockIDScanner
+}
+
+// NewMockIDScanner creates a new mock instance.
+func NewMockIDScanner(ctrl *gomock.Controller) *MockIDScanner {
+	mock := &MockIDScanner{ctrl: ctrl}
+	mock.recorder = &MockIDScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDScanner) EXPECT() *MockIDScannerMockRecorder {
+	return m.recorder
+}
+
+// ScanIDs mocks base method.
+func (m *MockIDScanner) ScanIDs(ctx context.Context, fn func(uuid.UUID) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanIDs", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScanIDs indicates an expected call of ScanIDs.
+func (mr *MockIDScannerMockRecorder) ScanIDs(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanIDs", reflect.TypeOf((*MockIDScanner)(nil).ScanIDs), ctx, fn)
+}
+
+// MockImporter is a mock of Importer interface.
+type MockImporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockImporterMockRecorder
+}
+
+// MockImporterMockRecorder is the mock recorder for MockImporter.
+type MockImporterMockRecorder struct {
+	mock *MockImporter
+}
+
+// NewMockImporter creates a new mock instance.
+func NewMockImporter(ctrl *gomock.Controller) *MockImporter {
+	mock := &MockImporter{ctrl: ctrl}
+	mock.recorder = &MockImporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImporter) EXPECT() *MockImporterMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockImporter) Import(ctx context.Context, urls []url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, urls)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockImporterMockRecorder) Import(ctx, urls interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockImporter)(nil).Import), ctx, urls)
+}
+
+// MockInventory is a mock of Inventory interface.
+type MockInventory struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryMockRecorder
+}
+
+// MockInventoryMockRecorder is the mock recorder for MockInventory.
+type MockInventoryMockRecorder struct {
+	mock *MockInventory
+}
+
+// NewMockInventory creates a new mock instance.
+func NewMockInventory(ctrl *gomock.Controller) *MockInventory {
+	mock := &MockInventory{ctrl: ctrl}
+	mock.recorder = &MockInventoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventory) EXPECT() *MockInventoryMockRecorder {
+	return m.recorder
+}
+
+// CountRecords mocks base method.
+func (m *MockInventory) CountRecords(ctx context.Context) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecords", ctx)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecords indicates an expected call of CountRecords.
+func (mr *MockInventoryMockRecorder) CountRecords(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecords", reflect.TypeOf((*MockInventory)(nil).CountRecords), ctx)
+}
+
+// MockSwitchover is a mock of Switchover interface.
+type MockSwitchover struct {
+	ctrl     *gomock.Controller
+	recorder *MockSwitchoverMockRecorder
+}
+
+// MockSwitchoverMockRecorder is the mock recorder for MockSwitchover.
+type MockSwitchoverMockRecorder struct {
+	mock *MockSwitchover
+}
+
+// NewMockSwitchover creates a new mock instance.
+func NewMockSwitchover(ctrl *gomock.Controller) *MockSwitchover {
+	mock := &MockSwitchover{ctrl: ctrl}
+	mock.recorder = &MockSwitchoverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSwitchover) EXPECT() *MockSwitchoverMockRecorder {
+	return m.recorder
+}
+
+// Primary mocks base method.
+func (m *MockSwitchover) Primary() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Primary")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Primary indicates an expected call of Primary.
+func (mr *MockSwitchoverMockRecorder) Primary() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Primary", reflect.TypeOf((*MockSwitchover)(nil).Primary))
+}
+
+// Promote mocks base method.
+func (m *MockSwitchover) Promote(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Promote", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Promote indicates an expected call of Promote.
+func (mr *MockSwitchoverMockRecorder) Promote(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Promote", reflect.TypeOf((*MockSwitchover)(nil).Promote), ctx)
+}
@@ -0,0 +1,130 @@
+// Package election implements electing the leader among the application replicas sharing the storage,
+// the singleton jobs run on the leader only.
+package election
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// releaseTimeout defines the time given to releasing the leadership on shutdown.
+const releaseTimeout = 5 * time.Second
+
+type elector struct {
+	locker   storage.Locker
+	lock     storage.Lock
+	key      int64
+	interval time.Duration
+	instance string
+	leader   atomic.Bool
+	logger   *slog.Logger
+}
+
+// IsLeader implements checking the replica holds the leadership.
+func (e *elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Instance implements getting the identifier of the replica.
+func (e *elector) Instance() string {
+	return e.instance
+}
+
+// Run implements acquiring and checking the leadership until the context is done.
+// Followers try to acquire the leadership on every check, so it fails over within the check interval
+// once the leader session ends. The leadership is released when the context is done.
+func (e *elector) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	e.logger.Info("leader election is running")
+	for {
+		e.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			e.release()
+			e.logger.Info("stop leader election")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// check implements acquiring the leadership by the follower and checking it by the leader.
+func (e *elector) check(ctx context.Context) {
+	if e.lock != nil {
+		err := e.lock.Check(ctx)
+		if err == nil {
+			return
+		}
+		if ctx.Err() == nil {
+			e.logger.Error("leadership lost", err, slog.String("func", "check"))
+		}
+		e.release()
+		return
+	}
+
+	lock, ok, err := e.locker.TryLock(ctx, e.key)
+	if err != nil {
+		e.logger.Error("failed acquire leadership", err, slog.String("func", "check"))
+		return
+	}
+	if !ok {
+		return
+	}
+
+	e.lock = lock
+	e.leader.Store(true)
+	e.logger.Info("leadership acquired")
+}
+
+// release implements giving up the leadership.
+func (e *elector) release() {
+	if e.lock == nil {
+		return
+	}
+
+	e.leader.Store(false)
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	if err := e.lock.Release(ctx); err != nil {
+		e.logger.Error("failed release leadership", err, slog.String("func", "release"))
+	}
+	e.lock = nil
+	e.logger.Info("leadership released")
+}
+
+// New implements the creation of the leader elector of the replica.
+func New(l storage.Locker, cfg config.Leader) *elector {
+	instance := uuid.NewString()
+	if host, err := os.Hostname(); err == nil {
+		instance = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+
+	log := slog.New(slog.NewJSONHandler(os.Stdout).
+		WithAttrs([]slog.Attr{slog.String("service", "election"), slog.String("instance", instance)}))
+
+	interval := cfg.GetCheckInterval()
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	return &elector{
+		locker:   l,
+		key:      cfg.GetLockKey(),
+		interval: interval,
+		instance: instance,
+		logger:   log,
+	}
+}
@@ -0,0 +1,110 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+// testConfig implements leader election configuration with a short check interval.
+type testConfig struct{}
+
+func (testConfig) GetLockKey() int64               { return 42 }
+func (testConfig) GetCheckInterval() time.Duration { return time.Millisecond }
+
+func Test_elector_check(t *testing.T) {
+	errStorage := errors.New("connection refused")
+	type fields struct {
+		leader   bool
+		checkErr error
+		lockErr  error
+		locked   bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   bool
+	}{
+		{
+			name:   "positive check (leadership acquired)",
+			fields: fields{locked: true},
+			want:   true,
+		},
+		{
+			name:   "positive check (lock held by another replica)",
+			fields: fields{locked: false},
+			want:   false,
+		},
+		{
+			name:   "positive check (leadership kept)",
+			fields: fields{leader: true},
+			want:   true,
+		},
+		{
+			name:   "negative check (leadership lost)",
+			fields: fields{leader: true, checkErr: errStorage},
+			want:   false,
+		},
+		{
+			name:   "negative check (storage error)",
+			fields: fields{lockErr: errStorage},
+			want:   false,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		locker := repoMock.NewMockLocker(ctl)
+		lock := repoMock.NewMockLock(ctl)
+		e := New(locker, testConfig{})
+
+		if tt.fields.leader {
+			e.lock = lock
+			e.leader.Store(true)
+			lock.EXPECT().Check(anyMock).Return(tt.fields.checkErr)
+			if tt.fields.checkErr != nil {
+				lock.EXPECT().Release(anyMock).Return(nil)
+			}
+		} else {
+			locker.EXPECT().TryLock(anyMock, int64(42)).Return(lock, tt.fields.locked, tt.fields.lockErr)
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			e.check(ctx)
+			assert.Equal(t, tt.want, e.IsLeader())
+			assert.Equal(t, tt.want, e.lock != nil)
+		})
+	}
+}
+
+func Test_elector_Run(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	locker := repoMock.NewMockLocker(ctl)
+	lock := repoMock.NewMockLock(ctl)
+	e := New(locker, testConfig{})
+	assert.NotEmpty(t, e.Instance())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	locker.EXPECT().TryLock(anyMock, int64(42)).Return(lock, true, nil)
+	lock.EXPECT().Check(anyMock).DoAndReturn(func(context.Context) error {
+		assert.True(t, e.IsLeader())
+		cancel()
+		return nil
+	}).MinTimes(1)
+	lock.EXPECT().Release(anyMock).Return(nil)
+
+	assert.NoError(t, e.Run(ctx))
+	assert.False(t, e.IsLeader())
+}
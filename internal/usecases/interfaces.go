@@ -3,10 +3,20 @@ package usecases
 
 import (
 	"context"
+	"time"
 
-	"github.com/sreway/shorturl/internal/domain/stats"
+	"github.com/google/uuid"
 
+	"github.com/sreway/shorturl/internal/domain/account"
+	"github.com/sreway/shorturl/internal/domain/audit"
+	"github.com/sreway/shorturl/internal/domain/health"
+	"github.com/sreway/shorturl/internal/domain/outbox"
+	"github.com/sreway/shorturl/internal/domain/report"
+	"github.com/sreway/shorturl/internal/domain/stats"
+	"github.com/sreway/shorturl/internal/domain/task"
 	"github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/webhook"
+	"github.com/sreway/shorturl/internal/domain/workspace"
 )
 
 // Shortener describes the implementation of the URL shortening service.
@@ -17,7 +27,61 @@ type Shortener interface {
 	BatchURL(ctx context.Context, correlationID, rawURL []string, userID string) ([]url.URL, error)
 	GetURL(ctx context.Context, urlID string) (url.URL, error)
 	GetUserURLs(ctx context.Context, userID string) ([]url.URL, error)
-	DeleteURL(ctx context.Context, userID string, urlID []string) error
+	UpdateURL(ctx context.Context, userID, urlID, rawURL, workspaceID string) (url.URL, error)
+	DeleteURL(ctx context.Context, userID string, urlID []string) (string, error)
+	GetJob(ctx context.Context, userID, jobID string) (task.Job, error)
 	StorageCheck(ctx context.Context) error
+	PromoteStorage(ctx context.Context) (string, error)
+	Health(ctx context.Context) health.Status
 	GetStats(ctx context.Context) (stats.Collection, error)
+	SearchURLs(ctx context.Context, query, userID string, limit, offset int) ([]url.URL, error)
+	DisableURL(ctx context.Context, urlID string, disabled bool) error
+	ForceDeleteURL(ctx context.Context, urlID []string) error
+	BanUser(ctx context.Context, userID string, banned bool) error
+	ReportURL(ctx context.Context, userID, urlID, reason string) error
+	GetReportQueue(ctx context.Context, limit, offset int) ([]report.Summary, error)
+	ResolveReports(ctx context.Context, urlID string, disable bool) error
+	GetAuditEvents(ctx context.Context, userID string, from, to time.Time, limit, offset int) ([]audit.Event, error)
+	CreateWebhook(ctx context.Context, userID, rawURL string, events []string) (webhook.Webhook, error)
+	GetWebhooks(ctx context.Context, userID string) ([]webhook.Webhook, error)
+	DeleteWebhook(ctx context.Context, userID, webhookID string) error
+	GetDeadLetters(ctx context.Context, userID string) ([]webhook.Delivery, error)
+	Register(ctx context.Context, email, password, userID string) (account.Account, error)
+	Login(ctx context.Context, email, password, userID string) (account.Account, error)
+	SingleSignOn(ctx context.Context, issuer, subject, userID string) (string, error)
+	CreateWorkspace(ctx context.Context, userID, name string) (workspace.Workspace, error)
+	GetWorkspaces(ctx context.Context, userID string) ([]workspace.Workspace, error)
+	SetWorkspaceMember(ctx context.Context, userID, workspaceID, memberID, role string) error
+	DeleteWorkspaceMember(ctx context.Context, userID, workspaceID, memberID string) error
+	GetWorkspaceURLs(ctx context.Context, userID, workspaceID string) ([]url.URL, error)
+}
+
+// Notifier describes the implementation of the short URL events delivery to the webhooks of users.
+//
+// CheckEndpoint fails with webhook.ErrForbiddenEndpoint for hosts not reachable from the internet.
+type Notifier interface {
+	Publish(ctx context.Context, event webhook.Event)
+	CheckEndpoint(ctx context.Context, host string) error
+	Invalidate(userID uuid.UUID)
+}
+
+// Sink describes the implementation of the destination of the short URL events relayed from the outbox.
+//
+// Messages are delivered at least once, the sink deduplicates them by the message ID when it matters.
+type Sink interface {
+	Dispatch(ctx context.Context, messages []outbox.Message) error
+}
+
+// Leader describes the implementation of the leadership of the application replica
+// among the replicas sharing the storage.
+type Leader interface {
+	IsLeader() bool
+	Instance() string
+}
+
+// Evicter describes the implementation of the local cache of short URLs dropping the entries
+// created or changed by any replica, Flush drops every entry.
+type Evicter interface {
+	Evict(ids []uuid.UUID)
+	Flush()
 }
@@ -0,0 +1,55 @@
+// Package invalidation implements evicting the short URLs changed by any replica from the local caches.
+package invalidation
+
+import (
+	"context"
+	"os"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/usecases"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+type listener struct {
+	storage  storage.Invalidation
+	evicters []usecases.Evicter
+	logger   *slog.Logger
+}
+
+// Run implements listening the changes of short URLs until the context is done.
+func (l *listener) Run(ctx context.Context) error {
+	l.logger.Info("invalidation listener is running", slog.Int("caches", len(l.evicters)))
+	err := l.storage.ListenInvalidations(ctx, l.evict, l.flush)
+	l.logger.Info("stop invalidation listener")
+	return err
+}
+
+// evict implements dropping the changed short URLs from every local cache.
+func (l *listener) evict(ids []uuid.UUID) {
+	for _, e := range l.evicters {
+		e.Evict(ids)
+	}
+	l.logger.Debug("short urls invalidated", slog.Int("count", len(ids)))
+}
+
+// flush implements dropping every entry of the local caches.
+func (l *listener) flush() {
+	for _, e := range l.evicters {
+		e.Flush()
+	}
+	l.logger.Info("local caches flushed")
+}
+
+// New implements the creation of the listener evicting the changed short URLs from the local caches.
+func New(s storage.Invalidation, evicters ...usecases.Evicter) *listener {
+	log := slog.New(slog.NewJSONHandler(os.Stdout).
+		WithAttrs([]slog.Attr{slog.String("service", "invalidation")}))
+
+	return &listener{
+		storage:  s,
+		evicters: evicters,
+		logger:   log,
+	}
+}
@@ -0,0 +1,64 @@
+package invalidation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+)
+
+func Test_listener_Run(t *testing.T) {
+	tests := []struct {
+		name      string
+		ids       [][]uuid.UUID
+		reconnect bool
+	}{
+		{
+			name: "positive evict changed urls",
+			ids:  [][]uuid.UUID{{uuid.New()}, {uuid.New(), uuid.New()}},
+		},
+		{
+			name:      "positive flush after reconnection",
+			ids:       [][]uuid.UUID{{uuid.New()}},
+			reconnect: true,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		repo := repoMock.NewMockInvalidation(ctl)
+		evicters := []*usecasesMock.MockEvicter{usecasesMock.NewMockEvicter(ctl), usecasesMock.NewMockEvicter(ctl)}
+		for _, e := range evicters {
+			for _, ids := range tt.ids {
+				e.EXPECT().Evict(ids)
+			}
+			if tt.reconnect {
+				e.EXPECT().Flush()
+			}
+		}
+
+		repo.EXPECT().ListenInvalidations(anyMock, anyMock, anyMock).DoAndReturn(
+			func(_ context.Context, evict func(ids []uuid.UUID), flush func()) error {
+				for _, ids := range tt.ids {
+					evict(ids)
+				}
+				if tt.reconnect {
+					flush()
+				}
+				return nil
+			})
+
+		l := New(repo, evicters[0], evicters[1])
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, l.Run(ctx))
+		})
+	}
+}
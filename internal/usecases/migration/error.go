@@ -0,0 +1,17 @@
+package migration
+
+import (
+	"errors"
+)
+
+var (
+	// ErrScanNotSupported implements the source storage not listing short URLs error.
+	ErrScanNotSupported = errors.New("source storage does not support listing urls")
+	// ErrImportNotSupported implements the destination storage not importing short URLs error.
+	ErrImportNotSupported = errors.New("destination storage does not support importing urls")
+	// ErrRecordsNotCopied implements the source storage keeping records other than short URLs error,
+	// the migration does not copy them, so it is refused.
+	ErrRecordsNotCopied = errors.New("source storage keeps records not copied by the migration")
+	// ErrIncomplete implements the migration finished with failed or mismatched short URLs error.
+	ErrIncomplete = errors.New("migration incomplete")
+)
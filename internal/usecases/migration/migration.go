@@ -0,0 +1,249 @@
+// Package migration implements copying short URLs between storages.
+//
+// Short URLs keep their IDs, owners, workspaces, deletion and moderation attributes, so existing short links keep
+// working. Short URLs equal in both storages are skipped and changed ones are replaced, so the migration is repeated
+// to resume after a failure or to catch up with changes made to the source meanwhile.
+//
+// Only short URLs are copied. The migration is refused when the source keeps accounts, workspaces, bans, reports,
+// webhooks or audit log events, since the links to them would be lost in the destination.
+package migration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// defaultBatchSize defines the number of short URLs imported at once by default.
+const defaultBatchSize = 1000
+
+type (
+	migration struct {
+		src       storage.URL
+		scanner   storage.IDScanner
+		inventory storage.Inventory
+		dst       storage.URL
+		importer  storage.Importer
+		batchSize int
+		logger    *slog.Logger
+	}
+
+	// Result implements the outcome of the migration.
+	Result struct {
+		// Scanned defines the number of short URLs listed by the source.
+		Scanned int
+		// Copied defines the number of short URLs imported to the destination.
+		Copied int
+		// Unchanged defines the number of short URLs equal in both storages already.
+		Unchanged int
+		// Failed defines the number of short URLs the destination refused.
+		Failed int
+		// Mismatched defines the number of short URLs differing in the storages after the migration.
+		Mismatched int
+	}
+)
+
+// Run implements copying all short URLs from the source to the destination and verifying the result.
+// ErrRecordsNotCopied is returned before copying when the source keeps records other than short URLs,
+// ErrIncomplete is returned when some short URLs were not copied or differ after the migration.
+func (m *migration) Run(ctx context.Context) (Result, error) {
+	var result Result
+
+	if err := m.checkRecords(ctx); err != nil {
+		return result, err
+	}
+
+	ids := make([]uuid.UUID, 0)
+	// the IDs are listed before copying, so the source is not read while it is scanned
+	err := m.scanner.ScanIDs(ctx, func(id uuid.UUID) error {
+		ids = append(ids, id)
+		return nil
+	})
+	if err != nil {
+		m.logger.Error("failed scan source urls", err, slog.String("func", "Run"))
+		return result, err
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+	result.Scanned = len(ids)
+
+	for start := 0; start < len(ids); start += m.batchSize {
+		end := start + m.batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		if err = m.copyBatch(ctx, ids[start:end], &result); err != nil {
+			return result, err
+		}
+
+		m.logger.Info("copy urls", slog.Int("processed", end), slog.Int("total", len(ids)),
+			slog.Int("copied", result.Copied), slog.Int("failed", result.Failed))
+	}
+
+	for _, id := range ids {
+		equal, err := m.check(ctx, id)
+		if err != nil {
+			return result, err
+		}
+
+		if !equal {
+			m.logger.Error("url mismatch", ErrIncomplete, slog.String("func", "Run"), slog.String("id", id.String()))
+			result.Mismatched++
+		}
+	}
+
+	if result.Failed > 0 || result.Mismatched > 0 {
+		return result, fmt.Errorf("%w: %d failed, %d mismatched", ErrIncomplete, result.Failed, result.Mismatched)
+	}
+	return result, nil
+}
+
+// copyBatch implements importing the short URLs of the batch that differ in the destination. The batch
+// refused by the destination is imported one by one, so only the conflicting short URLs fail.
+func (m *migration) copyBatch(ctx context.Context, ids []uuid.UUID, result *Result) error {
+	pending := make([]entity.URL, 0, len(ids))
+	for _, id := range ids {
+		u, err := m.src.Get(ctx, id)
+		if errors.Is(err, entity.ErrNotFound) {
+			// removed from the source after the scan
+			result.Scanned--
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		stored, err := m.dst.Get(ctx, id)
+		if err != nil && !errors.Is(err, entity.ErrNotFound) {
+			return err
+		}
+
+		if err == nil && equal(u, stored) {
+			result.Unchanged++
+			continue
+		}
+		pending = append(pending, u)
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if err := m.importer.Import(ctx, pending); err == nil {
+		result.Copied += len(pending)
+		return nil
+	}
+
+	for _, u := range pending {
+		if err := m.importer.Import(ctx, []entity.URL{u}); err != nil {
+			m.logger.Error("failed import url", err, slog.String("func", "copyBatch"),
+				slog.String("id", u.ID().String()), slog.String("url", u.LongURL()))
+			result.Failed++
+			continue
+		}
+		result.Copied++
+	}
+	return nil
+}
+
+// checkRecords implements refusing the migration when the source keeps records other than short URLs.
+func (m *migration) checkRecords(ctx context.Context) error {
+	if m.inventory == nil {
+		return nil
+	}
+
+	counts, err := m.inventory.CountRecords(ctx)
+	if err != nil {
+		m.logger.Error("failed count source records", err, slog.String("func", "checkRecords"))
+		return err
+	}
+
+	if len(counts) == 0 {
+		return nil
+	}
+
+	stores := make([]string, 0, len(counts))
+	for name, n := range counts {
+		stores = append(stores, fmt.Sprintf("%s=%d", name, n))
+	}
+	sort.Strings(stores)
+	return fmt.Errorf("%w: %s", ErrRecordsNotCopied, strings.Join(stores, ", "))
+}
+
+// check implements comparing the short URL in the storages, the short URL removed from the source
+// after the scan is not checked.
+func (m *migration) check(ctx context.Context, id uuid.UUID) (bool, error) {
+	u, err := m.src.Get(ctx, id)
+	if errors.Is(err, entity.ErrNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	stored, err := m.dst.Get(ctx, id)
+	if errors.Is(err, entity.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return equal(u, stored), nil
+}
+
+// equal implements comparing the stored attributes of the short URLs.
+func equal(a, b entity.URL) bool {
+	return a.ID() == b.ID() &&
+		a.UserID() == b.UserID() &&
+		a.WorkspaceID() == b.WorkspaceID() &&
+		a.LongURL() == b.LongURL() &&
+		a.Deleted() == b.Deleted() &&
+		a.Disabled() == b.Disabled()
+}
+
+// New implements the creation of the migration copying short URLs from the source to the destination storage,
+// the records of the source other than short URLs are checked when the source counts them.
+func New(src, dst storage.URL, batchSize int) (*migration, error) {
+	scanner, ok := src.(storage.IDScanner)
+	if !ok {
+		return nil, ErrScanNotSupported
+	}
+
+	importer, ok := dst.(storage.Importer)
+	if !ok {
+		return nil, ErrImportNotSupported
+	}
+
+	inventory, _ := src.(storage.Inventory)
+
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	log := slog.New(slog.NewJSONHandler(os.Stdout).
+		WithAttrs([]slog.Attr{slog.String("service", "migration")}))
+
+	return &migration{
+		src:       src,
+		scanner:   scanner,
+		inventory: inventory,
+		dst:       dst,
+		importer:  importer,
+		batchSize: batchSize,
+		logger:    log,
+	}, nil
+}
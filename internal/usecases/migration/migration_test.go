@@ -0,0 +1,257 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+// source implements the storage listing short URLs.
+type source struct {
+	*repoMock.MockURL
+	*repoMock.MockIDScanner
+}
+
+// destination implements the storage importing short URLs.
+type destination struct {
+	*repoMock.MockURL
+	*repoMock.MockImporter
+}
+
+func newURL(id uuid.UUID, rawURL string, deleted bool) entity.URL {
+	value, _ := url.Parse(rawURL)
+	u := entity.NewURL(id, uuid.MustParse("5e3c7b6a-2f4d-4c8e-9a1b-3d5f7e9a1c2b"))
+	u.SetWorkspaceID(u.UserID())
+	u.SetLongURL(*value)
+	u.SetDeleted(deleted)
+	return u
+}
+
+func Test_migration_Run(t *testing.T) {
+	var (
+		id1 = uuid.MustParse("1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d")
+		id2 = uuid.MustParse("2b3c4d5e-6f7a-4b9c-8d1e-2f3a4b5c6d7e")
+		id3 = uuid.MustParse("3c4d5e6f-7a8b-4c0d-9e2f-3a4b5c6d7e8f")
+
+		errImport = errors.New("any error")
+	)
+
+	type fields struct {
+		src       map[uuid.UUID]entity.URL
+		dst       map[uuid.UUID]entity.URL
+		refused   map[uuid.UUID]bool
+		batchSize int
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		want    Result
+		wantErr error
+	}{
+		{
+			name: "positive copy urls",
+			fields: fields{
+				src: map[uuid.UUID]entity.URL{
+					id1: newURL(id1, "https://example.com/1", false),
+					id2: newURL(id2, "https://example.com/2", true),
+					id3: newURL(id3, "https://example.com/3", false),
+				},
+				batchSize: 2,
+			},
+			want: Result{Scanned: 3, Copied: 3},
+		},
+		{
+			name: "positive resume copy (changed and copied urls)",
+			fields: fields{
+				src: map[uuid.UUID]entity.URL{
+					id1: newURL(id1, "https://example.com/1", false),
+					id2: newURL(id2, "https://example.com/2", true),
+				},
+				dst: map[uuid.UUID]entity.URL{
+					id1: newURL(id1, "https://example.com/1", false),
+					id2: newURL(id2, "https://example.com/2", false),
+				},
+			},
+			want: Result{Scanned: 2, Copied: 1, Unchanged: 1},
+		},
+		{
+			name: "negative copy urls (refused url)",
+			fields: fields{
+				src: map[uuid.UUID]entity.URL{
+					id1: newURL(id1, "https://example.com/1", false),
+					id2: newURL(id2, "https://example.com/2", false),
+				},
+				refused: map[uuid.UUID]bool{id2: true},
+			},
+			want:    Result{Scanned: 2, Copied: 1, Failed: 1, Mismatched: 1},
+			wantErr: ErrIncomplete,
+		},
+	}
+
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		dstURLs := make(map[uuid.UUID]entity.URL)
+		for id, u := range tt.fields.dst {
+			dstURLs[id] = u
+		}
+
+		src := source{MockURL: repoMock.NewMockURL(ctl), MockIDScanner: repoMock.NewMockIDScanner(ctl)}
+		src.MockIDScanner.EXPECT().ScanIDs(anyMock, anyMock).DoAndReturn(
+			func(_ context.Context, fn func(id uuid.UUID) error) error {
+				for id := range tt.fields.src {
+					if err := fn(id); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		src.MockURL.EXPECT().Get(anyMock, anyMock).DoAndReturn(
+			func(_ context.Context, id uuid.UUID) (entity.URL, error) {
+				return tt.fields.src[id], nil
+			}).AnyTimes()
+
+		dst := destination{MockURL: repoMock.NewMockURL(ctl), MockImporter: repoMock.NewMockImporter(ctl)}
+		dst.MockURL.EXPECT().Get(anyMock, anyMock).DoAndReturn(
+			func(_ context.Context, id uuid.UUID) (entity.URL, error) {
+				u, ok := dstURLs[id]
+				if !ok {
+					return nil, entity.NewURLErr(id, uuid.UUID{}, entity.ErrNotFound)
+				}
+				return u, nil
+			}).AnyTimes()
+		dst.MockImporter.EXPECT().Import(anyMock, anyMock).DoAndReturn(
+			func(_ context.Context, urls []entity.URL) error {
+				for _, u := range urls {
+					if tt.fields.refused[u.ID()] {
+						return entity.NewURLErr(u.ID(), u.UserID(), errImport)
+					}
+				}
+				for _, u := range urls {
+					dstURLs[u.ID()] = u
+				}
+				return nil
+			}).AnyTimes()
+
+		m, err := New(src, dst, tt.fields.batchSize)
+		assert.NoError(t, err)
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := m.Run(ctx)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, result)
+			for id, u := range tt.fields.src {
+				if tt.fields.refused[id] {
+					continue
+				}
+				assert.True(t, equal(u, dstURLs[id]))
+			}
+		})
+	}
+}
+
+func Test_New(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	src := source{MockURL: repoMock.NewMockURL(ctl), MockIDScanner: repoMock.NewMockIDScanner(ctl)}
+	dst := destination{MockURL: repoMock.NewMockURL(ctl), MockImporter: repoMock.NewMockImporter(ctl)}
+
+	tests := []struct {
+		name    string
+		src     storage.URL
+		dst     storage.URL
+		wantErr error
+	}{
+		{
+			name: "positive new migration",
+			src:  src,
+			dst:  dst,
+		},
+		{
+			name:    "negative new migration (source not listing urls)",
+			src:     repoMock.NewMockURL(ctl),
+			dst:     dst,
+			wantErr: ErrScanNotSupported,
+		},
+		{
+			name:    "negative new migration (destination not importing urls)",
+			src:     src,
+			dst:     repoMock.NewMockURL(ctl),
+			wantErr: ErrImportNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.src, tt.dst, 0)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+// inventorySource implements the source storage counting its records other than short URLs.
+type inventorySource struct {
+	source
+	*repoMock.MockInventory
+}
+
+func Test_migration_Run_records(t *testing.T) {
+	tests := []struct {
+		name    string
+		counts  map[string]int
+		want    Result
+		wantErr error
+	}{
+		{
+			name:   "positive copy urls (no other records)",
+			counts: map[string]int{},
+		},
+		{
+			name:    "negative copy urls (source keeps accounts and webhooks)",
+			counts:  map[string]int{"accounts": 2, "webhooks": 1},
+			wantErr: ErrRecordsNotCopied,
+		},
+	}
+
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := inventorySource{
+				source: source{
+					MockURL:       repoMock.NewMockURL(ctl),
+					MockIDScanner: repoMock.NewMockIDScanner(ctl),
+				},
+				MockInventory: repoMock.NewMockInventory(ctl),
+			}
+			src.MockInventory.EXPECT().CountRecords(anyMock).Return(tt.counts, nil)
+			if tt.wantErr == nil {
+				src.MockIDScanner.EXPECT().ScanIDs(anyMock, anyMock).Return(nil)
+			}
+
+			dst := destination{MockURL: repoMock.NewMockURL(ctl), MockImporter: repoMock.NewMockImporter(ctl)}
+			m, err := New(src, dst, 0)
+			assert.NoError(t, err)
+
+			result, err := m.Run(ctx)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
@@ -7,10 +7,20 @@ package usecaseMock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	account "github.com/sreway/shorturl/internal/domain/account"
+	audit "github.com/sreway/shorturl/internal/domain/audit"
+	health "github.com/sreway/shorturl/internal/domain/health"
+	outbox "github.com/sreway/shorturl/internal/domain/outbox"
+	report "github.com/sreway/shorturl/internal/domain/report"
 	stats "github.com/sreway/shorturl/internal/domain/stats"
+	task "github.com/sreway/shorturl/internal/domain/task"
 	url "github.com/sreway/shorturl/internal/domain/url"
+	webhook "github.com/sreway/shorturl/internal/domain/webhook"
+	workspace "github.com/sreway/shorturl/internal/domain/workspace"
 )
 
 // MockShortener is a mock of Shortener interface.
@@ -36,6 +46,20 @@ func (m *MockShortener) EXPECT() *MockShortenerMockRecorder {
 	return m.recorder
 }
 
+// BanUser mocks base method.
+func (m *MockShortener) BanUser(ctx context.Context, userID string, banned bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanUser", ctx, userID, banned)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BanUser indicates an expected call of BanUser.
+func (mr *MockShortenerMockRecorder) BanUser(ctx, userID, banned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanUser", reflect.TypeOf((*MockShortener)(nil).BanUser), ctx, userID, banned)
+}
+
 // BatchURL mocks base method.
 func (m *MockShortener) BatchURL(ctx context.Context, correlationID, rawURL []string, userID string) ([]url.URL, error) {
 	m.ctrl.T.Helper()
@@ -66,12 +90,43 @@ func (mr *MockShortenerMockRecorder) CreateURL(ctx, rawURL, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateURL", reflect.TypeOf((*MockShortener)(nil).CreateURL), ctx, rawURL, userID)
 }
 
+// CreateWebhook mocks base method.
+func (m *MockShortener) CreateWebhook(ctx context.Context, userID, rawURL string, events []string) (webhook.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, userID, rawURL, events)
+	ret0, _ := ret[0].(webhook.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockShortenerMockRecorder) CreateWebhook(ctx, userID, rawURL, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockShortener)(nil).CreateWebhook), ctx, userID, rawURL, events)
+}
+
+// CreateWorkspace mocks base method.
+func (m *MockShortener) CreateWorkspace(ctx context.Context, userID, name string) (workspace.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", ctx, userID, name)
+	ret0, _ := ret[0].(workspace.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockShortenerMockRecorder) CreateWorkspace(ctx, userID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockShortener)(nil).CreateWorkspace), ctx, userID, name)
+}
+
 // DeleteURL mocks base method.
-func (m *MockShortener) DeleteURL(ctx context.Context, userID string, urlID []string) error {
+func (m *MockShortener) DeleteURL(ctx context.Context, userID string, urlID []string) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteURL", ctx, userID, urlID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteURL indicates an expected call of DeleteURL.
@@ -80,6 +135,122 @@ func (mr *MockShortenerMockRecorder) DeleteURL(ctx, userID, urlID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteURL", reflect.TypeOf((*MockShortener)(nil).DeleteURL), ctx, userID, urlID)
 }
 
+// DeleteWebhook mocks base method.
+func (m *MockShortener) DeleteWebhook(ctx context.Context, userID, webhookID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, userID, webhookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockShortenerMockRecorder) DeleteWebhook(ctx, userID, webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockShortener)(nil).DeleteWebhook), ctx, userID, webhookID)
+}
+
+// DeleteWorkspaceMember mocks base method.
+func (m *MockShortener) DeleteWorkspaceMember(ctx context.Context, userID, workspaceID, memberID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceMember", ctx, userID, workspaceID, memberID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspaceMember indicates an expected call of DeleteWorkspaceMember.
+func (mr *MockShortenerMockRecorder) DeleteWorkspaceMember(ctx, userID, workspaceID, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceMember", reflect.TypeOf((*MockShortener)(nil).DeleteWorkspaceMember), ctx, userID, workspaceID, memberID)
+}
+
+// DisableURL mocks base method.
+func (m *MockShortener) DisableURL(ctx context.Context, urlID string, disabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableURL", ctx, urlID, disabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableURL indicates an expected call of DisableURL.
+func (mr *MockShortenerMockRecorder) DisableURL(ctx, urlID, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableURL", reflect.TypeOf((*MockShortener)(nil).DisableURL), ctx, urlID, disabled)
+}
+
+// ForceDeleteURL mocks base method.
+func (m *MockShortener) ForceDeleteURL(ctx context.Context, urlID []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceDeleteURL", ctx, urlID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceDeleteURL indicates an expected call of ForceDeleteURL.
+func (mr *MockShortenerMockRecorder) ForceDeleteURL(ctx, urlID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceDeleteURL", reflect.TypeOf((*MockShortener)(nil).ForceDeleteURL), ctx, urlID)
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockShortener) GetAuditEvents(ctx context.Context, userID string, from, to time.Time, limit, offset int) ([]audit.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEvents", ctx, userID, from, to, limit, offset)
+	ret0, _ := ret[0].([]audit.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockShortenerMockRecorder) GetAuditEvents(ctx, userID, from, to, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockShortener)(nil).GetAuditEvents), ctx, userID, from, to, limit, offset)
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockShortener) GetDeadLetters(ctx context.Context, userID string) ([]webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters", ctx, userID)
+	ret0, _ := ret[0].([]webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockShortenerMockRecorder) GetDeadLetters(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockShortener)(nil).GetDeadLetters), ctx, userID)
+}
+
+// GetJob mocks base method.
+func (m *MockShortener) GetJob(ctx context.Context, userID, jobID string) (task.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, userID, jobID)
+	ret0, _ := ret[0].(task.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockShortenerMockRecorder) GetJob(ctx, userID, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockShortener)(nil).GetJob), ctx, userID, jobID)
+}
+
+// GetReportQueue mocks base method.
+func (m *MockShortener) GetReportQueue(ctx context.Context, limit, offset int) ([]report.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportQueue", ctx, limit, offset)
+	ret0, _ := ret[0].([]report.Summary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportQueue indicates an expected call of GetReportQueue.
+func (mr *MockShortenerMockRecorder) GetReportQueue(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportQueue", reflect.TypeOf((*MockShortener)(nil).GetReportQueue), ctx, limit, offset)
+}
+
 // GetStats mocks base method.
 func (m *MockShortener) GetStats(ctx context.Context) (stats.Collection, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +296,182 @@ func (mr *MockShortenerMockRecorder) GetUserURLs(ctx, userID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserURLs", reflect.TypeOf((*MockShortener)(nil).GetUserURLs), ctx, userID)
 }
 
+// GetWebhooks mocks base method.
+func (m *MockShortener) GetWebhooks(ctx context.Context, userID string) ([]webhook.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx, userID)
+	ret0, _ := ret[0].([]webhook.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockShortenerMockRecorder) GetWebhooks(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockShortener)(nil).GetWebhooks), ctx, userID)
+}
+
+// GetWorkspaceURLs mocks base method.
+func (m *MockShortener) GetWorkspaceURLs(ctx context.Context, userID, workspaceID string) ([]url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceURLs", ctx, userID, workspaceID)
+	ret0, _ := ret[0].([]url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceURLs indicates an expected call of GetWorkspaceURLs.
+func (mr *MockShortenerMockRecorder) GetWorkspaceURLs(ctx, userID, workspaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceURLs", reflect.TypeOf((*MockShortener)(nil).GetWorkspaceURLs), ctx, userID, workspaceID)
+}
+
+// GetWorkspaces mocks base method.
+func (m *MockShortener) GetWorkspaces(ctx context.Context, userID string) ([]workspace.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaces", ctx, userID)
+	ret0, _ := ret[0].([]workspace.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaces indicates an expected call of GetWorkspaces.
+func (mr *MockShortenerMockRecorder) GetWorkspaces(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaces", reflect.TypeOf((*MockShortener)(nil).GetWorkspaces), ctx, userID)
+}
+
+// Health mocks base method.
+func (m *MockShortener) Health(ctx context.Context) health.Status {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health", ctx)
+	ret0, _ := ret[0].(health.Status)
+	return ret0
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockShortenerMockRecorder) Health(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockShortener)(nil).Health), ctx)
+}
+
+// Login mocks base method.
+func (m *MockShortener) Login(ctx context.Context, email, password, userID string) (account.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, email, password, userID)
+	ret0, _ := ret[0].(account.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockShortenerMockRecorder) Login(ctx, email, password, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockShortener)(nil).Login), ctx, email, password, userID)
+}
+
+// PromoteStorage mocks base method.
+func (m *MockShortener) PromoteStorage(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteStorage", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteStorage indicates an expected call of PromoteStorage.
+func (mr *MockShortenerMockRecorder) PromoteStorage(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteStorage", reflect.TypeOf((*MockShortener)(nil).PromoteStorage), ctx)
+}
+
+// Register mocks base method.
+func (m *MockShortener) Register(ctx context.Context, email, password, userID string) (account.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, email, password, userID)
+	ret0, _ := ret[0].(account.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockShortenerMockRecorder) Register(ctx, email, password, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockShortener)(nil).Register), ctx, email, password, userID)
+}
+
+// ReportURL mocks base method.
+func (m *MockShortener) ReportURL(ctx context.Context, userID, urlID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportURL", ctx, userID, urlID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportURL indicates an expected call of ReportURL.
+func (mr *MockShortenerMockRecorder) ReportURL(ctx, userID, urlID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportURL", reflect.TypeOf((*MockShortener)(nil).ReportURL), ctx, userID, urlID, reason)
+}
+
+// ResolveReports mocks base method.
+func (m *MockShortener) ResolveReports(ctx context.Context, urlID string, disable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveReports", ctx, urlID, disable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveReports indicates an expected call of ResolveReports.
+func (mr *MockShortenerMockRecorder) ResolveReports(ctx, urlID, disable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReports", reflect.TypeOf((*MockShortener)(nil).ResolveReports), ctx, urlID, disable)
+}
+
+// SearchURLs mocks base method.
+func (m *MockShortener) SearchURLs(ctx context.Context, query, userID string, limit, offset int) ([]url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchURLs", ctx, query, userID, limit, offset)
+	ret0, _ := ret[0].([]url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchURLs indicates an expected call of SearchURLs.
+func (mr *MockShortenerMockRecorder) SearchURLs(ctx, query, userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchURLs", reflect.TypeOf((*MockShortener)(nil).SearchURLs), ctx, query, userID, limit, offset)
+}
+
+// SetWorkspaceMember mocks base method.
+func (m *MockShortener) SetWorkspaceMember(ctx context.Context, userID, workspaceID, memberID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWorkspaceMember", ctx, userID, workspaceID, memberID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWorkspaceMember indicates an expected call of SetWorkspaceMember.
+func (mr *MockShortenerMockRecorder) SetWorkspaceMember(ctx, userID, workspaceID, memberID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkspaceMember", reflect.TypeOf((*MockShortener)(nil).SetWorkspaceMember), ctx, userID, workspaceID, memberID, role)
+}
+
+// SingleSignOn mocks base method.
+func (m *MockShortener) SingleSignOn(ctx context.Context, issuer, subject, userID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SingleSignOn", ctx, issuer, subject, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SingleSignOn indicates an expected call of SingleSignOn.
+func (mr *MockShortenerMockRecorder) SingleSignOn(ctx, issuer, subject, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleSignOn", reflect.TypeOf((*MockShortener)(nil).SingleSignOn), ctx, issuer, subject, userID)
+}
+
 // StorageCheck mocks base method.
 func (m *MockShortener) StorageCheck(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -138,3 +485,214 @@ func (mr *MockShortenerMockRecorder) StorageCheck(ctx interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageCheck", reflect.TypeOf((*MockShortener)(nil).StorageCheck), ctx)
 }
+
+// UpdateURL mocks base method.
+func (m *MockShortener) UpdateURL(ctx context.Context, userID, urlID, rawURL, workspaceID string) (url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateURL", ctx, userID, urlID, rawURL, workspaceID)
+	ret0, _ := ret[0].(url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateURL indicates an expected call of UpdateURL.
+func (mr *MockShortenerMockRecorder) UpdateURL(ctx, userID, urlID, rawURL, workspaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateURL", reflect.TypeOf((*MockShortener)(nil).UpdateURL), ctx, userID, urlID, rawURL, workspaceID)
+}
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// CheckEndpoint mocks base method.
+func (m *MockNotifier) CheckEndpoint(ctx context.Context, host string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckEndpoint", ctx, host)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckEndpoint indicates an expected call of CheckEndpoint.
+func (mr *MockNotifierMockRecorder) CheckEndpoint(ctx, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckEndpoint", reflect.TypeOf((*MockNotifier)(nil).CheckEndpoint), ctx, host)
+}
+
+// Invalidate mocks base method.
+func (m *MockNotifier) Invalidate(userID uuid.UUID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Invalidate", userID)
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockNotifierMockRecorder) Invalidate(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockNotifier)(nil).Invalidate), userID)
+}
+
+// Publish mocks base method.
+func (m *MockNotifier) Publish(ctx context.Context, event webhook.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ctx, event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockNotifierMockRecorder) Publish(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockNotifier)(nil).Publish), ctx, event)
+}
+
+// MockSink is a mock of Sink interface.
+type MockSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockSinkMockRecorder
+}
+
+// MockSinkMockRecorder is the mock recorder for MockSink.
+type MockSinkMockRecorder struct {
+	mock *MockSink
+}
+
+// NewMockSink creates a new mock instance.
+func NewMockSink(ctrl *gomock.Controller) *MockSink {
+	mock := &MockSink{ctrl: ctrl}
+	mock.recorder = &MockSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSink) EXPECT() *MockSinkMockRecorder {
+	return m.recorder
+}
+
+// Dispatch mocks base method.
+func (m *MockSink) Dispatch(ctx context.Context, messages []outbox.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dispatch", ctx, messages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Dispatch indicates an expected call of Dispatch.
+func (mr *MockSinkMockRecorder) Dispatch(ctx, messages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*MockSink)(nil).Dispatch), ctx, messages)
+}
+
+// MockLeader is a mock of Leader interface.
+type MockLeader struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeaderMockRecorder
+}
+
+// MockLeaderMockRecorder is the mock recorder for MockLeader.
+type MockLeaderMockRecorder struct {
+	mock *MockLeader
+}
+
+// NewMockLeader creates a new mock instance.
+func NewMockLeader(ctrl *gomock.Controller) *MockLeader {
+	mock := &MockLeader{ctrl: ctrl}
+	mock.recorder = &MockLeaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLeader) EXPECT() *MockLeaderMockRecorder {
+	return m.recorder
+}
+
+// Instance mocks base method.
+func (m *MockLeader) Instance() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instance")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Instance indicates an expected call of Instance.
+func (mr *MockLeaderMockRecorder) Instance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instance", reflect.TypeOf((*MockLeader)(nil).Instance))
+}
+
+// IsLeader mocks base method.
+func (m *MockLeader) IsLeader() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsLeader")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsLeader indicates an expected call of IsLeader.
+func (mr *MockLeaderMockRecorder) IsLeader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLeader", reflect.TypeOf((*MockLeader)(nil).IsLeader))
+}
+
+// MockEvicter is a mock of Evicter interface.
+type MockEvicter struct {
+	ctrl     *gomock.Controller
+	recorder *MockEvicterMockRecorder
+}
+
+// MockEvicterMockRecorder is the mock recorder for MockEvicter.
+type MockEvicterMockRecorder struct {
+	mock *MockEvicter
+}
+
+// NewMockEvicter creates a new mock instance.
+func NewMockEvicter(ctrl *gomock.Controller) *MockEvicter {
+	mock := &MockEvicter{ctrl: ctrl}
+	mock.recorder = &MockEvicterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvicter) EXPECT() *MockEvicterMockRecorder {
+	return m.recorder
+}
+
+// Evict mocks base method.
+func (m *MockEvicter) Evict(ids []uuid.UUID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Evict", ids)
+}
+
+// Evict indicates an expected call of Evict.
+func (mr *MockEvicterMockRecorder) Evict(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evict", reflect.TypeOf((*MockEvicter)(nil).Evict), ids)
+}
+
+// Flush mocks base method.
+func (m *MockEvicter) Flush() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Flush")
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockEvicterMockRecorder) Flush() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockEvicter)(nil).Flush))
+}
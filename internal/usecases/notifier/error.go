@@ -0,0 +1,8 @@
+package notifier
+
+import (
+	"errors"
+)
+
+// ErrUnexpectedStatus implements notifier unexpected webhook response status error.
+var ErrUnexpectedStatus = errors.New("unexpected response status")
@@ -0,0 +1,423 @@
+// Package notifier implements the delivery of short URL events to the webhooks of users.
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/webhook"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+const (
+	// HeaderEvent defines the header of the delivered event type.
+	HeaderEvent = "X-Shorturl-Event"
+	// HeaderDelivery defines the header of the delivery ID, it stays the same across retries.
+	HeaderDelivery = "X-Shorturl-Delivery"
+	// HeaderEventID defines the header of the event ID, receivers deduplicate events delivered more than once by it.
+	HeaderEventID = "X-Shorturl-Event-Id"
+	// HeaderSignature defines the header of the payload HMAC-SHA256 signature.
+	HeaderSignature = "X-Shorturl-Signature"
+)
+
+const (
+	// subscriptionTTL defines how long the webhooks of the user are cached for the click events,
+	// the webhooks changed on another replica are seen after it.
+	subscriptionTTL = time.Minute
+	// maxSubscriptions defines the number of users whose webhooks are cached.
+	maxSubscriptions = 10000
+)
+
+type (
+	notifier struct {
+		storage  storage.Webhook
+		client   *http.Client
+		resolver *net.Resolver
+		// allowPrivate defines the permission to deliver to loopback, private and link-local addresses.
+		allowPrivate bool
+		// queue keeps the lifecycle events, clicks are queued apart so their bursts never drop them.
+		queue       chan webhook.Event
+		clicks      chan webhook.Event
+		retries     chan *delivery
+		workers     int
+		maxAttempts int
+		backoff     time.Duration
+		maxBackoff  time.Duration
+		logger      *slog.Logger
+		// subscriptions caches the webhooks of the users, clicks of users without click webhooks are not queued.
+		subscriptions map[uuid.UUID]subscription
+		// generation is changed by the invalidation, webhooks loaded before it are not cached.
+		generation uint64
+		mu         sync.RWMutex
+	}
+
+	// subscription implements the cached webhooks of the user.
+	subscription struct {
+		hooks   []webhook.Webhook
+		expires time.Time
+	}
+
+	// delivery implements the pending delivery of the event to the webhook.
+	delivery struct {
+		id        uuid.UUID
+		eventID   uuid.UUID
+		hook      webhook.Webhook
+		event     webhook.EventType
+		payload   []byte
+		attempts  int
+		createdAt time.Time
+	}
+
+	// payload describes the JSON body of the delivered event, the ID is the event ID.
+	payload struct {
+		ID        string            `json:"id"`
+		Event     webhook.EventType `json:"event"`
+		CreatedAt time.Time         `json:"created_at"`
+		Data      any               `json:"data"`
+	}
+)
+
+// Publish implements queueing the event for the delivery to the subscribed webhooks of the user.
+// The event is dropped when the queue is full, publishing never blocks the caller.
+// Clicks have their own queue and are skipped when the cached webhooks of the user are not subscribed to them.
+func (n *notifier) Publish(_ context.Context, event webhook.Event) {
+	queue := n.queue
+	if event.Type == webhook.EventURLClicked {
+		if hooks, ok := n.cached(event.UserID); ok && !subscribed(hooks, event.Type) {
+			return
+		}
+		queue = n.clicks
+	}
+
+	select {
+	case queue <- event:
+	default:
+		n.logger.Warn("webhook queue full, event dropped", slog.String("event", string(event.Type)),
+			slog.String("userID", event.UserID.String()))
+	}
+}
+
+// Invalidate implements dropping the cached webhooks of the user, it is called when the webhooks are changed.
+func (n *notifier) Invalidate(userID uuid.UUID) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.subscriptions, userID)
+	n.generation++
+}
+
+// Run implements processing the event queue until the context is done.
+// Attempts in flight are completed, retries waiting for their backoff when the context is done are dropped.
+func (n *notifier) Run(ctx context.Context) error {
+	wg := sync.WaitGroup{}
+	for i := 0; i < n.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n.work(ctx)
+		}()
+	}
+
+	n.logger.Info("webhook notifier is running")
+	wg.Wait()
+	n.logger.Info("stop webhook notifier")
+	return nil
+}
+
+// work implements the worker delivering queued events and retries, lifecycle events are taken before clicks.
+func (n *notifier) work(ctx context.Context) {
+	for {
+		select {
+		case event := <-n.queue:
+			n.dispatch(ctx, event)
+			continue
+		default:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-n.retries:
+			n.attempt(ctx, d)
+		case event := <-n.queue:
+			n.dispatch(ctx, event)
+		case event := <-n.clicks:
+			n.dispatch(ctx, event)
+		}
+	}
+}
+
+// dispatch implements the creation of deliveries of the event to the subscribed webhooks.
+func (n *notifier) dispatch(ctx context.Context, event webhook.Event) {
+	hooks, err := n.webhooks(ctx, event)
+	if err != nil {
+		n.logger.Error("failed get webhooks", err, slog.String("userID", event.UserID.String()),
+			slog.String("func", "dispatch"))
+		return
+	}
+
+	for _, hook := range hooks {
+		if !hook.Subscribed(event.Type) {
+			continue
+		}
+
+		body, err := json.Marshal(payload{
+			ID:        event.ID.String(),
+			Event:     event.Type,
+			CreatedAt: event.CreatedAt,
+			Data:      event.Data,
+		})
+		if err != nil {
+			n.logger.Error("failed marshal payload", err, slog.String("func", "dispatch"))
+			return
+		}
+
+		n.attempt(ctx, &delivery{
+			id:        uuid.New(),
+			eventID:   event.ID,
+			hook:      hook,
+			event:     event.Type,
+			payload:   body,
+			createdAt: event.CreatedAt,
+		})
+	}
+}
+
+// webhooks implements getting the webhooks of the event user. Clicks use the cached webhooks,
+// lifecycle events load them from the storage and refresh the cache.
+func (n *notifier) webhooks(ctx context.Context, event webhook.Event) ([]webhook.Webhook, error) {
+	if event.Type == webhook.EventURLClicked {
+		if hooks, ok := n.cached(event.UserID); ok {
+			return hooks, nil
+		}
+	}
+
+	n.mu.RLock()
+	generation := n.generation
+	n.mu.RUnlock()
+
+	hooks, err := n.storage.GetWebhooks(ctx, event.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if generation != n.generation {
+		return hooks, nil
+	}
+
+	now := time.Now()
+	if len(n.subscriptions) >= maxSubscriptions {
+		for userID, s := range n.subscriptions {
+			if now.After(s.expires) {
+				delete(n.subscriptions, userID)
+			}
+		}
+		if len(n.subscriptions) >= maxSubscriptions {
+			n.subscriptions = make(map[uuid.UUID]subscription)
+		}
+	}
+	n.subscriptions[event.UserID] = subscription{hooks: hooks, expires: now.Add(subscriptionTTL)}
+
+	return hooks, nil
+}
+
+// cached implements getting the cached webhooks of the user, expired ones are not returned.
+func (n *notifier) cached(userID uuid.UUID) ([]webhook.Webhook, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	s, ok := n.subscriptions[userID]
+	if !ok || time.Now().After(s.expires) {
+		return nil, false
+	}
+	return s.hooks, true
+}
+
+// subscribed implements checking that any of the webhooks is subscribed to the event type.
+func subscribed(hooks []webhook.Webhook, event webhook.EventType) bool {
+	for _, hook := range hooks {
+		if hook.Subscribed(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// attempt implements the single delivery attempt, failed deliveries are retried with exponential backoff
+// and moved to the dead-letter list when the attempts are exhausted.
+func (n *notifier) attempt(ctx context.Context, d *delivery) {
+	d.attempts++
+	err := n.send(d)
+	if err == nil {
+		return
+	}
+
+	n.logger.Error("failed deliver webhook", err, slog.String("webhookID", d.hook.ID().String()),
+		slog.Int("attempt", d.attempts), slog.String("func", "attempt"))
+
+	if d.attempts >= n.maxAttempts {
+		err = n.storage.AddDeadLetter(ctx, webhook.Delivery{
+			ID:        d.id,
+			WebhookID: d.hook.ID(),
+			UserID:    d.hook.UserID(),
+			Event:     d.event,
+			Payload:   d.payload,
+			Attempts:  d.attempts,
+			LastError: err.Error(),
+			CreatedAt: d.createdAt,
+		})
+		if err != nil {
+			n.logger.Error("failed add dead letter", err, slog.String("webhookID", d.hook.ID().String()),
+				slog.String("func", "attempt"))
+		}
+		return
+	}
+
+	time.AfterFunc(n.delay(d.attempts), func() {
+		select {
+		case n.retries <- d:
+		case <-ctx.Done():
+		}
+	})
+}
+
+// send implements posting the signed payload to the webhook endpoint.
+// The request is bounded by the client timeout only, so attempts in flight complete on shutdown.
+func (n *notifier) send(d *delivery) error {
+	req, err := http.NewRequest(http.MethodPost, d.hook.URL(), bytes.NewReader(d.payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(d.event))
+	req.Header.Set(HeaderDelivery, d.id.String())
+	req.Header.Set(HeaderEventID, d.eventID.String())
+	req.Header.Set(HeaderSignature, Sign(d.hook.Secret(), d.payload))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// CheckEndpoint implements checking that the webhook endpoint host resolves to public addresses only.
+// The addresses are checked again when the delivery dials them, since the host may resolve differently later.
+func (n *notifier) CheckEndpoint(ctx context.Context, host string) error {
+	if n.allowPrivate {
+		return nil
+	}
+
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		var err error
+		if ips, err = n.resolver.LookupIP(ctx, "ip", host); err != nil {
+			return err
+		}
+	}
+
+	for _, ip := range ips {
+		if !webhook.PublicAddress(ip) {
+			return webhook.ErrForbiddenEndpoint
+		}
+	}
+
+	return nil
+}
+
+// control implements checking the dialed address of the delivery,
+// it keeps deliveries off the server network when the endpoint host is rebound to another address.
+func control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !webhook.PublicAddress(ip) {
+		return fmt.Errorf("%w: %s", webhook.ErrForbiddenEndpoint, host)
+	}
+
+	return nil
+}
+
+// delay implements getting the backoff before the next attempt.
+func (n *notifier) delay(attempts int) time.Duration {
+	delay := n.backoff
+	for i := 1; i < attempts && delay < n.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > n.maxBackoff {
+		delay = n.maxBackoff
+	}
+	return delay
+}
+
+// Sign implements the calculation of the payload signature sent in the signature header,
+// receivers verify it with the webhook secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// New implements the creation of the webhooks notifier.
+func New(s storage.Webhook, cfg config.Webhook) *notifier {
+	log := slog.New(slog.NewJSONHandler(os.Stdout).
+		WithAttrs([]slog.Attr{slog.String("service", "notifier")}))
+
+	workers := cfg.GetWorkers()
+	if workers <= 0 {
+		workers = 1
+	}
+
+	dialer := &net.Dialer{Timeout: cfg.GetTimeout()}
+	if !cfg.GetAllowPrivate() {
+		dialer.Control = control
+	}
+
+	// the proxy would dial the endpoint instead of the checked dialer
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &notifier{
+		storage:       s,
+		client:        &http.Client{Timeout: cfg.GetTimeout(), Transport: transport},
+		resolver:      net.DefaultResolver,
+		allowPrivate:  cfg.GetAllowPrivate(),
+		queue:         make(chan webhook.Event, cfg.GetQueueSize()),
+		clicks:        make(chan webhook.Event, cfg.GetQueueSize()),
+		retries:       make(chan *delivery),
+		workers:       workers,
+		maxAttempts:   cfg.GetMaxAttempts(),
+		backoff:       cfg.GetBackoff(),
+		maxBackoff:    cfg.GetMaxBackoff(),
+		logger:        log,
+		subscriptions: make(map[uuid.UUID]subscription),
+	}
+}
@@ -0,0 +1,321 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/webhook"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+// testConfig implements webhooks delivery configuration with short backoff.
+type testConfig struct {
+	allowPrivate bool
+}
+
+func (testConfig) GetWorkers() int              { return 2 }
+func (testConfig) GetQueueSize() int            { return 10 }
+func (testConfig) GetTimeout() time.Duration    { return time.Second }
+func (testConfig) GetMaxAttempts() int          { return 3 }
+func (testConfig) GetBackoff() time.Duration    { return time.Millisecond }
+func (testConfig) GetMaxBackoff() time.Duration { return 10 * time.Millisecond }
+func (c testConfig) GetAllowPrivate() bool      { return c.allowPrivate }
+
+func Test_notifier_Publish(t *testing.T) {
+	type fields struct {
+		failures   int32
+		subscribed webhook.EventType
+	}
+	type want struct {
+		requests   int32
+		deadLetter bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "positive delivery",
+			fields: fields{
+				subscribed: webhook.EventURLCreated,
+			},
+			want: want{
+				requests: 1,
+			},
+		},
+		{
+			name: "positive delivery (retry after failures)",
+			fields: fields{
+				failures:   2,
+				subscribed: webhook.EventURLCreated,
+			},
+			want: want{
+				requests: 3,
+			},
+		},
+		{
+			name: "negative delivery (dead letter after max attempts)",
+			fields: fields{
+				failures:   3,
+				subscribed: webhook.EventURLCreated,
+			},
+			want: want{
+				requests:   3,
+				deadLetter: true,
+			},
+		},
+		{
+			name: "positive delivery (not subscribed)",
+			fields: fields{
+				subscribed: webhook.EventURLClicked,
+			},
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	userID := uuid.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			eventID := uuid.New()
+			done := make(chan struct{}, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, Sign("secret", body), r.Header.Get(HeaderSignature))
+				assert.Equal(t, string(webhook.EventURLCreated), r.Header.Get(HeaderEvent))
+
+				var p map[string]any
+				assert.NoError(t, json.Unmarshal(body, &p))
+				assert.Equal(t, eventID.String(), r.Header.Get(HeaderEventID))
+				assert.Equal(t, eventID.String(), p["id"])
+				assert.NotEmpty(t, r.Header.Get(HeaderDelivery))
+
+				if atomic.AddInt32(&requests, 1) <= tt.fields.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+				done <- struct{}{}
+			}))
+			defer server.Close()
+
+			hook := webhook.NewWebhook(uuid.New(), userID)
+			hook.SetURL(server.URL)
+			hook.SetSecret("secret")
+			hook.SetEvents([]webhook.EventType{tt.fields.subscribed})
+
+			repo := repoMock.NewMockWebhook(ctl)
+			repo.EXPECT().GetWebhooks(anyMock, userID).Return([]webhook.Webhook{hook}, nil)
+			if tt.want.deadLetter {
+				repo.EXPECT().AddDeadLetter(anyMock, anyMock).DoAndReturn(
+					func(_ context.Context, item webhook.Delivery) error {
+						assert.Equal(t, hook.ID(), item.WebhookID)
+						assert.Equal(t, int(tt.want.requests), item.Attempts)
+						done <- struct{}{}
+						return nil
+					})
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			n := New(repo, testConfig{allowPrivate: true})
+			stopped := make(chan struct{})
+			go func() {
+				assert.NoError(t, n.Run(ctx))
+				close(stopped)
+			}()
+
+			n.Publish(ctx, webhook.Event{
+				ID:        eventID,
+				Type:      webhook.EventURLCreated,
+				UserID:    userID,
+				CreatedAt: time.Now(),
+				Data:      webhook.URLData{ID: "2ZrI5IHFnvPscPYKlxFtRQ"},
+			})
+
+			if tt.want.requests > 0 {
+				select {
+				case <-done:
+				case <-time.After(5 * time.Second):
+					t.Fatal("delivery timeout")
+				}
+			} else {
+				time.Sleep(50 * time.Millisecond)
+			}
+
+			cancel()
+			<-stopped
+			assert.Equal(t, tt.want.requests, atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func Test_notifier_Publish_forbidden(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	userID := uuid.New()
+	hook := webhook.NewWebhook(uuid.New(), userID)
+	hook.SetURL(server.URL)
+	hook.SetSecret("secret")
+	hook.SetEvents([]webhook.EventType{webhook.EventURLCreated})
+
+	done := make(chan struct{}, 1)
+	repo := repoMock.NewMockWebhook(ctl)
+	repo.EXPECT().GetWebhooks(anyMock, userID).Return([]webhook.Webhook{hook}, nil)
+	repo.EXPECT().AddDeadLetter(anyMock, anyMock).DoAndReturn(
+		func(_ context.Context, item webhook.Delivery) error {
+			assert.Contains(t, item.LastError, webhook.ErrForbiddenEndpoint.Error())
+			done <- struct{}{}
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n := New(repo, testConfig{})
+	stopped := make(chan struct{})
+	go func() {
+		assert.NoError(t, n.Run(ctx))
+		close(stopped)
+	}()
+
+	n.Publish(ctx, webhook.Event{
+		ID:        uuid.New(),
+		Type:      webhook.EventURLCreated,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+		Data:      webhook.URLData{ID: "2ZrI5IHFnvPscPYKlxFtRQ"},
+	})
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery timeout")
+	}
+
+	cancel()
+	<-stopped
+	assert.Zero(t, atomic.LoadInt32(&requests))
+}
+
+func Test_notifier_Publish_clicks(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	userID := uuid.New()
+	hook := webhook.NewWebhook(uuid.New(), userID)
+	hook.SetURL("https://crm.example.com/hooks/shorturl")
+	hook.SetEvents([]webhook.EventType{webhook.EventURLCreated})
+
+	repo := repoMock.NewMockWebhook(ctl)
+	n := New(repo, testConfig{})
+	ctx := context.Background()
+	click := webhook.Event{ID: uuid.New(), Type: webhook.EventURLClicked, UserID: userID}
+
+	// the webhooks of the user are loaded once, the next clicks are skipped before they are queued
+	repo.EXPECT().GetWebhooks(anyMock, userID).Return([]webhook.Webhook{hook}, nil)
+	n.Publish(ctx, click)
+	n.dispatch(ctx, <-n.clicks)
+	for i := 0; i < 3; i++ {
+		n.Publish(ctx, click)
+	}
+	assert.Empty(t, n.clicks)
+
+	// the changed webhooks are loaded again after the invalidation
+	n.Invalidate(userID)
+	n.Publish(ctx, click)
+	assert.Len(t, n.clicks, 1)
+}
+
+func Test_notifier_Publish_queues(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cfg := testConfig{}
+	n := New(repoMock.NewMockWebhook(ctl), cfg)
+	ctx := context.Background()
+
+	// the clicks filling their queue do not drop the lifecycle events
+	for i := 0; i < 2*cfg.GetQueueSize(); i++ {
+		n.Publish(ctx, webhook.Event{ID: uuid.New(), Type: webhook.EventURLClicked, UserID: uuid.New()})
+	}
+	n.Publish(ctx, webhook.Event{ID: uuid.New(), Type: webhook.EventURLCreated, UserID: uuid.New()})
+	n.Publish(ctx, webhook.Event{ID: uuid.New(), Type: webhook.EventURLDeleted, UserID: uuid.New()})
+
+	assert.Len(t, n.clicks, cfg.GetQueueSize())
+	assert.Len(t, n.queue, 2)
+}
+
+func Test_notifier_CheckEndpoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		host         string
+		allowPrivate bool
+		wantErr      error
+	}{
+		{
+			name: "positive check endpoint (public address)",
+			host: "93.184.216.34",
+		},
+		{
+			name:         "positive check endpoint (private allowed)",
+			host:         "127.0.0.1",
+			allowPrivate: true,
+		},
+		{
+			name:    "negative check endpoint (loopback)",
+			host:    "127.0.0.1",
+			wantErr: webhook.ErrForbiddenEndpoint,
+		},
+		{
+			name:    "negative check endpoint (loopback name)",
+			host:    "localhost",
+			wantErr: webhook.ErrForbiddenEndpoint,
+		},
+		{
+			name:    "negative check endpoint (private)",
+			host:    "10.0.0.5",
+			wantErr: webhook.ErrForbiddenEndpoint,
+		},
+		{
+			name:    "negative check endpoint (link-local metadata)",
+			host:    "169.254.169.254",
+			wantErr: webhook.ErrForbiddenEndpoint,
+		},
+		{
+			name:    "negative check endpoint (ipv6 loopback)",
+			host:    "::1",
+			wantErr: webhook.ErrForbiddenEndpoint,
+		},
+	}
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	for _, tt := range tests {
+		n := New(repoMock.NewMockWebhook(ctl), testConfig{allowPrivate: tt.allowPrivate})
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, n.CheckEndpoint(context.Background(), tt.host), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Package relay implements publishing the short URL events from the transactional outbox to the sinks.
+package relay
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/outbox"
+	"github.com/sreway/shorturl/internal/usecases"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+type (
+	relay struct {
+		storage   storage.Outbox
+		sinks     []usecases.Sink
+		interval  time.Duration
+		batchSize int
+		retention time.Duration
+		logger    *slog.Logger
+	}
+
+	// logSink implements the sink writing the short URL events to the log.
+	logSink struct {
+		logger *slog.Logger
+	}
+)
+
+// Run implements polling the outbox until the context is done.
+// The outbox is drained on every poll, messages whose dispatch failed are retried on the next poll.
+func (r *relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.logger.Info("outbox relay is running")
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("stop outbox relay")
+			return nil
+		case <-ticker.C:
+			r.drain(ctx)
+		}
+	}
+}
+
+// drain implements dispatching batches of the outbox messages until the outbox is empty.
+func (r *relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := r.storage.DispatchOutbox(ctx, r.batchSize, r.dispatch)
+		if err != nil {
+			r.logger.Error("failed dispatch outbox", err, slog.String("func", "drain"))
+			return
+		}
+
+		if n < r.batchSize {
+			return
+		}
+	}
+}
+
+// Purge implements deleting the dispatched outbox messages kept longer than the retention.
+func (r *relay) Purge(ctx context.Context) error {
+	if r.retention <= 0 {
+		return nil
+	}
+
+	n, err := r.storage.PurgeOutbox(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		r.logger.Error("failed purge outbox", err, slog.String("func", "Purge"))
+		return err
+	}
+
+	r.logger.Info("dispatched outbox messages purged", slog.Int("count", n))
+	return nil
+}
+
+// dispatch implements passing the outbox messages to every sink.
+// The failure of any sink keeps the messages in the outbox, so sinks that succeeded receive them again.
+func (r *relay) dispatch(ctx context.Context, messages []outbox.Message) error {
+	for _, sink := range r.sinks {
+		if err := sink.Dispatch(ctx, messages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dispatch implements writing the short URL events to the log.
+func (s *logSink) Dispatch(_ context.Context, messages []outbox.Message) error {
+	for _, m := range messages {
+		s.logger.Info("outbox event", slog.String("id", m.ID.String()), slog.String("type", string(m.Type)),
+			slog.String("urlID", m.URLID.String()), slog.String("userID", m.UserID.String()))
+	}
+	return nil
+}
+
+// LogSink implements the creation of the sink writing the short URL events to the log.
+func LogSink() usecases.Sink {
+	return &logSink{
+		logger: slog.New(slog.NewJSONHandler(os.Stdout).
+			WithAttrs([]slog.Attr{slog.String("sink", "log")})),
+	}
+}
+
+// New implements the creation of the outbox relay publishing events to the sinks.
+func New(s storage.Outbox, cfg config.Outbox, sinks ...usecases.Sink) *relay {
+	log := slog.New(slog.NewJSONHandler(os.Stdout).
+		WithAttrs([]slog.Attr{slog.String("service", "relay")}))
+
+	interval := cfg.GetPollInterval()
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	batchSize := cfg.GetBatchSize()
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+
+	return &relay{
+		storage:   s,
+		sinks:     sinks,
+		interval:  interval,
+		batchSize: batchSize,
+		retention: cfg.GetRetention(),
+		logger:    log,
+	}
+}
@@ -0,0 +1,152 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/outbox"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+)
+
+// testConfig implements outbox relay configuration with a small batch.
+type testConfig struct{}
+
+func (testConfig) GetPollInterval() time.Duration { return time.Millisecond }
+func (testConfig) GetBatchSize() int              { return 2 }
+func (testConfig) GetRetention() time.Duration    { return time.Hour }
+func (testConfig) GetPurgeSchedule() string       { return "@hourly" }
+
+func Test_relay_drain(t *testing.T) {
+	errSink := errors.New("sink unavailable")
+	type want struct {
+		polls     int
+		delivered int
+	}
+	tests := []struct {
+		name    string
+		pending int
+		sinkErr error
+		want    want
+	}{
+		{
+			name:    "positive drain (empty outbox)",
+			pending: 0,
+			want:    want{polls: 1},
+		},
+		{
+			name:    "positive drain (several batches)",
+			pending: 5,
+			want:    want{polls: 3, delivered: 5},
+		},
+		{
+			name:    "positive drain (full last batch)",
+			pending: 4,
+			want:    want{polls: 3, delivered: 4},
+		},
+		{
+			name:    "negative drain (sink error)",
+			pending: 5,
+			sinkErr: errSink,
+			want:    want{polls: 1},
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		repo := repoMock.NewMockOutbox(ctl)
+		sink := usecasesMock.NewMockSink(ctl)
+		r := New(repo, testConfig{}, sink)
+
+		pending := make([]outbox.Message, tt.pending)
+		for idx := range pending {
+			pending[idx] = outbox.Message{ID: uuid.New(), Type: outbox.TypeURLCreated}
+		}
+
+		delivered := 0
+		repo.EXPECT().DispatchOutbox(anyMock, 2, anyMock).Times(tt.want.polls).DoAndReturn(
+			func(ctx context.Context, limit int,
+				dispatch func(ctx context.Context, messages []outbox.Message) error,
+			) (int, error) {
+				n := limit
+				if n > len(pending) {
+					n = len(pending)
+				}
+				if n == 0 {
+					return 0, nil
+				}
+				if err := dispatch(ctx, pending[:n]); err != nil {
+					return 0, err
+				}
+				pending = pending[n:]
+				return n, nil
+			})
+		sink.EXPECT().Dispatch(anyMock, anyMock).AnyTimes().DoAndReturn(
+			func(_ context.Context, messages []outbox.Message) error {
+				if tt.sinkErr != nil {
+					return tt.sinkErr
+				}
+				delivered += len(messages)
+				return nil
+			})
+
+		t.Run(tt.name, func(t *testing.T) {
+			r.drain(ctx)
+			assert.Equal(t, tt.want.delivered, delivered)
+		})
+	}
+}
+
+func Test_relay_Run(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	repo := repoMock.NewMockOutbox(ctl)
+	r := New(repo, testConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	polled := make(chan struct{})
+	repo.EXPECT().DispatchOutbox(anyMock, anyMock, anyMock).MinTimes(1).DoAndReturn(
+		func(context.Context, int, func(context.Context, []outbox.Message) error) (int, error) {
+			select {
+			case polled <- struct{}{}:
+			default:
+			}
+			return 0, nil
+		})
+
+	stopped := make(chan struct{})
+	go func() {
+		assert.NoError(t, r.Run(ctx))
+		close(stopped)
+	}()
+
+	<-polled
+	cancel()
+	<-stopped
+}
+
+func Test_relay_Purge(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	repo := repoMock.NewMockOutbox(ctl)
+	repo.EXPECT().PurgeOutbox(anyMock, anyMock).DoAndReturn(func(_ context.Context, before time.Time) (int, error) {
+		assert.WithinDuration(t, time.Now().Add(-time.Hour), before, time.Minute)
+		return 3, nil
+	})
+
+	r := New(repo, testConfig{})
+	assert.NoError(t, r.Purge(context.Background()))
+}
@@ -0,0 +1,6 @@
+package scheduler
+
+import "errors"
+
+// ErrInvalidSchedule implements scheduler invalid schedule specification error.
+var ErrInvalidSchedule = errors.New("invalid schedule")
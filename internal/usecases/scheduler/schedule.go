@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScheduleYears defines how far ahead the next run of the cron schedule is searched.
+const maxScheduleYears = 5
+
+type (
+	// Schedule describes the times of the periodic job runs.
+	Schedule interface {
+		Next(t time.Time) time.Time
+	}
+
+	// every implements the schedule running the job at the fixed interval.
+	every time.Duration
+
+	// cron implements the schedule of the five field cron expression (minute hour day-of-month month day-of-week),
+	// fields are bit sets of the allowed values.
+	cron struct {
+		minute, hour, dom, month, dow uint64
+		// domAny and dowAny define the unrestricted day fields, when both are restricted either of them matches.
+		domAny, dowAny bool
+	}
+
+	// field describes the bounds of the cron expression field.
+	field struct {
+		name     string
+		min, max int
+	}
+)
+
+// descriptors defines the cron expressions of the predefined schedules.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronFields defines the fields of the cron expression in order.
+var cronFields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// Every implements the creation of the schedule running the job at the fixed interval.
+func Every(interval time.Duration) Schedule {
+	return every(interval)
+}
+
+// Next implements getting the time of the run following t.
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// ParseSchedule implements parsing the schedule specification: the five field cron expression,
+// the predefined schedule (@hourly, @daily, @weekly, @monthly, @yearly) or the interval (@every 10m).
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@every ") {
+		value := strings.TrimPrefix(spec, "@every ")
+		interval, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("%w: non-positive interval %s", ErrInvalidSchedule, value)
+		}
+		return Every(interval), nil
+	}
+
+	if expr, ok := descriptors[spec]; ok {
+		spec = expr
+	}
+
+	values := strings.Fields(spec)
+	if len(values) != len(cronFields) {
+		return nil, fmt.Errorf("%w: expected %d fields, got %d", ErrInvalidSchedule, len(cronFields), len(values))
+	}
+
+	sets := make([]uint64, len(cronFields))
+	for idx, f := range cronFields {
+		set, err := f.parse(values[idx])
+		if err != nil {
+			return nil, err
+		}
+		sets[idx] = set
+	}
+
+	// Sunday is both 0 and 7
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	return &cron{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: values[2] == "*",
+		dowAny: values[4] == "*",
+	}, nil
+}
+
+// Next implements getting the time of the first matching minute after t,
+// zero time is returned when there is no matching time in the following years.
+func (c *cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + maxScheduleYears
+
+	for t.Year() <= limit {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches implements checking the day fields for the day of t.
+func (c *cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// parse implements parsing the comma separated list of values, ranges and steps of the cron field.
+func (f field) parse(value string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(value, ",") {
+		rangeValue, stepValue, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepValue)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%w: invalid %s step %q", ErrInvalidSchedule, f.name, part)
+			}
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangeValue == "*":
+		case strings.Contains(rangeValue, "-"):
+			lowValue, highValue, _ := strings.Cut(rangeValue, "-")
+			var err error
+			if low, err = f.value(lowValue); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(highValue); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("%w: invalid %s range %q", ErrInvalidSchedule, f.name, part)
+			}
+		default:
+			var err error
+			if low, err = f.value(rangeValue); err != nil {
+				return 0, err
+			}
+			if !hasStep {
+				high = low
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+// value implements parsing the single value of the cron field.
+func (f field) value(value string) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%w: invalid %s value %q", ErrInvalidSchedule, f.name, value)
+	}
+	return v, nil
+}
@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	from := time.Date(2023, time.March, 14, 10, 30, 15, 0, time.UTC)
+	tests := []struct {
+		name    string
+		spec    string
+		want    time.Time
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "positive parse schedule (interval)",
+			spec:    "@every 90s",
+			want:    from.Add(90 * time.Second),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "positive parse schedule (hourly)",
+			spec:    "@hourly",
+			want:    time.Date(2023, time.March, 14, 11, 0, 0, 0, time.UTC),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "positive parse schedule (daily)",
+			spec:    "@daily",
+			want:    time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "positive parse schedule (every minute)",
+			spec:    "* * * * *",
+			want:    time.Date(2023, time.March, 14, 10, 31, 0, 0, time.UTC),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "positive parse schedule (step)",
+			spec:    "*/20 * * * *",
+			want:    time.Date(2023, time.March, 14, 10, 40, 0, 0, time.UTC),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "positive parse schedule (list and range)",
+			spec:    "15,45 2-4 * * *",
+			want:    time.Date(2023, time.March, 15, 2, 15, 0, 0, time.UTC),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "positive parse schedule (sunday as 7)",
+			spec:    "0 3 * * 7",
+			want:    time.Date(2023, time.March, 19, 3, 0, 0, 0, time.UTC),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "positive parse schedule (day of month or week)",
+			spec:    "0 0 1 * 5",
+			want:    time.Date(2023, time.March, 17, 0, 0, 0, 0, time.UTC),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "positive parse schedule (next year)",
+			spec:    "0 0 29 2 *",
+			want:    time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "negative parse schedule (fields count)",
+			spec:    "* * * *",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "negative parse schedule (out of range)",
+			spec:    "60 * * * *",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "negative parse schedule (invalid range)",
+			spec:    "* 5-2 * * *",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "negative parse schedule (invalid step)",
+			spec:    "*/0 * * * *",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "negative parse schedule (invalid interval)",
+			spec:    "@every -1m",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSchedule(tt.spec)
+			if !tt.wantErr(t, err, "ParseSchedule(%s)", tt.spec) {
+				return
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, ErrInvalidSchedule)
+				return
+			}
+			assert.Equal(t, tt.want, got.Next(from))
+		})
+	}
+}
+
+func TestParseSchedule_never(t *testing.T) {
+	s, err := ParseSchedule("0 0 31 2 *")
+	assert.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
@@ -0,0 +1,402 @@
+// Package scheduler implements processing the deferred tasks by the handlers registered per action
+// and running the periodic jobs by their schedules.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/task"
+	"github.com/sreway/shorturl/internal/usecases"
+	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
+)
+
+// taskLease defines the time the claimed task stays hidden from other workers,
+// the handler run is canceled when the lease ends.
+const taskLease = time.Minute
+
+var (
+	// metrics implements the published counters of the processed tasks and the job runs,
+	// keys are prefixed with the task action or the job name.
+	metrics = expvar.NewMap("jobs")
+	// queueDepth implements the published number of the pending tasks.
+	queueDepth = new(expvar.Int)
+)
+
+type (
+	// Handler describes the function applying the deferred task action,
+	// the outcomes for the short URLs of the task are returned.
+	Handler func(ctx context.Context, t task.Task) (map[uuid.UUID]task.Outcome, error)
+
+	// Job describes the function of the periodic job.
+	Job func(ctx context.Context) error
+
+	// Option describes an option for the registered handler or job.
+	Option func(*limits)
+
+	// limits implements the execution limits of the handler or job.
+	limits struct {
+		concurrency int
+		singleton   bool
+	}
+
+	// handler implements the registered handler of the task action.
+	handler struct {
+		fn    Handler
+		slots chan struct{}
+	}
+
+	// job implements the registered periodic job.
+	job struct {
+		name      string
+		schedule  Schedule
+		fn        Job
+		slots     chan struct{}
+		singleton bool
+	}
+
+	scheduler struct {
+		queue        storage.Queue
+		leader       usecases.Leader
+		handlers     map[task.Action]*handler
+		jobs         []*job
+		tasks        sync.WaitGroup
+		runs         sync.WaitGroup
+		interval     time.Duration
+		batchSize    int
+		workers      int
+		maxAttempts  int
+		backoff      time.Duration
+		maxBackoff   time.Duration
+		drainTimeout time.Duration
+		logger       *slog.Logger
+	}
+)
+
+// Concurrency implements an option that sets the maximum number of simultaneous runs of the handler or job.
+func Concurrency(n int) Option {
+	return func(l *limits) {
+		if n > 0 {
+			l.concurrency = n
+		}
+	}
+}
+
+// Singleton implements an option that runs the job on the leader replica only.
+// Tasks are claimed exclusively by any replica, so the option does not apply to handlers.
+func Singleton() Option {
+	return func(l *limits) {
+		l.singleton = true
+	}
+}
+
+// Handle implements registering the handler of the deferred task action,
+// the concurrency defaults to the configured number of task workers. Handlers are registered before Run.
+func (s *scheduler) Handle(action task.Action, fn Handler, opts ...Option) {
+	l := limits{concurrency: s.workers}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	s.handlers[action] = &handler{
+		fn:    fn,
+		slots: make(chan struct{}, l.concurrency),
+	}
+}
+
+// Schedule implements registering the periodic job, the run due while the previous runs
+// hold all the concurrency slots is skipped. Jobs are registered before Run.
+func (s *scheduler) Schedule(name string, schedule Schedule, fn Job, opts ...Option) {
+	l := limits{concurrency: 1}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	s.jobs = append(s.jobs, &job{
+		name:      name,
+		schedule:  schedule,
+		fn:        fn,
+		slots:     make(chan struct{}, l.concurrency),
+		singleton: l.singleton,
+	})
+}
+
+// Run implements processing the task queue and running the periodic jobs until the context is done.
+// Tasks due when the context is done are processed within the drain timeout,
+// the rest stay in the durable queue until the next start. Job runs in flight are waited for.
+func (s *scheduler) Run(ctx context.Context) error {
+	// tasks are not canceled with the context, so runs in flight complete within the drain timeout
+	taskCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	for _, j := range s.jobs {
+		wg.Add(1)
+		go func(j *job) {
+			defer wg.Done()
+			s.runJob(ctx, j)
+		}(j)
+	}
+
+	s.logger.Info("scheduler is running", slog.Int("handlers", len(s.handlers)), slog.Int("jobs", len(s.jobs)))
+	if s.queue == nil || len(s.handlers) == 0 {
+		<-ctx.Done()
+	} else {
+		s.procQueue(ctx, taskCtx)
+
+		timer := time.AfterFunc(s.drainTimeout, cancel)
+		s.drain(taskCtx)
+		timer.Stop()
+	}
+
+	wg.Wait()
+	s.runs.Wait()
+	s.logger.Info("stop scheduler")
+	return nil
+}
+
+// procQueue implements polling the task queue until the context is done.
+func (s *scheduler) procQueue(ctx, taskCtx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.poll(taskCtx)
+			s.updateDepth(taskCtx)
+		}
+	}
+}
+
+// drain implements processing the due tasks until there are none left or the context is done.
+func (s *scheduler) drain(ctx context.Context) {
+	for {
+		s.tasks.Wait()
+		if ctx.Err() != nil || s.poll(ctx) == 0 {
+			s.tasks.Wait()
+			return
+		}
+	}
+}
+
+// poll implements claiming the due tasks of every action up to the free slots of its handler and dispatching them,
+// the number of claimed tasks is returned. Tasks of the actions without the registered handler are not claimed.
+func (s *scheduler) poll(ctx context.Context) int {
+	var total int
+	for action, h := range s.handlers {
+		total += s.pollAction(ctx, action, h)
+	}
+	return total
+}
+
+// pollAction implements claiming the due tasks of the action up to the free slots of the handler,
+// so the claimed task does not wait for the slot while its lease runs out.
+func (s *scheduler) pollAction(ctx context.Context, action task.Action, h *handler) int {
+	var total int
+	for ctx.Err() == nil {
+		// the slots are taken by this goroutine only, so the free slots do not decrease meanwhile
+		limit := cap(h.slots) - len(h.slots)
+		if limit > s.batchSize {
+			limit = s.batchSize
+		}
+		if limit == 0 {
+			return total
+		}
+
+		tasks, err := s.queue.ClaimTasks(ctx, action, limit, taskLease)
+		if err != nil {
+			s.logger.Error("failed claim tasks", err, slog.String("func", "poll"),
+				slog.String("action", string(action)))
+			return total
+		}
+
+		for _, t := range tasks {
+			s.dispatch(ctx, h, t)
+		}
+
+		total += len(tasks)
+		if len(tasks) < limit {
+			return total
+		}
+	}
+	return total
+}
+
+// dispatch implements running the task by the handler of its action in the taken handler slot,
+// the run is canceled when the lease of the task ends.
+func (s *scheduler) dispatch(ctx context.Context, h *handler, t task.Task) {
+	h.slots <- struct{}{}
+	s.tasks.Add(1)
+	go func() {
+		defer func() {
+			<-h.slots
+			s.tasks.Done()
+		}()
+
+		runCtx, cancel := context.WithTimeout(ctx, taskLease)
+		outcomes, err := h.fn(runCtx, t)
+		cancel()
+		s.finish(ctx, t, outcomes, err)
+	}()
+}
+
+// finish implements recording the result of the task attempt, failed tasks are retried with exponential
+// backoff and moved to the dead state when the attempts are exhausted. The result of the attempt whose task
+// was claimed again after the lease ended is dropped, the task is finished by the new claim.
+func (s *scheduler) finish(ctx context.Context, t task.Task, outcomes map[uuid.UUID]task.Outcome, err error) {
+	action := string(t.Action)
+	if err == nil {
+		metrics.Add(action+".processed", 1)
+		err = s.queue.CompleteTask(ctx, t.ID, t.Attempts, outcomes)
+		if errors.Is(err, task.ErrLeaseLost) {
+			metrics.Add(action+".lease_lost", 1)
+		}
+		if err != nil {
+			s.logger.Error("failed complete task", err, slog.String("id", t.ID.String()),
+				slog.String("func", "finish"))
+		}
+		return
+	}
+
+	metrics.Add(action+".failed", 1)
+	s.logger.Error("failed run task", err, slog.String("id", t.ID.String()),
+		slog.String("action", action), slog.Int("attempt", t.Attempts), slog.String("func", "finish"))
+
+	if t.Attempts >= s.maxAttempts {
+		metrics.Add(action+".dead", 1)
+		err = s.queue.FailTask(ctx, t.ID, t.Attempts, err.Error())
+	} else {
+		metrics.Add(action+".retried", 1)
+		err = s.queue.RetryTask(ctx, t.ID, t.Attempts, time.Now().Add(s.delay(t.Attempts)), err.Error())
+	}
+
+	if errors.Is(err, task.ErrLeaseLost) {
+		metrics.Add(action+".lease_lost", 1)
+	}
+	if err != nil {
+		s.logger.Error("failed reschedule task", err, slog.String("id", t.ID.String()),
+			slog.String("func", "finish"))
+	}
+}
+
+// updateDepth implements publishing the number of the pending tasks.
+func (s *scheduler) updateDepth(ctx context.Context) {
+	n, err := s.queue.CountTasks(ctx, task.StatusPending)
+	if err != nil {
+		s.logger.Error("failed count tasks", err, slog.String("func", "updateDepth"))
+		return
+	}
+	queueDepth.Set(int64(n))
+}
+
+// runJob implements running the periodic job by its schedule until the context is done.
+func (s *scheduler) runJob(ctx context.Context, j *job) {
+	for {
+		next := j.schedule.Next(time.Now())
+		if next.IsZero() {
+			s.logger.Warn("job schedule has no next run", slog.String("job", j.name))
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if j.singleton && !s.isLeader() {
+			continue
+		}
+
+		select {
+		case j.slots <- struct{}{}:
+		default:
+			metrics.Add(j.name+".skipped", 1)
+			s.logger.Warn("job run skipped, previous run in progress", slog.String("job", j.name))
+			continue
+		}
+
+		s.runs.Add(1)
+		go func() {
+			defer func() {
+				<-j.slots
+				s.runs.Done()
+			}()
+
+			metrics.Add(j.name+".runs", 1)
+			if err := j.fn(ctx); err != nil {
+				metrics.Add(j.name+".failures", 1)
+				s.logger.Error("failed run job", err, slog.String("job", j.name), slog.String("func", "runJob"))
+			}
+		}()
+	}
+}
+
+// isLeader implements checking the replica runs the singleton jobs,
+// the replica is the leader when there is no leader election.
+func (s *scheduler) isLeader() bool {
+	return s.leader == nil || s.leader.IsLeader()
+}
+
+// delay implements getting the backoff before the next attempt of the task.
+func (s *scheduler) delay(attempts int) time.Duration {
+	delay := s.backoff
+	for i := 1; i < attempts && delay < s.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.maxBackoff {
+		delay = s.maxBackoff
+	}
+	return delay
+}
+
+// New implements the creation of the scheduler processing the deferred tasks from the queue,
+// only the periodic jobs are run without the queue. The singleton jobs run on every replica without the leader.
+func New(q storage.Queue, cfg config.TaskQueue, l usecases.Leader) *scheduler {
+	log := slog.New(slog.NewJSONHandler(os.Stdout).
+		WithAttrs([]slog.Attr{slog.String("service", "scheduler")}))
+
+	metrics.Set("queue_depth", queueDepth)
+
+	interval := cfg.GetCheckTaskInterval()
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	batchSize := cfg.GetMaxTaskQueue()
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+
+	workers := cfg.GetTaskWorkers()
+	if workers <= 0 {
+		workers = 1
+	}
+
+	return &scheduler{
+		queue:        q,
+		leader:       l,
+		handlers:     make(map[task.Action]*handler),
+		interval:     interval,
+		batchSize:    batchSize,
+		workers:      workers,
+		maxAttempts:  cfg.GetTaskMaxAttempts(),
+		backoff:      cfg.GetTaskBackoff(),
+		maxBackoff:   cfg.GetTaskMaxBackoff(),
+		drainTimeout: cfg.GetTaskDrainTimeout(),
+		logger:       log,
+	}
+}
@@ -0,0 +1,228 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+)
+
+// testConfig implements deferred tasks processing configuration with short intervals.
+type testConfig struct{}
+
+func (testConfig) GetCheckTaskInterval() time.Duration { return time.Millisecond }
+func (testConfig) GetMaxTaskQueue() int                { return 10 }
+func (testConfig) GetTaskWorkers() int                 { return 2 }
+func (testConfig) GetTaskMaxAttempts() int             { return 3 }
+func (testConfig) GetTaskBackoff() time.Duration       { return time.Second }
+func (testConfig) GetTaskMaxBackoff() time.Duration    { return 5 * time.Second }
+func (testConfig) GetTaskDrainTimeout() time.Duration  { return time.Second }
+
+func Test_scheduler_poll(t *testing.T) {
+	errStorage := errors.New("connection refused")
+	type fields struct {
+		attempts   int
+		busy       int
+		handlerErr error
+		leaseLost  bool
+	}
+	type want struct {
+		complete bool
+		retry    bool
+		fail     bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "positive process task",
+			fields: fields{
+				attempts: 1,
+			},
+			want: want{complete: true},
+		},
+		{
+			name: "positive process task (handler slot busy)",
+			fields: fields{
+				attempts: 1,
+				busy:     1,
+			},
+			want: want{complete: true},
+		},
+		{
+			name: "negative process task (retry)",
+			fields: fields{
+				attempts:   1,
+				handlerErr: errStorage,
+			},
+			want: want{retry: true},
+		},
+		{
+			name: "negative process task (attempts exhausted)",
+			fields: fields{
+				attempts:   3,
+				handlerErr: errStorage,
+			},
+			want: want{fail: true},
+		},
+		{
+			name: "negative process task (lease lost)",
+			fields: fields{
+				attempts:  2,
+				leaseLost: true,
+			},
+			want: want{complete: true},
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		queue := repoMock.NewMockQueue(ctl)
+		s := New(queue, testConfig{}, nil)
+
+		item := task.New(task.ActionDelete, uuid.New(), []uuid.UUID{uuid.New()})
+		item.Attempts = tt.fields.attempts
+		outcomes := map[uuid.UUID]task.Outcome{item.URLIDs[0]: task.OutcomeDeleted}
+		s.Handle(task.ActionDelete, func(_ context.Context, got task.Task) (map[uuid.UUID]task.Outcome, error) {
+			assert.Equal(t, item.ID, got.ID)
+			return outcomes, tt.fields.handlerErr
+		})
+		for i := 0; i < tt.fields.busy; i++ {
+			s.handlers[task.ActionDelete].slots <- struct{}{}
+		}
+
+		// no more tasks are claimed than the handler has free slots
+		limit := testConfig{}.GetTaskWorkers() - tt.fields.busy
+		queue.EXPECT().ClaimTasks(anyMock, task.ActionDelete, limit, taskLease).Return([]task.Task{item}, nil)
+		if tt.want.complete {
+			var err error
+			if tt.fields.leaseLost {
+				err = task.ErrLeaseLost
+			}
+			queue.EXPECT().CompleteTask(anyMock, item.ID, tt.fields.attempts, outcomes).Return(err)
+		}
+		if tt.want.retry {
+			queue.EXPECT().RetryTask(anyMock, item.ID, tt.fields.attempts, anyMock, errStorage.Error()).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, _ int, runAt time.Time, _ string) error {
+					assert.True(t, runAt.After(time.Now()))
+					return nil
+				})
+		}
+		if tt.want.fail {
+			queue.EXPECT().FailTask(anyMock, item.ID, tt.fields.attempts, anyMock).Return(nil)
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, 1, s.poll(ctx))
+			s.tasks.Wait()
+		})
+	}
+}
+
+func Test_scheduler_Run(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	queue := repoMock.NewMockQueue(ctl)
+	s := New(queue, testConfig{}, nil)
+
+	var running, maxRunning, processed int32
+	s.Handle(task.ActionDelete, func(context.Context, task.Task) (map[uuid.UUID]task.Outcome, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&processed, 1)
+		return nil, nil
+	}, Concurrency(1))
+
+	var runs int32
+	s.Schedule("count", Every(time.Millisecond), func(context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+
+	var pending int32 = 3
+	queue.EXPECT().ClaimTasks(anyMock, task.ActionDelete, 1, taskLease).DoAndReturn(
+		func(context.Context, task.Action, int, time.Duration) ([]task.Task, error) {
+			if atomic.AddInt32(&pending, -1) < 0 {
+				return nil, nil
+			}
+			return []task.Task{task.New(task.ActionDelete, uuid.New(), nil)}, nil
+		}).AnyTimes()
+	queue.EXPECT().CompleteTask(anyMock, anyMock, anyMock, anyMock).Return(nil).AnyTimes()
+	queue.EXPECT().CountTasks(anyMock, task.StatusPending).Return(0, nil).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	assert.NoError(t, s.Run(ctx))
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&processed))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxRunning))
+	assert.Positive(t, atomic.LoadInt32(&runs))
+}
+
+func Test_scheduler_delay(t *testing.T) {
+	s := New(nil, testConfig{}, nil)
+	assert.Equal(t, time.Second, s.delay(1))
+	assert.Equal(t, 2*time.Second, s.delay(2))
+	assert.Equal(t, 4*time.Second, s.delay(3))
+	assert.Equal(t, 5*time.Second, s.delay(4))
+}
+
+func Test_scheduler_Run_singleton(t *testing.T) {
+	tests := []struct {
+		name     string
+		isLeader bool
+	}{
+		{
+			name:     "positive run singleton job (leader)",
+			isLeader: true,
+		},
+		{
+			name:     "positive skip singleton job (follower)",
+			isLeader: false,
+		},
+	}
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	for _, tt := range tests {
+		leader := usecasesMock.NewMockLeader(ctl)
+		leader.EXPECT().IsLeader().Return(tt.isLeader).AnyTimes()
+		s := New(nil, testConfig{}, leader)
+
+		var runs int32
+		s.Schedule("singleton", Every(time.Millisecond), func(context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		}, Singleton())
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			assert.NoError(t, s.Run(ctx))
+			assert.Equal(t, tt.isLeader, atomic.LoadInt32(&runs) > 0)
+		})
+	}
+}
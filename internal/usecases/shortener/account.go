@@ -0,0 +1,131 @@
+package shortener
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/account"
+)
+
+// minPasswordLength defines the minimum length of the account password.
+const minPasswordLength = 8
+
+// Register implements the registration of a user account.
+// Short URLs of the current user ID are moved to the account.
+func (uc *useCase) Register(ctx context.Context, email, password, userID string) (account.Account, error) {
+	if uc.accounts == nil {
+		return nil, ErrAccountsNotSupported
+	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		uc.logger.Error("failed parse email", err, slog.String("email", email))
+		return nil, ErrParseEmail
+	}
+
+	if len(password) < minPasswordLength {
+		return nil, ErrWeakPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		uc.logger.Error("failed hash password", err)
+		return nil, err
+	}
+
+	a := account.NewAccount(uuid.New(), strings.ToLower(addr.Address))
+	a.SetPasswordHash(hash)
+	a.SetCreatedAt(time.Now().UTC())
+
+	if err = uc.accounts.AddAccount(ctx, a); err != nil {
+		uc.logger.Error("failed add account", err, slog.String("email", a.Email()))
+		return nil, err
+	}
+
+	if err = uc.adoptURLs(ctx, userID, a.ID()); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Login implements the authentication of a user account.
+// Short URLs of the current user ID are moved to the account.
+func (uc *useCase) Login(ctx context.Context, email, password, userID string) (account.Account, error) {
+	if uc.accounts == nil {
+		return nil, ErrAccountsNotSupported
+	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	a, err := uc.accounts.GetAccount(ctx, strings.ToLower(addr.Address))
+	if err != nil {
+		if errors.Is(err, account.ErrNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		uc.logger.Error("failed get account", err, slog.String("email", email))
+		return nil, err
+	}
+
+	if err = bcrypt.CompareHashAndPassword(a.PasswordHash(), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	if err = uc.adoptURLs(ctx, userID, a.ID()); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// SingleSignOn implements the authentication of a user by the identity provider.
+// The subject claim is mapped to a stable user ID and short URLs of the current user ID are moved to it.
+func (uc *useCase) SingleSignOn(ctx context.Context, issuer, subject, userID string) (string, error) {
+	if len(issuer) == 0 || len(subject) == 0 {
+		return "", ErrInvalidCredentials
+	}
+
+	id := uuid.NewSHA1(uuid.NewSHA1(uuid.NameSpaceURL, []byte(issuer)), []byte(subject))
+
+	if err := uc.adoptURLs(ctx, userID, id); err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// adoptURLs implements moving short URLs of the anonymous user ID to the account.
+func (uc *useCase) adoptURLs(ctx context.Context, userID string, accountID uuid.UUID) error {
+	if len(userID) == 0 {
+		return nil
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+		return ErrParseUUID
+	}
+
+	if parsedUserID == accountID {
+		return nil
+	}
+
+	err = uc.storage.ChangeOwner(ctx, parsedUserID, accountID)
+	if err != nil {
+		uc.logger.Error("failed change url owner", err, slog.String("userID", userID),
+			slog.String("accountID", accountID.String()))
+		return err
+	}
+
+	return nil
+}
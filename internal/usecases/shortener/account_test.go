@@ -0,0 +1,173 @@
+package shortener
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/account"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+func Test_useCase_Register(t *testing.T) {
+	type args struct {
+		email    string
+		password string
+		userID   string
+	}
+	type fields struct {
+		repoErr error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		fields  fields
+		wantErr error
+	}{
+		{
+			name: "positive register",
+			args: args{
+				email:    "User@Example.com",
+				password: "password",
+				userID:   "624708fa-d258-4b99-b09a-49d95f294626",
+			},
+		},
+		{
+			name: "negative register (invalid email)",
+			args: args{
+				email:    "invalid",
+				password: "password",
+			},
+			wantErr: ErrParseEmail,
+		},
+		{
+			name: "negative register (weak password)",
+			args: args{
+				email:    "user@example.com",
+				password: "pass",
+			},
+			wantErr: ErrWeakPassword,
+		},
+		{
+			name: "negative register (exist account)",
+			args: args{
+				email:    "user@example.com",
+				password: "password",
+			},
+			fields: fields{
+				repoErr: account.ErrAlreadyExist,
+			},
+			wantErr: account.ErrAlreadyExist,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		accounts := repoMock.NewMockAccount(ctl)
+		uc := New(repo, cfg.GetShortURL(), Accounts(accounts))
+
+		accounts.EXPECT().AddAccount(anyMock, anyMock).Return(tt.fields.repoErr).AnyTimes()
+		repo.EXPECT().ChangeOwner(anyMock, anyMock, anyMock).Return(nil).AnyTimes()
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Register(ctx, tt.args.email, tt.args.password, tt.args.userID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr, fmt.Sprintf("Register(%v)", tt.args.email))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "user@example.com", got.Email())
+			assert.NoError(t, bcrypt.CompareHashAndPassword(got.PasswordHash(), []byte(tt.args.password)))
+		})
+	}
+}
+
+func Test_useCase_Login(t *testing.T) {
+	type args struct {
+		email    string
+		password string
+		userID   string
+	}
+	type fields struct {
+		repoErr error
+	}
+	tests := []struct {
+		name      string
+		args      args
+		fields    fields
+		wantAdopt bool
+		wantErr   error
+	}{
+		{
+			name: "positive login (adopt anonymous urls)",
+			args: args{
+				email:    "user@example.com",
+				password: "password",
+				userID:   "624708fa-d258-4b99-b09a-49d95f294626",
+			},
+			wantAdopt: true,
+		},
+		{
+			name: "negative login (invalid password)",
+			args: args{
+				email:    "user@example.com",
+				password: "invalid",
+				userID:   "624708fa-d258-4b99-b09a-49d95f294626",
+			},
+			wantErr: ErrInvalidCredentials,
+		},
+		{
+			name: "negative login (account not found)",
+			args: args{
+				email:    "user@example.com",
+				password: "password",
+			},
+			fields: fields{
+				repoErr: account.ErrNotFound,
+			},
+			wantErr: ErrInvalidCredentials,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		accounts := repoMock.NewMockAccount(ctl)
+		uc := New(repo, cfg.GetShortURL(), Accounts(accounts))
+
+		a := account.NewAccount(uuid.New(), tt.args.email)
+		a.SetPasswordHash(hash)
+		accounts.EXPECT().GetAccount(anyMock, tt.args.email).Return(a, tt.fields.repoErr).AnyTimes()
+		if tt.wantAdopt {
+			repo.EXPECT().ChangeOwner(anyMock, uuid.MustParse(tt.args.userID), a.ID()).Return(nil)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Login(ctx, tt.args.email, tt.args.password, tt.args.userID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr, fmt.Sprintf("Login(%v)", tt.args.email))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, a.ID(), got.ID())
+		})
+	}
+}
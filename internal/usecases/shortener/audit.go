@@ -0,0 +1,80 @@
+package shortener
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/audit"
+)
+
+// GetAuditEvents implements getting audit log events of the period, optionally limited to the user.
+// Zero time values leave the period unbounded, empty user ID matches all users.
+func (uc *useCase) GetAuditEvents(ctx context.Context, userID string, from, to time.Time,
+	limit, offset int,
+) ([]audit.Event, error) {
+	if uc.events == nil {
+		return nil, ErrAuditNotSupported
+	}
+
+	filter := audit.Filter{
+		From:   from,
+		To:     to,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if len(userID) > 0 {
+		parsedUserID, err := uuid.Parse(userID)
+		if err != nil {
+			uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+			return nil, ErrParseUUID
+		}
+		filter.UserID = parsedUserID
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSearchLimit
+	}
+	if filter.Limit > maxSearchLimit {
+		filter.Limit = maxSearchLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	events, err := uc.events.GetEvents(ctx, filter)
+	if err != nil {
+		uc.logger.Error("failed get audit events", err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// audit implements recording the audit log events of the completed operation.
+// The events carry the network address of the operation, the trusted subnet operations are identified by it.
+// The operation is not rolled back when the audit log is unavailable, the failure is logged instead.
+func (uc *useCase) audit(ctx context.Context, action audit.Action, userID uuid.UUID, targetID ...uuid.UUID) {
+	if uc.events == nil || len(targetID) == 0 {
+		return
+	}
+
+	transport, actor := audit.TransportFromContext(ctx), audit.ActorFromContext(ctx)
+	createdAt := time.Now().UTC()
+	events := make([]audit.Event, len(targetID))
+	for idx, id := range targetID {
+		e := audit.NewEvent(uuid.New(), action, userID, id)
+		e.SetTransport(transport)
+		e.SetActor(actor)
+		e.SetCreatedAt(createdAt)
+		events[idx] = e
+	}
+
+	if err := uc.events.AddEvents(ctx, events); err != nil {
+		uc.logger.Error("failed add audit events", err, slog.String("action", string(action)),
+			slog.String("userID", userID.String()))
+	}
+}
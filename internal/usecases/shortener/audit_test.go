@@ -0,0 +1,185 @@
+package shortener
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/audit"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+func Test_useCase_audit(t *testing.T) {
+	type args struct {
+		transport audit.Transport
+	}
+	type fields struct {
+		auditErr error
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+	}{
+		{
+			name: "positive create url (http audit event)",
+			args: args{
+				transport: audit.TransportHTTP,
+			},
+		},
+		{
+			name: "positive create url (grpc audit event)",
+			args: args{
+				transport: audit.TransportGRPC,
+			},
+		},
+		{
+			name: "positive create url (audit log unavailable)",
+			args: args{
+				transport: audit.TransportHTTP,
+			},
+			fields: fields{
+				auditErr: errors.New("audit log unavailable"),
+			},
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	userID := uuid.MustParse("624708fa-d258-4b99-b09a-49d95f294626")
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		events := repoMock.NewMockAudit(ctl)
+		uc := New(repo, cfg.GetShortURL(), Audit(events))
+
+		var recorded []audit.Event
+		repo.EXPECT().IsBanned(anyMock, anyMock).Return(false, nil).AnyTimes()
+		repo.EXPECT().Add(anyMock, anyMock).Return(nil).AnyTimes()
+		events.EXPECT().AddEvents(anyMock, anyMock).DoAndReturn(func(_ context.Context, e []audit.Event) error {
+			recorded = append(recorded, e...)
+			return tt.fields.auditErr
+		})
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := audit.WithActor(audit.WithTransport(context.Background(), tt.args.transport), "192.168.88.1")
+			got, err := uc.CreateURL(ctx, "https://ya.ru", userID.String())
+			assert.NoError(t, err)
+			assert.Len(t, recorded, 1)
+			assert.Equal(t, audit.ActionCreate, recorded[0].Action())
+			assert.Equal(t, userID, recorded[0].UserID())
+			assert.Equal(t, got.ID(), recorded[0].TargetID())
+			assert.Equal(t, tt.args.transport, recorded[0].Transport())
+			assert.Equal(t, "192.168.88.1", recorded[0].Actor())
+			assert.False(t, recorded[0].CreatedAt().IsZero())
+		})
+	}
+}
+
+func Test_useCase_BanUser_audit(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cfg, err := config.NewConfig()
+	assert.NoError(t, err)
+	repo := repoMock.NewMockURL(ctl)
+	events := repoMock.NewMockAudit(ctl)
+	uc := New(repo, cfg.GetShortURL(), Audit(events))
+
+	// the trusted subnet operation has no user ID, the real IP identifies it
+	userID := uuid.New()
+	repo.EXPECT().BanUser(anyMock, userID, true).Return(nil)
+	events.EXPECT().AddEvents(anyMock, anyMock).DoAndReturn(func(_ context.Context, e []audit.Event) error {
+		assert.Len(t, e, 1)
+		assert.Equal(t, audit.ActionBan, e[0].Action())
+		assert.Equal(t, uuid.Nil, e[0].UserID())
+		assert.Equal(t, userID, e[0].TargetID())
+		assert.Equal(t, audit.TransportGRPC, e[0].Transport())
+		assert.Equal(t, "192.168.88.1", e[0].Actor())
+		return nil
+	})
+
+	ctx := audit.WithActor(audit.WithTransport(context.Background(), audit.TransportGRPC), "192.168.88.1")
+	assert.NoError(t, uc.BanUser(ctx, userID.String(), true))
+}
+
+func Test_useCase_GetAuditEvents(t *testing.T) {
+	type args struct {
+		userID string
+		limit  int
+	}
+	type want struct {
+		userID uuid.UUID
+		limit  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr error
+	}{
+		{
+			name: "positive get audit events (all users, default limit)",
+			want: want{
+				limit: defaultSearchLimit,
+			},
+		},
+		{
+			name: "positive get audit events (user, max limit)",
+			args: args{
+				userID: "624708fa-d258-4b99-b09a-49d95f294626",
+				limit:  maxSearchLimit + 1,
+			},
+			want: want{
+				userID: uuid.MustParse("624708fa-d258-4b99-b09a-49d95f294626"),
+				limit:  maxSearchLimit,
+			},
+		},
+		{
+			name: "negative get audit events (invalid user id)",
+			args: args{
+				userID: "invalid",
+			},
+			wantErr: ErrParseUUID,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	from := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		events := repoMock.NewMockAudit(ctl)
+		uc := New(repo, cfg.GetShortURL(), Audit(events))
+
+		events.EXPECT().GetEvents(anyMock, audit.Filter{
+			UserID: tt.want.userID,
+			From:   from,
+			To:     to,
+			Limit:  tt.want.limit,
+		}).Return([]audit.Event{}, nil).AnyTimes()
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.GetAuditEvents(ctx, tt.args.userID, from, to, tt.args.limit, 0)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr, fmt.Sprintf("GetAuditEvents(%v)", tt.args.userID))
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+
+	"github.com/google/uuid"
 )
 
 const base62Chars = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
@@ -39,11 +41,16 @@ func decodeUUID(s string) ([]byte, error) {
 		num.Add(num, big.NewInt(int64(index)))
 	}
 
-	uuidBytes := num.Bytes()
+	if num.BitLen() > 128 {
+		return nil, fmt.Errorf("invalid UUID length: %d", len(num.Bytes()))
+	}
 
-	if len(uuidBytes) < 16 {
-		return nil, fmt.Errorf("invalid UUID length: %d", len(uuidBytes))
+	// UUIDs starting with zero bytes are encoded without them, the bytes are restored by padding
+	var id uuid.UUID
+	num.FillBytes(id[:])
+	if id.Variant() != uuid.RFC4122 {
+		return nil, fmt.Errorf("invalid UUID variant: %s", id.Variant())
 	}
 
-	return uuidBytes, nil
+	return id[:], nil
 }
@@ -23,6 +23,13 @@ func Test_encodeUUID(t *testing.T) {
 			},
 			want: "2ZrI5IHFnvPscPYKlxFtRQ",
 		},
+		{
+			name: "positive encode uuid (leading zero byte)",
+			args: args{
+				uuid: "00a1b2c3-d4e5-4f60-8a7b-8c9d0e1f2a3b",
+			},
+			want: "1bTzuwhjzLwSmZXSQDfXJ",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -53,6 +60,14 @@ func Test_decodeUUID(t *testing.T) {
 			want:    "624708fa-d258-4b99-b09a-49d95f294626",
 			wantErr: false,
 		},
+		{
+			name: "positive decode uuid (leading zero bytes)",
+			args: args{
+				s: "jMIfK4co5eF3x1sUyxl",
+			},
+			want:    "0000b2c3-d4e5-4f60-8a7b-8c9d0e1f2a3b",
+			wantErr: false,
+		},
 
 		{
 			name: "negative decode uuid",
@@ -61,6 +76,13 @@ func Test_decodeUUID(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative decode uuid (too long)",
+			args: args{
+				s: "2ZrI5IHFnvPscPYKlxFtRQ2ZrI5IHFnvPscPYKlxFtRQ",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
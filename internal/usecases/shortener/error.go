@@ -13,5 +13,44 @@ var ErrDecodeURL = errors.New("URL decoding error")
 // ErrParseUUID implements shortener UUID parsing error.
 var ErrParseUUID = errors.New("UUID parsing error")
 
-// ErrTaskBufferFull implements shortener Utask buffer full error.
-var ErrTaskBufferFull = errors.New("task buffer full")
+// ErrParseEmail implements shortener email parsing error.
+var ErrParseEmail = errors.New("email parsing error")
+
+// ErrWeakPassword implements shortener weak password error.
+var ErrWeakPassword = errors.New("password is too short")
+
+// ErrInvalidCredentials implements shortener invalid account credentials error.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// ErrAccountsNotSupported implements shortener accounts not supported error.
+var ErrAccountsNotSupported = errors.New("accounts not supported")
+
+// ErrWorkspacesNotSupported implements shortener workspaces not supported error.
+var ErrWorkspacesNotSupported = errors.New("workspaces not supported")
+
+// ErrEmptyWorkspaceName implements shortener empty workspace name error.
+var ErrEmptyWorkspaceName = errors.New("empty workspace name")
+
+// ErrUserBanned implements shortener banned user error.
+var ErrUserBanned = errors.New("user banned")
+
+// ErrReportsNotSupported implements shortener abuse reports not supported error.
+var ErrReportsNotSupported = errors.New("reports not supported")
+
+// ErrInvalidReason implements shortener invalid report reason error.
+var ErrInvalidReason = errors.New("invalid report reason")
+
+// ErrAuditNotSupported implements shortener audit log not supported error.
+var ErrAuditNotSupported = errors.New("audit log not supported")
+
+// ErrWebhooksNotSupported implements shortener webhooks not supported error.
+var ErrWebhooksNotSupported = errors.New("webhooks not supported")
+
+// ErrEmptyWebhookEvents implements shortener webhook without event types error.
+var ErrEmptyWebhookEvents = errors.New("empty webhook event types")
+
+// ErrJobsNotSupported implements shortener job status not supported error.
+var ErrJobsNotSupported = errors.New("job status not supported")
+
+// ErrSwitchoverNotSupported implements shortener storage promotion not supported error.
+var ErrSwitchoverNotSupported = errors.New("storage promotion not supported")
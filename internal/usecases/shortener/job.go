@@ -0,0 +1,65 @@
+package shortener
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/task"
+)
+
+// GetJob implements getting the state of the asynchronous job of the user
+// with the outcome for every short URL of the job.
+func (uc *useCase) GetJob(ctx context.Context, userID, jobID string) (task.Job, error) {
+	if uc.queue == nil {
+		return task.Job{}, ErrJobsNotSupported
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+		return task.Job{}, ErrParseUUID
+	}
+
+	parsedJobID, err := uuid.Parse(jobID)
+	if err != nil {
+		uc.logger.Error("failed parse RFC 4122 uuid from job id", err, slog.String("jobID", jobID))
+		return task.Job{}, ErrParseUUID
+	}
+
+	t, err := uc.queue.GetTask(ctx, parsedJobID)
+	if err != nil {
+		uc.logger.Error("failed get task", err, slog.String("jobID", jobID))
+		return task.Job{}, err
+	}
+
+	if t.UserID != parsedUserID {
+		return task.Job{}, task.ErrNotFound
+	}
+
+	job := task.Job{
+		ID:        t.ID,
+		Action:    t.Action,
+		Status:    t.Status,
+		Attempts:  t.Attempts,
+		CreatedAt: t.CreatedAt,
+		URLs:      make([]task.JobURL, len(t.URLIDs)),
+	}
+
+	for idx, id := range t.URLIDs {
+		shortURL := url.URL{
+			Scheme: uc.baseURL.Scheme,
+			Host:   uc.baseURL.Host,
+			Path:   encodeUUID(id),
+		}
+		job.URLs[idx] = task.JobURL{
+			ID:       encodeUUID(id),
+			ShortURL: shortURL.String(),
+			Outcome:  t.Outcome(id),
+		}
+	}
+
+	return job, nil
+}
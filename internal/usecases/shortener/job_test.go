@@ -0,0 +1,112 @@
+package shortener
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/task"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+func Test_useCase_GetJob(t *testing.T) {
+	userID := uuid.New()
+	deletedID := uuid.MustParse("c9f7c7a6-2a44-4d6a-9e18-bc0e1b37e5a1")
+	pendingID := uuid.MustParse("7b1e4f0a-5c3d-4e8f-a2b6-9d0c1e2f3a4b")
+
+	item := task.New(task.ActionDelete, userID, []uuid.UUID{deletedID, pendingID})
+	item.Status = task.StatusRunning
+	item.Outcomes = map[uuid.UUID]task.Outcome{deletedID: task.OutcomeDeleted}
+
+	type args struct {
+		userID string
+		jobID  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		repoErr error
+		wantErr error
+	}{
+		{
+			name: "positive get job",
+			args: args{
+				userID: userID.String(),
+				jobID:  item.ID.String(),
+			},
+		},
+		{
+			name: "negative get job (other user)",
+			args: args{
+				userID: uuid.New().String(),
+				jobID:  item.ID.String(),
+			},
+			wantErr: task.ErrNotFound,
+		},
+		{
+			name: "negative get job (not found)",
+			args: args{
+				userID: userID.String(),
+				jobID:  item.ID.String(),
+			},
+			repoErr: task.ErrNotFound,
+			wantErr: task.ErrNotFound,
+		},
+		{
+			name: "negative get job (invalid job id)",
+			args: args{
+				userID: userID.String(),
+				jobID:  "invalid",
+			},
+			wantErr: ErrParseUUID,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		queue := repoMock.NewMockQueue(ctl)
+		uc := New(repo, cfg.GetShortURL(), Queue(queue))
+
+		queue.EXPECT().GetTask(anyMock, item.ID).Return(item, tt.repoErr).AnyTimes()
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.GetJob(ctx, tt.args.userID, tt.args.jobID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr, fmt.Sprintf("GetJob(%v)", tt.args.jobID))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, task.StatusRunning, got.Status)
+			assert.Equal(t, []task.JobURL{
+				{
+					ID:       encodeUUID(deletedID),
+					ShortURL: cfg.GetShortURL().GetBaseURL().JoinPath(encodeUUID(deletedID)).String(),
+					Outcome:  task.OutcomeDeleted,
+				},
+				{
+					ID:       encodeUUID(pendingID),
+					ShortURL: cfg.GetShortURL().GetBaseURL().JoinPath(encodeUUID(pendingID)).String(),
+					Outcome:  task.OutcomePending,
+				},
+			}, got.URLs)
+		})
+	}
+
+	t.Run("negative get job (not supported)", func(t *testing.T) {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		uc := New(repoMock.NewMockURL(ctl), cfg.GetShortURL())
+		_, err = uc.GetJob(ctx, userID.String(), item.ID.String())
+		assert.ErrorIs(t, err, ErrJobsNotSupported)
+	})
+}
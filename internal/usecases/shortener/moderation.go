@@ -0,0 +1,165 @@
+package shortener
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/audit"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+const (
+	// defaultSearchLimit defines the number of short URLs returned by search when the limit is not set.
+	defaultSearchLimit = 100
+	// maxSearchLimit defines the maximum number of short URLs returned by search.
+	maxSearchLimit = 1000
+)
+
+// SearchURLs implements searching short URLs of all users by the long URL substring.
+// Empty user ID matches all users.
+func (uc *useCase) SearchURLs(ctx context.Context, query, userID string, limit, offset int) ([]entity.URL, error) {
+	filter := entity.Filter{
+		Query:  query,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if len(userID) > 0 {
+		parsedUserID, err := uuid.Parse(userID)
+		if err != nil {
+			uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+			return nil, ErrParseUUID
+		}
+		filter.UserID = parsedUserID
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSearchLimit
+	}
+	if filter.Limit > maxSearchLimit {
+		filter.Limit = maxSearchLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	urls, err := uc.storage.Search(ctx, filter)
+	if err != nil {
+		uc.logger.Error("failed search urls", err, slog.String("query", query))
+		return nil, err
+	}
+
+	for idx, i := range urls {
+		shortURL := url.URL{
+			Scheme: uc.baseURL.Scheme,
+			Host:   uc.baseURL.Host,
+		}
+		shortURL.Path = encodeUUID(i.ID())
+		urls[idx].SetShortURL(shortURL)
+	}
+
+	return urls, nil
+}
+
+// DisableURL implements the setting of the moderation attribute, disabled short URLs are not redirected.
+func (uc *useCase) DisableURL(ctx context.Context, urlID string, disabled bool) error {
+	id, err := uc.parseURLID(urlID)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.storage.SetDisabled(ctx, id, disabled); err != nil {
+		uc.logger.Error("failed set url disabled", err, slog.String("urlID", urlID))
+		return err
+	}
+
+	uc.audit(ctx, moderationAction(disabled), uuid.Nil, id)
+
+	return nil
+}
+
+// ForceDeleteURL implements the removal of short URLs regardless of the owner.
+func (uc *useCase) ForceDeleteURL(ctx context.Context, urlID []string) error {
+	ids := make([]uuid.UUID, len(urlID))
+	for idx, i := range urlID {
+		id, err := uc.parseURLID(i)
+		if err != nil {
+			return err
+		}
+		ids[idx] = id
+	}
+
+	if err := uc.storage.ForceDelete(ctx, ids); err != nil {
+		uc.logger.Error("failed force delete urls", err)
+		return err
+	}
+
+	uc.audit(ctx, audit.ActionDelete, uuid.Nil, ids...)
+
+	return nil
+}
+
+// BanUser implements the setting of the user ban, banned users can't create short URLs.
+func (uc *useCase) BanUser(ctx context.Context, userID string, banned bool) error {
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+		return ErrParseUUID
+	}
+
+	if err = uc.storage.BanUser(ctx, parsedUserID, banned); err != nil {
+		uc.logger.Error("failed ban user", err, slog.String("userID", userID))
+		return err
+	}
+
+	action := audit.ActionUnban
+	if banned {
+		action = audit.ActionBan
+	}
+	uc.audit(ctx, action, uuid.Nil, parsedUserID)
+
+	return nil
+}
+
+// checkBanned implements checking that the user is allowed to create short URLs.
+func (uc *useCase) checkBanned(ctx context.Context, userID uuid.UUID) error {
+	banned, err := uc.storage.IsBanned(ctx, userID)
+	if err != nil {
+		uc.logger.Error("failed check user ban", err, slog.String("userID", userID.String()))
+		return err
+	}
+
+	if banned {
+		return ErrUserBanned
+	}
+
+	return nil
+}
+
+// moderationAction implements getting the audited operation of the short URL moderation.
+func moderationAction(disabled bool) audit.Action {
+	if disabled {
+		return audit.ActionDisable
+	}
+	return audit.ActionRestore
+}
+
+// parseURLID implements getting the short URL uuid from its encoded ID.
+func (uc *useCase) parseURLID(urlID string) (uuid.UUID, error) {
+	decoded, err := decodeUUID(urlID)
+	if err != nil {
+		uc.logger.Error("decode short url", err)
+		return uuid.UUID{}, ErrDecodeURL
+	}
+
+	id, err := uuid.FromBytes(decoded)
+	if err != nil {
+		uc.logger.Error("failed create uuid from url id", err, slog.String("urlID", urlID))
+		return uuid.UUID{}, ErrParseUUID
+	}
+
+	return id, nil
+}
@@ -0,0 +1,62 @@
+package shortener
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/outbox"
+	"github.com/sreway/shorturl/internal/domain/webhook"
+)
+
+// outboxEvents defines the webhook event types of the outbox message types.
+var outboxEvents = map[outbox.Type]webhook.EventType{
+	outbox.TypeURLCreated: webhook.EventURLCreated,
+	outbox.TypeURLDeleted: webhook.EventURLDeleted,
+}
+
+// Dispatch implements publishing the short URL events relayed from the outbox to the webhooks of users.
+// The webhook event ID is the message ID, it is sent in the event ID header and the payload,
+// so receivers deduplicate events redelivered when the relay stops before marking them dispatched.
+func (uc *useCase) Dispatch(ctx context.Context, messages []outbox.Message) error {
+	if uc.notifier == nil {
+		return nil
+	}
+
+	for _, m := range messages {
+		event, ok := outboxEvents[m.Type]
+		if !ok {
+			uc.logger.Warn("unknown outbox message type", slog.String("type", string(m.Type)),
+				slog.String("id", m.ID.String()))
+			continue
+		}
+
+		payload := new(outbox.URLPayload)
+		if err := json.Unmarshal(m.Payload, payload); err != nil {
+			uc.logger.Error("failed unmarshal outbox payload", err, slog.String("id", m.ID.String()))
+			continue
+		}
+
+		shortURL := url.URL{
+			Scheme: uc.baseURL.Scheme,
+			Host:   uc.baseURL.Host,
+			Path:   encodeUUID(m.URLID),
+		}
+
+		uc.notifier.Publish(ctx, webhook.Event{
+			ID:        m.ID,
+			Type:      event,
+			UserID:    m.UserID,
+			CreatedAt: m.CreatedAt,
+			Data: webhook.URLData{
+				ID:          encodeUUID(m.URLID),
+				ShortURL:    shortURL.String(),
+				OriginalURL: payload.OriginalURL,
+			},
+		})
+	}
+
+	return nil
+}
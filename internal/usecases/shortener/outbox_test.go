@@ -0,0 +1,100 @@
+package shortener
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/outbox"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/webhook"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+)
+
+func Test_useCase_Dispatch(t *testing.T) {
+	id := uuid.MustParse("c9f7c7a6-2a44-4d6a-9e18-bc0e1b37e5a1")
+	userID := uuid.New()
+
+	created := entity.NewURL(id, userID)
+	created.SetLongURL(url.URL{Scheme: "https", Host: "ya.ru"})
+
+	tests := []struct {
+		name     string
+		message  outbox.Type
+		want     webhook.EventType
+		original string
+	}{
+		{
+			name:     "positive dispatch created event",
+			message:  outbox.TypeURLCreated,
+			want:     webhook.EventURLCreated,
+			original: "https://ya.ru",
+		},
+		{
+			name:    "positive dispatch deleted event",
+			message: outbox.TypeURLDeleted,
+			want:    webhook.EventURLDeleted,
+		},
+		{
+			name:    "positive dispatch unknown event",
+			message: outbox.Type("url.archived"),
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		n := usecasesMock.NewMockNotifier(ctl)
+		uc := New(repo, cfg.GetShortURL(), Notifier(n), OutboxEvents())
+
+		m, err := outbox.NewURLMessage(tt.message, created)
+		assert.NoError(t, err)
+
+		if len(tt.want) > 0 {
+			n.EXPECT().Publish(anyMock, anyMock).Do(func(_ context.Context, event webhook.Event) {
+				assert.Equal(t, m.ID, event.ID)
+				assert.Equal(t, tt.want, event.Type)
+				assert.Equal(t, userID, event.UserID)
+				assert.Equal(t, webhook.URLData{
+					ID:          encodeUUID(id),
+					ShortURL:    cfg.GetShortURL().GetBaseURL().JoinPath(encodeUUID(id)).String(),
+					OriginalURL: tt.original,
+				}, event.Data)
+			})
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, uc.Dispatch(ctx, []outbox.Message{m}))
+		})
+	}
+}
+
+func Test_useCase_CreateURL_outboxEvents(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cfg, err := config.NewConfig()
+	assert.NoError(t, err)
+	repo := repoMock.NewMockURL(ctl)
+	n := usecasesMock.NewMockNotifier(ctl)
+	uc := New(repo, cfg.GetShortURL(), Notifier(n), OutboxEvents())
+
+	repo.EXPECT().IsBanned(anyMock, anyMock).Return(false, nil)
+	repo.EXPECT().Add(anyMock, anyMock).Return(nil)
+	n.EXPECT().Publish(anyMock, anyMock).Times(0)
+
+	_, err = uc.CreateURL(context.Background(), "https://ya.ru", uuid.New().String())
+	assert.NoError(t, err)
+}
@@ -2,69 +2,69 @@ package shortener
 
 import (
 	"context"
+	"net/url"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/exp/slog"
 
-	"github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/audit"
+	"github.com/sreway/shorturl/internal/domain/task"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/webhook"
 )
 
-type (
-	action string
-	// task implements the task type for the queue.
-	task struct {
-		name action
-		urls []url.URL
+// DeleteTask implements applying the deferred deletion task, the outcomes for the short URLs of the task are returned.
+// The deletion is audited and notified only for the short URLs deleted by the task.
+func (uc *useCase) DeleteTask(ctx context.Context, t task.Task) (map[uuid.UUID]task.Outcome, error) {
+	urls := make([]entity.URL, len(t.URLIDs))
+	for idx, id := range t.URLIDs {
+		u := entity.NewURL(id, t.UserID)
+		u.SetDeleted(true)
+		urls[idx] = u
 	}
-)
 
-const (
-	deleteAction action = "delete"
-)
+	outcomes, err := uc.storage.BatchDelete(ctx, urls)
+	if err != nil {
+		return nil, err
+	}
 
-// NewTask implements the creation of task for queue.
-func NewTask(name action, urls []url.URL) *task {
-	return &task{
-		name: name,
-		urls: urls,
+	ids := make([]uuid.UUID, 0, len(urls))
+	deleted := make([]entity.URL, 0, len(urls))
+	for _, u := range urls {
+		if outcomes[u.ID()] != task.OutcomeDeleted {
+			continue
+		}
+
+		shortURL := url.URL{
+			Scheme: uc.baseURL.Scheme,
+			Host:   uc.baseURL.Host,
+		}
+		shortURL.Path = encodeUUID(u.ID())
+		u.SetShortURL(shortURL)
+		ids = append(ids, u.ID())
+		deleted = append(deleted, u)
 	}
-}
 
-// ProcQueue implements processing task queue.
-func (uc *useCase) ProcQueue(ctx context.Context, checkInterval time.Duration) error {
-	tick := time.NewTicker(checkInterval)
-	defer tick.Stop()
-	for {
-		select {
-		case <-tick.C:
-			if len(uc.taskQueue) == 0 {
-				continue
-			}
+	uc.audit(ctx, audit.ActionDelete, t.UserID, ids...)
+	uc.notify(ctx, webhook.EventURLDeleted, deleted...)
 
-			actions := make(map[action][]url.URL, len(uc.taskQueue))
+	return outcomes, nil
+}
 
-			for len(uc.taskQueue) != 0 {
-				t := <-uc.taskQueue
-				actions[t.name] = append(actions[t.name], t.urls...)
-			}
+// PurgeTasks implements deleting the processed deferred tasks kept longer than the retention,
+// the job status of purged tasks is not found.
+func (uc *useCase) PurgeTasks(ctx context.Context) error {
+	if uc.queue == nil || uc.taskRetention <= 0 {
+		return nil
+	}
 
-			for k, v := range actions {
-				switch k {
-				case deleteAction:
-					err := uc.storage.BatchDelete(ctx, v)
-					if err != nil {
-						uc.logger.Error("failed batch update", err, slog.String("func", "ProcQueue"))
-						continue
-					}
-				default:
-					uc.logger.Warn("unknown task action", slog.Any("action", k),
-						slog.String("func", "ProcQueue"))
-				}
-			}
-		case <-ctx.Done():
-			close(uc.taskQueue)
-			uc.logger.Info("stop processed task queue")
-			return nil
-		}
+	n, err := uc.queue.PurgeTasks(ctx, time.Now().Add(-uc.taskRetention))
+	if err != nil {
+		uc.logger.Error("failed purge tasks", err, slog.String("func", "PurgeTasks"))
+		return err
 	}
+
+	uc.logger.Info("processed tasks purged", slog.Int("count", n))
+	return nil
 }
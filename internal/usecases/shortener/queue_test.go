@@ -0,0 +1,151 @@
+package shortener
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/audit"
+	"github.com/sreway/shorturl/internal/domain/task"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+func Test_useCase_DeleteTask(t *testing.T) {
+	errStorage := errors.New("connection refused")
+	tests := []struct {
+		name    string
+		repoErr error
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "positive delete task",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "negative delete task (storage error)",
+			repoErr: errStorage,
+			wantErr: assert.Error,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		uc := New(repo, cfg.GetShortURL())
+
+		item := task.New(task.ActionDelete, uuid.New(), []uuid.UUID{uuid.New()})
+		outcomes := map[uuid.UUID]task.Outcome{item.URLIDs[0]: task.OutcomeDeleted}
+		repo.EXPECT().BatchDelete(anyMock, anyMock).DoAndReturn(
+			func(_ context.Context, urls []entity.URL) (map[uuid.UUID]task.Outcome, error) {
+				assert.Len(t, urls, 1)
+				assert.Equal(t, item.URLIDs[0], urls[0].ID())
+				assert.Equal(t, item.UserID, urls[0].UserID())
+				assert.True(t, urls[0].Deleted())
+				return outcomes, tt.repoErr
+			})
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.DeleteTask(ctx, item)
+			if !tt.wantErr(t, err, "DeleteTask()") {
+				return
+			}
+			if err != nil {
+				return
+			}
+			assert.Equal(t, outcomes, got)
+		})
+	}
+}
+
+func Test_useCase_DeleteTask_audit(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cfg, err := config.NewConfig()
+	assert.NoError(t, err)
+	repo := repoMock.NewMockURL(ctl)
+	events := repoMock.NewMockAudit(ctl)
+	uc := New(repo, cfg.GetShortURL(), Audit(events))
+
+	var (
+		deletedID  = uuid.MustParse("3b1f2c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d")
+		notOwnedID = uuid.MustParse("4c2a3d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e")
+		missingID  = uuid.MustParse("5d3b4e6f-7a8b-4c9d-8e1f-2a3b4c5d6e7f")
+	)
+	item := task.New(task.ActionDelete, uuid.New(), []uuid.UUID{deletedID, notOwnedID, missingID})
+	repo.EXPECT().BatchDelete(anyMock, anyMock).Return(map[uuid.UUID]task.Outcome{
+		deletedID:  task.OutcomeDeleted,
+		notOwnedID: task.OutcomeNotOwned,
+		missingID:  task.OutcomeNotFound,
+	}, nil)
+
+	var recorded []audit.Event
+	events.EXPECT().AddEvents(anyMock, anyMock).DoAndReturn(func(_ context.Context, e []audit.Event) error {
+		recorded = append(recorded, e...)
+		return nil
+	})
+
+	_, err = uc.DeleteTask(context.Background(), item)
+	assert.NoError(t, err)
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, audit.ActionDelete, recorded[0].Action())
+	assert.Equal(t, item.UserID, recorded[0].UserID())
+	assert.Equal(t, deletedID, recorded[0].TargetID())
+}
+
+func Test_useCase_PurgeTasks(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cfg, err := config.NewConfig()
+	assert.NoError(t, err)
+	repo := repoMock.NewMockURL(ctl)
+	queue := repoMock.NewMockQueue(ctl)
+	uc := New(repo, cfg.GetShortURL(), Queue(queue))
+
+	queue.EXPECT().PurgeTasks(anyMock, anyMock).DoAndReturn(func(_ context.Context, before time.Time) (int, error) {
+		assert.True(t, before.Before(time.Now().Add(-cfg.GetShortURL().GetTaskRetention()+time.Minute)))
+		return 1, nil
+	})
+	assert.NoError(t, uc.PurgeTasks(context.Background()))
+}
+
+func Test_useCase_DeleteURL_queue(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cfg, err := config.NewConfig()
+	assert.NoError(t, err)
+	repo := repoMock.NewMockURL(ctl)
+	queue := repoMock.NewMockQueue(ctl)
+	uc := New(repo, cfg.GetShortURL(), Queue(queue))
+
+	userID := uuid.New()
+	id := uuid.MustParse("c9f7c7a6-2a44-4d6a-9e18-bc0e1b37e5a1")
+	queue.EXPECT().AddTask(anyMock, anyMock).DoAndReturn(func(_ context.Context, item task.Task) error {
+		assert.Equal(t, task.ActionDelete, item.Action)
+		assert.Equal(t, task.StatusPending, item.Status)
+		assert.Equal(t, userID, item.UserID)
+		assert.Equal(t, []uuid.UUID{id}, item.URLIDs)
+		return nil
+	})
+
+	jobID, err := uc.DeleteURL(context.Background(), userID.String(), []string{encodeUUID(id)})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, jobID)
+}
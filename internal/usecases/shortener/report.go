@@ -0,0 +1,144 @@
+package shortener
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	"github.com/sreway/shorturl/internal/domain/report"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+)
+
+// maxReasonLength defines the maximum length of the report reason.
+const maxReasonLength = 500
+
+// ReportURL implements the reporting of the short URL abuse by the user.
+func (uc *useCase) ReportURL(ctx context.Context, userID, urlID, reason string) error {
+	if uc.reports == nil {
+		return ErrReportsNotSupported
+	}
+
+	reason = strings.TrimSpace(reason)
+	if len(reason) == 0 || utf8.RuneCountInString(reason) > maxReasonLength {
+		return ErrInvalidReason
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+		return ErrParseUUID
+	}
+
+	id, err := uc.parseURLID(urlID)
+	if err != nil {
+		return err
+	}
+
+	u, err := uc.storage.Get(ctx, id)
+	if err != nil {
+		uc.logger.Error("failed get url", err, slog.String("urlID", urlID))
+		return err
+	}
+
+	if u.Deleted() {
+		return entity.ErrDeleted
+	}
+
+	item := report.NewReport(uuid.New(), id, parsedUserID)
+	item.SetReason(reason)
+	item.SetCreatedAt(time.Now().UTC())
+
+	if err = uc.reports.AddReport(ctx, item); err != nil {
+		uc.logger.Error("failed add report", err, slog.String("urlID", urlID))
+		return err
+	}
+
+	return nil
+}
+
+// GetReportQueue implements getting the moderation queue of reported short URLs.
+// Short URLs whose open reports crossed the threshold are flagged, they are not redirected until the review.
+func (uc *useCase) GetReportQueue(ctx context.Context, limit, offset int) ([]report.Summary, error) {
+	if uc.reports == nil {
+		return nil, ErrReportsNotSupported
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	queue, err := uc.reports.GetReportQueue(ctx, limit, offset)
+	if err != nil {
+		uc.logger.Error("failed get report queue", err)
+		return nil, err
+	}
+
+	for idx, item := range queue {
+		shortURL := url.URL{
+			Scheme: uc.baseURL.Scheme,
+			Host:   uc.baseURL.Host,
+		}
+		shortURL.Path = encodeUUID(item.URLID)
+		queue[idx].ShortURL = shortURL.String()
+		queue[idx].Flagged = uc.reportThreshold > 0 && item.Count >= uc.reportThreshold
+	}
+
+	return queue, nil
+}
+
+// ResolveReports implements the moderator review of the reported short URL.
+// The short URL is disabled or enabled according to the decision and its open reports are closed.
+func (uc *useCase) ResolveReports(ctx context.Context, urlID string, disable bool) error {
+	if uc.reports == nil {
+		return ErrReportsNotSupported
+	}
+
+	id, err := uc.parseURLID(urlID)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.storage.SetDisabled(ctx, id, disable); err != nil {
+		uc.logger.Error("failed set url disabled", err, slog.String("urlID", urlID))
+		return err
+	}
+
+	if err = uc.reports.ResolveReports(ctx, id); err != nil {
+		uc.logger.Error("failed resolve reports", err, slog.String("urlID", urlID))
+		return err
+	}
+
+	uc.audit(ctx, moderationAction(disable), uuid.Nil, id)
+
+	return nil
+}
+
+// checkReports implements checking that the short URL has not crossed the report threshold.
+func (uc *useCase) checkReports(ctx context.Context, id uuid.UUID) error {
+	if uc.reports == nil || uc.reportThreshold <= 0 {
+		return nil
+	}
+
+	count, err := uc.reports.CountReports(ctx, id)
+	if err != nil {
+		uc.logger.Error("failed count reports", err, slog.String("id", id.String()))
+		return err
+	}
+
+	if count >= uc.reportThreshold {
+		return entity.ErrDisabled
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package shortener
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/report"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+func Test_useCase_ReportURL(t *testing.T) {
+	type args struct {
+		reason string
+	}
+	type fields struct {
+		getErr    error
+		reportErr error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		fields  fields
+		wantErr error
+	}{
+		{
+			name: "positive report url",
+			args: args{
+				reason: "phishing",
+			},
+		},
+		{
+			name: "negative report url (empty reason)",
+			args: args{
+				reason: "  ",
+			},
+			wantErr: ErrInvalidReason,
+		},
+		{
+			name: "negative report url (reason too long)",
+			args: args{
+				reason: strings.Repeat("a", maxReasonLength+1),
+			},
+			wantErr: ErrInvalidReason,
+		},
+		{
+			name: "negative report url (not found)",
+			args: args{
+				reason: "phishing",
+			},
+			fields: fields{
+				getErr: entity.ErrNotFound,
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name: "negative report url (already reported)",
+			args: args{
+				reason: "phishing",
+			},
+			fields: fields{
+				reportErr: report.ErrAlreadyExist,
+			},
+			wantErr: report.ErrAlreadyExist,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	id := uuid.MustParse("c9f7c7a6-2a44-4d6a-9e18-bc0e1b37e5a1")
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		reports := repoMock.NewMockReport(ctl)
+		uc := New(repo, cfg.GetShortURL(), Reports(reports))
+
+		u := entity.NewURL(id, uuid.New())
+		u.SetLongURL(url.URL{Scheme: "https", Host: "ya.ru"})
+		repo.EXPECT().Get(anyMock, id).Return(u, tt.fields.getErr).AnyTimes()
+		reports.EXPECT().AddReport(anyMock, anyMock).DoAndReturn(func(_ context.Context, item report.Report) error {
+			assert.Equal(t, id, item.URLID())
+			assert.Equal(t, userID, item.ReporterID())
+			return tt.fields.reportErr
+		}).AnyTimes()
+		t.Run(tt.name, func(t *testing.T) {
+			err := uc.ReportURL(ctx, userID.String(), encodeUUID(id), tt.args.reason)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr, fmt.Sprintf("ReportURL(%v)", tt.args.reason))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_useCase_GetReportQueue(t *testing.T) {
+	type fields struct {
+		threshold int
+		count     int
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		wantFlagged bool
+	}{
+		{
+			name: "positive get report queue (below threshold)",
+			fields: fields{
+				threshold: 3,
+				count:     2,
+			},
+		},
+		{
+			name: "positive get report queue (threshold disabled)",
+			fields: fields{
+				count: 10,
+			},
+		},
+		{
+			name: "positive get report queue (threshold crossed)",
+			fields: fields{
+				threshold: 3,
+				count:     3,
+			},
+			wantFlagged: true,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	id := uuid.MustParse("c9f7c7a6-2a44-4d6a-9e18-bc0e1b37e5a1")
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		reports := repoMock.NewMockReport(ctl)
+		uc := New(repo, cfg.GetShortURL(), Reports(reports))
+		uc.reportThreshold = tt.fields.threshold
+
+		reports.EXPECT().GetReportQueue(anyMock, anyMock, anyMock).Return([]report.Summary{
+			{URLID: id, LongURL: "https://ya.ru", Count: tt.fields.count},
+		}, nil)
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.GetReportQueue(ctx, 10, 0)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.wantFlagged, got[0].Flagged)
+			assert.True(t, strings.HasSuffix(got[0].ShortURL, encodeUUID(id)))
+		})
+	}
+}
+
+func Test_useCase_GetURL_reported(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	id := uuid.MustParse("d1a8e4b2-7c3f-4e5a-9b6d-2f0c8a1e3b47")
+	cfg, err := config.NewConfig()
+	assert.NoError(t, err)
+	repo := repoMock.NewMockURL(ctl)
+	reports := repoMock.NewMockReport(ctl)
+	uc := New(repo, cfg.GetShortURL(), Reports(reports))
+	uc.reportThreshold = 3
+
+	u := entity.NewURL(id, uuid.New())
+	u.SetLongURL(url.URL{Scheme: "https", Host: "ya.ru"})
+	repo.EXPECT().Get(anyMock, id).Return(u, nil).AnyTimes()
+	gomock.InOrder(
+		reports.EXPECT().CountReports(anyMock, id).Return(3, nil),
+		repo.EXPECT().SetDisabled(anyMock, id, false).Return(nil),
+		reports.EXPECT().ResolveReports(anyMock, id).Return(nil),
+		reports.EXPECT().CountReports(anyMock, id).Return(0, nil),
+	)
+
+	// the short URL is not redirected once its open reports reach the threshold
+	_, err = uc.GetURL(ctx, encodeUUID(id))
+	assert.ErrorIs(t, err, entity.ErrDisabled)
+
+	// the moderator keeps the short URL, its reports are resolved
+	assert.NoError(t, uc.ResolveReports(ctx, encodeUUID(id), false))
+
+	got, err := uc.GetURL(ctx, encodeUUID(id))
+	assert.NoError(t, err)
+	assert.Equal(t, u.LongURL(), got.LongURL())
+}
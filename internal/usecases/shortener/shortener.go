@@ -6,25 +6,131 @@ import (
 	"errors"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/exp/slog"
 
 	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/audit"
+	"github.com/sreway/shorturl/internal/domain/health"
 	"github.com/sreway/shorturl/internal/domain/stats"
+	"github.com/sreway/shorturl/internal/domain/task"
 	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/webhook"
+	"github.com/sreway/shorturl/internal/domain/workspace"
+	"github.com/sreway/shorturl/internal/usecases"
 	"github.com/sreway/shorturl/internal/usecases/adapters/storage"
 )
 
 type (
 	useCase struct {
-		baseURL   *url.URL
-		storage   storage.URL
-		logger    *slog.Logger
-		taskQueue chan task
+		baseURL    *url.URL
+		storage    storage.URL
+		accounts   storage.Account
+		workspaces storage.Workspace
+		reports    storage.Report
+		events     storage.Audit
+		webhooks   storage.Webhook
+		notifier   usecases.Notifier
+		leader     usecases.Leader
+		driver     string
+		switchover storage.Switchover
+		logger     *slog.Logger
+		queue      storage.Queue
+		// reportThreshold defines the number of open reports after which the short URL is not redirected
+		// until the moderator review.
+		reportThreshold int
+		// taskRetention defines the time the processed deferred task is kept for the job status.
+		taskRetention time.Duration
+		// outboxEvents defines that creation and deletion events are published from the outbox by the relay.
+		outboxEvents bool
 	}
+
+	// Option describes an option for URL shortening service.
+	Option func(*useCase)
 )
 
+// Accounts implements an option that sets the registered user accounts storage.
+func Accounts(s storage.Account) Option {
+	return func(uc *useCase) {
+		uc.accounts = s
+	}
+}
+
+// Workspaces implements an option that sets the workspaces storage.
+func Workspaces(s storage.Workspace) Option {
+	return func(uc *useCase) {
+		uc.workspaces = s
+	}
+}
+
+// Reports implements an option that sets the abuse reports storage.
+func Reports(s storage.Report) Option {
+	return func(uc *useCase) {
+		uc.reports = s
+	}
+}
+
+// Audit implements an option that sets the audit log storage.
+func Audit(s storage.Audit) Option {
+	return func(uc *useCase) {
+		uc.events = s
+	}
+}
+
+// Webhooks implements an option that sets the webhooks storage.
+func Webhooks(s storage.Webhook) Option {
+	return func(uc *useCase) {
+		uc.webhooks = s
+	}
+}
+
+// Notifier implements an option that sets the delivery of short URL events to webhooks.
+func Notifier(n usecases.Notifier) Option {
+	return func(uc *useCase) {
+		uc.notifier = n
+	}
+}
+
+// Leader implements an option that sets the leadership of the replica reported in the health status.
+func Leader(l usecases.Leader) Option {
+	return func(uc *useCase) {
+		uc.leader = l
+	}
+}
+
+// StorageDriver implements an option that sets the name of the active storage driver reported in the health status.
+func StorageDriver(name string) Option {
+	return func(uc *useCase) {
+		uc.driver = name
+	}
+}
+
+// Switchover implements an option that sets the storage writing short URLs to the primary and the secondary storage,
+// the driver of the current primary storage is reported in the health status then.
+func Switchover(s storage.Switchover) Option {
+	return func(uc *useCase) {
+		uc.switchover = s
+	}
+}
+
+// Queue implements an option that sets the durable deferred tasks storage,
+// deferred deletion is not supported without it.
+func Queue(s storage.Queue) Option {
+	return func(uc *useCase) {
+		uc.queue = s
+	}
+}
+
+// OutboxEvents implements an option that leaves publishing the short URL creation and deletion events
+// to the outbox relay, which passes them back to the service as a sink.
+func OutboxEvents() Option {
+	return func(uc *useCase) {
+		uc.outboxEvents = true
+	}
+}
+
 // CreateURL implements the creation of a short URL.
 func (uc *useCase) CreateURL(ctx context.Context, rawURL string, userID string) (entity.URL, error) {
 	longURL, err := url.ParseRequestURI(rawURL)
@@ -44,6 +150,10 @@ func (uc *useCase) CreateURL(ctx context.Context, rawURL string, userID string)
 		return nil, err
 	}
 
+	if err = uc.checkBanned(ctx, parsedUserID); err != nil {
+		return nil, err
+	}
+
 	id := uuid.New()
 
 	shortURL.Path = encodeUUID(id)
@@ -73,6 +183,9 @@ func (uc *useCase) CreateURL(ctx context.Context, rawURL string, userID string)
 		return nil, err
 	}
 
+	uc.audit(ctx, audit.ActionCreate, parsedUserID, id)
+	uc.notify(ctx, webhook.EventURLCreated, addURL)
+
 	return addURL, nil
 }
 
@@ -100,6 +213,14 @@ func (uc *useCase) GetURL(ctx context.Context, urlID string) (entity.URL, error)
 		return nil, entity.ErrDeleted
 	}
 
+	if u.Disabled() {
+		return nil, entity.ErrDisabled
+	}
+
+	if err = uc.checkReports(ctx, id); err != nil {
+		return nil, err
+	}
+
 	shortURL := url.URL{
 		Scheme: uc.baseURL.Scheme,
 		Host:   uc.baseURL.Host,
@@ -108,6 +229,7 @@ func (uc *useCase) GetURL(ctx context.Context, urlID string) (entity.URL, error)
 	shortURL.Path = encodeUUID(id)
 
 	u.SetShortURL(shortURL)
+	uc.notify(ctx, webhook.EventURLClicked, u)
 
 	return u, nil
 }
@@ -137,14 +259,133 @@ func (uc *useCase) GetUserURLs(ctx context.Context, userID string) ([]entity.URL
 	return urls, nil
 }
 
+// UpdateURL implements changing the long URL of the short URL and moving it to another workspace.
+// Empty values keep the current ones, the user must be an editor of both workspaces.
+func (uc *useCase) UpdateURL(ctx context.Context, userID, urlID, rawURL, workspaceID string) (entity.URL, error) {
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+		return nil, ErrParseUUID
+	}
+
+	decoded, err := decodeUUID(urlID)
+	if err != nil {
+		uc.logger.Error("decode short url", err)
+		return nil, ErrDecodeURL
+	}
+
+	id, err := uuid.FromBytes(decoded)
+	if err != nil {
+		uc.logger.Error("failed create uuid from url id", err, slog.String("urlID", urlID))
+		return nil, ErrParseUUID
+	}
+
+	current, err := uc.storage.Get(ctx, id)
+	if err != nil {
+		uc.logger.Error("failed get url", err, slog.String("urlID", urlID))
+		return nil, err
+	}
+
+	if current.Deleted() {
+		return nil, entity.ErrDeleted
+	}
+
+	if err = uc.authorize(ctx, current.WorkspaceID(), parsedUserID, workspace.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	u := entity.NewURL(id, parsedUserID)
+	u.SetLongURL(current.LongValue())
+	u.SetWorkspaceID(current.WorkspaceID())
+
+	if len(rawURL) > 0 {
+		longURL, err := url.ParseRequestURI(rawURL)
+		if err != nil {
+			uc.logger.Error("parse long url", err, slog.String("longURL", rawURL))
+			return nil, ErrParseURL
+		}
+		u.SetLongURL(*longURL)
+	}
+
+	if len(workspaceID) > 0 {
+		parsedWorkspaceID, err := uuid.Parse(workspaceID)
+		if err != nil {
+			uc.logger.Error("failed parse RFC 4122 uuid from workspace id", err,
+				slog.String("workspaceID", workspaceID))
+			return nil, ErrParseUUID
+		}
+
+		if err = uc.authorize(ctx, parsedWorkspaceID, parsedUserID, workspace.RoleEditor); err != nil {
+			return nil, err
+		}
+		u.SetWorkspaceID(parsedWorkspaceID)
+	}
+
+	if err = uc.storage.Update(ctx, u); err != nil {
+		uc.logger.Error("failed update url", err, slog.String("urlID", urlID))
+		return nil, err
+	}
+
+	uc.audit(ctx, audit.ActionUpdate, parsedUserID, id)
+
+	shortURL := url.URL{
+		Scheme: uc.baseURL.Scheme,
+		Host:   uc.baseURL.Host,
+	}
+	shortURL.Path = encodeUUID(id)
+	u.SetShortURL(shortURL)
+
+	return u, nil
+}
+
+// Health implements getting the health status of the replica with the leadership.
+func (uc *useCase) Health(ctx context.Context) health.Status {
+	status := health.Status{
+		Storage: uc.storage.Ping(ctx),
+		Driver:  uc.driver,
+		Leader:  true,
+	}
+
+	if uc.switchover != nil {
+		status.Driver = uc.switchover.Primary()
+	}
+
+	if uc.leader != nil {
+		status.Instance = uc.leader.Instance()
+		status.Election = true
+		status.Leader = uc.leader.IsLeader()
+	}
+	return status
+}
+
+// PromoteStorage implements making the secondary storage primary, it returns the driver of the promoted storage.
+func (uc *useCase) PromoteStorage(ctx context.Context) (string, error) {
+	if uc.switchover == nil {
+		return "", ErrSwitchoverNotSupported
+	}
+
+	name, err := uc.switchover.Promote(ctx)
+	if err != nil {
+		uc.logger.Error("failed promote storage", err, slog.String("func", "PromoteStorage"))
+		return "", err
+	}
+	return name, nil
+}
+
 // StorageCheck implements storage health check.
 func (uc *useCase) StorageCheck(ctx context.Context) error {
 	return uc.storage.Ping(ctx)
 }
 
 // BatchURL implements the creation of several short URLs.
+// The items repeating the long URL of the batch get the same short URL, it is stored once.
+// When some long URLs are already shortened by the user, nothing is stored and these items
+// are returned with the existing short URLs along with the error.
 func (uc *useCase) BatchURL(ctx context.Context, correlationID, rawURL []string, userID string) ([]entity.URL, error) {
 	urls := []entity.URL{}
+	// unique keeps the items passed to the storage, the first item of every long URL.
+	unique := []entity.URL{}
+	ids := make(map[string]uuid.UUID, len(rawURL))
 
 	for idx, item := range rawURL {
 		longURL, err := url.ParseRequestURI(item)
@@ -164,7 +405,11 @@ func (uc *useCase) BatchURL(ctx context.Context, correlationID, rawURL []string,
 			return nil, ErrParseUUID
 		}
 
-		id := uuid.New()
+		id, repeated := ids[longURL.String()]
+		if !repeated {
+			id = uuid.New()
+			ids[longURL.String()] = id
+		}
 		shortURL.Path = encodeUUID(id)
 
 		u := entity.NewURL(id, parsedUserID)
@@ -172,50 +417,102 @@ func (uc *useCase) BatchURL(ctx context.Context, correlationID, rawURL []string,
 		u.SetLongURL(*longURL)
 		u.SetCorrelationID(correlationID[idx])
 		urls = append(urls, u)
+		if !repeated {
+			unique = append(unique, u)
+		}
 	}
 
-	err := uc.storage.Batch(ctx, urls)
+	if len(urls) > 0 {
+		if err := uc.checkBanned(ctx, urls[0].UserID()); err != nil {
+			return nil, err
+		}
+	}
+
+	err := uc.storage.Batch(ctx, unique)
 	if err != nil {
+		var errBatch *entity.ErrBatch
+		if errors.Is(err, entity.ErrAlreadyExist) && errors.As(err, &errBatch) {
+			return uc.batchExisting(urls, errBatch.Existing()), err
+		}
 		return nil, err
 	}
 
+	if len(unique) > 0 {
+		created := make([]uuid.UUID, len(unique))
+		for idx, u := range unique {
+			created[idx] = u.ID()
+		}
+		uc.audit(ctx, audit.ActionCreate, unique[0].UserID(), created...)
+		uc.notify(ctx, webhook.EventURLCreated, unique...)
+	}
+
 	return urls, nil
 }
 
+// batchExisting implements getting the batch items already shortened by the user with the existing short URLs,
+// the existing short URL IDs are keyed by the IDs of the batch items.
+func (uc *useCase) batchExisting(urls []entity.URL, existing map[uuid.UUID]uuid.UUID) []entity.URL {
+	conflicts := make([]entity.URL, 0, len(existing))
+	for _, u := range urls {
+		existingID, ok := existing[u.ID()]
+		if !ok {
+			continue
+		}
+
+		shortURL := url.URL{
+			Scheme: uc.baseURL.Scheme,
+			Host:   uc.baseURL.Host,
+			Path:   encodeUUID(existingID),
+		}
+		conflict := entity.NewURL(existingID, u.UserID())
+		conflict.SetShortURL(shortURL)
+		if longURL, err := url.Parse(u.LongURL()); err == nil {
+			conflict.SetLongURL(*longURL)
+		}
+		conflict.SetCorrelationID(u.CorrelationID())
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
 // DeleteURL implements the deletion multiple short URLs.
-func (uc *useCase) DeleteURL(_ context.Context, userID string, urlID []string) error {
-	urls := []entity.URL{}
+// The deletion is applied asynchronously to short URLs whose workspace the user may edit,
+// the returned job ID identifies the deletion status.
+func (uc *useCase) DeleteURL(ctx context.Context, userID string, urlID []string) (string, error) {
+	if uc.queue == nil {
+		return "", ErrJobsNotSupported
+	}
+
+	ids := []uuid.UUID{}
 	parsedUserID, err := uuid.ParseBytes([]byte(userID))
 	if err != nil {
 		uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
-		return ErrParseUUID
+		return "", ErrParseUUID
 	}
 
 	for _, i := range urlID {
 		decoded, err := decodeUUID(i)
 		if err != nil {
 			uc.logger.Error("decode short url", err)
-			return ErrDecodeURL
+			return "", ErrDecodeURL
 		}
 
 		id, err := uuid.FromBytes(decoded)
 		if err != nil {
 			uc.logger.Error("failed create uuid from url id", err, slog.String("urlID", i))
-			return ErrParseUUID
+			return "", ErrParseUUID
 		}
 
-		u := entity.NewURL(id, parsedUserID)
-		u.SetDeleted(true)
-		urls = append(urls, u)
+		ids = append(ids, id)
 	}
 
-	if len(uc.taskQueue) == cap(uc.taskQueue) {
-		return ErrTaskBufferFull
+	t := task.New(task.ActionDelete, parsedUserID, ids)
+	if err = uc.queue.AddTask(ctx, t); err != nil {
+		uc.logger.Error("failed add task", err, slog.String("userID", userID))
+		return "", err
 	}
 
-	uc.taskQueue <- *NewTask(deleteAction, urls)
-
-	return nil
+	return t.ID.String(), nil
 }
 
 // GetStats implements getting stats of the short URLs service.
@@ -239,14 +536,20 @@ func (uc *useCase) GetStats(ctx context.Context) (stats.Collection, error) {
 }
 
 // New implements the creation of a URL shortening service.
-func New(s storage.URL, cfg config.ShortURL) *useCase {
+func New(s storage.URL, cfg config.ShortURL, opts ...Option) *useCase {
 	log := slog.New(slog.NewJSONHandler(os.Stdout).
 		WithAttrs([]slog.Attr{slog.String("service", "shortener")}))
-	taskQueue := make(chan task, cfg.GetMaxTaskQueue())
-	return &useCase{
-		baseURL:   cfg.GetBaseURL(),
-		storage:   s,
-		logger:    log,
-		taskQueue: taskQueue,
+	uc := &useCase{
+		baseURL:         cfg.GetBaseURL(),
+		storage:         s,
+		logger:          log,
+		reportThreshold: cfg.GetReportThreshold(),
+		taskRetention:   cfg.GetTaskRetention(),
+	}
+
+	for _, opt := range opts {
+		opt(uc)
 	}
+
+	return uc
 }
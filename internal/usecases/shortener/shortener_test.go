@@ -11,9 +11,11 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sreway/shorturl/internal/config"
+	"github.com/sreway/shorturl/internal/domain/health"
 	"github.com/sreway/shorturl/internal/domain/url"
 	urlMock "github.com/sreway/shorturl/internal/domain/url/mock"
 	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
 )
 
 func Test_useCase_CreateURL(t *testing.T) {
@@ -23,6 +25,7 @@ func Test_useCase_CreateURL(t *testing.T) {
 	}
 	type fields struct {
 		repoErr error
+		banned  bool
 	}
 	tests := []struct {
 		name    string
@@ -68,6 +71,18 @@ func Test_useCase_CreateURL(t *testing.T) {
 			},
 			wantErr: assert.Error,
 		},
+
+		{
+			name: "negative create url (banned user)",
+			args: args{
+				rawURL: "https://ya.ru",
+				userID: "624708fa-d258-4b99-b09a-49d95f294626",
+			},
+			fields: fields{
+				banned: true,
+			},
+			wantErr: assert.Error,
+		},
 	}
 	anyMock := gomock.Any()
 	ctl := gomock.NewController(t)
@@ -80,6 +95,7 @@ func Test_useCase_CreateURL(t *testing.T) {
 		repo := repoMock.NewMockURL(ctl)
 		uc := New(repo, cfg.GetShortURL())
 
+		repo.EXPECT().IsBanned(anyMock, anyMock).Return(tt.fields.banned, nil).AnyTimes()
 		repo.EXPECT().Add(anyMock, anyMock).Return(tt.fields.repoErr).AnyTimes()
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := uc.CreateURL(ctx, tt.args.rawURL, tt.args.userID)
@@ -100,8 +116,9 @@ func Test_useCase_CreateURL(t *testing.T) {
 
 func Test_useCase_GetURL(t *testing.T) {
 	type args struct {
-		urlID   string
-		deleted bool
+		urlID    string
+		deleted  bool
+		disabled bool
 	}
 	type fields struct {
 		repoErr error
@@ -159,6 +176,15 @@ func Test_useCase_GetURL(t *testing.T) {
 			},
 			wantErr: assert.Error,
 		},
+
+		{
+			name: "negative get url (disabled)",
+			args: args{
+				urlID:    "5nPymsbLZfXlsUDlZ4MIhY",
+				disabled: true,
+			},
+			wantErr: assert.Error,
+		},
 	}
 	anyMock := gomock.Any()
 	ctl := gomock.NewController(t)
@@ -172,6 +198,7 @@ func Test_useCase_GetURL(t *testing.T) {
 		uc := New(repo, cfg.GetShortURL())
 		mockURL := urlMock.NewMockURL(ctl)
 		mockURL.EXPECT().Deleted().Return(tt.args.deleted).AnyTimes()
+		mockURL.EXPECT().Disabled().Return(tt.args.disabled).AnyTimes()
 		mockURL.EXPECT().SetShortURL(anyMock).AnyTimes()
 		repo.EXPECT().Get(anyMock, anyMock).Return(mockURL, tt.fields.repoErr).AnyTimes()
 		t.Run(tt.name, func(t *testing.T) {
@@ -294,6 +321,131 @@ func Test_useCase_StorageCheck(t *testing.T) {
 	}
 }
 
+func Test_useCase_Health(t *testing.T) {
+	errStorage := errors.New("any error")
+	type fields struct {
+		repoErr  error
+		election bool
+		isLeader bool
+		driver   string
+		primary  string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   health.Status
+	}{
+		{
+			name: "positive health (single replica)",
+			want: health.Status{Leader: true},
+		},
+		{
+			name:   "positive health (follower)",
+			fields: fields{election: true, driver: "postgres"},
+			want:   health.Status{Instance: "host-1", Driver: "postgres", Election: true},
+		},
+		{
+			name:   "positive health (promoted storage)",
+			fields: fields{driver: "file", primary: "postgres"},
+			want:   health.Status{Driver: "postgres", Leader: true},
+		},
+		{
+			name:   "negative health (storage error)",
+			fields: fields{repoErr: errStorage, election: true, isLeader: true},
+			want:   health.Status{Instance: "host-1", Storage: errStorage, Election: true, Leader: true},
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		repo.EXPECT().Ping(anyMock).Return(tt.fields.repoErr)
+
+		var opts []Option
+		if len(tt.fields.driver) > 0 {
+			opts = append(opts, StorageDriver(tt.fields.driver))
+		}
+		if len(tt.fields.primary) > 0 {
+			switchover := repoMock.NewMockSwitchover(ctl)
+			switchover.EXPECT().Primary().Return(tt.fields.primary)
+			opts = append(opts, Switchover(switchover))
+		}
+		if tt.fields.election {
+			leader := usecasesMock.NewMockLeader(ctl)
+			leader.EXPECT().Instance().Return("host-1")
+			leader.EXPECT().IsLeader().Return(tt.fields.isLeader)
+			opts = append(opts, Leader(leader))
+		}
+		uc := New(repo, cfg.GetShortURL(), opts...)
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, uc.Health(ctx))
+		})
+	}
+}
+
+func Test_useCase_PromoteStorage(t *testing.T) {
+	errPing := errors.New("any error")
+	type fields struct {
+		switchover bool
+		promoteErr error
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "positive promote storage",
+			fields: fields{switchover: true},
+			want:   "postgres",
+		},
+		{
+			name:    "negative promote storage (secondary unavailable)",
+			fields:  fields{switchover: true, promoteErr: errPing},
+			wantErr: errPing,
+		},
+		{
+			name:    "negative promote storage (dual-write not used)",
+			wantErr: ErrSwitchoverNotSupported,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+
+		var opts []Option
+		if tt.fields.switchover {
+			switchover := repoMock.NewMockSwitchover(ctl)
+			if tt.fields.promoteErr != nil {
+				switchover.EXPECT().Promote(anyMock).Return("", tt.fields.promoteErr)
+			} else {
+				switchover.EXPECT().Promote(anyMock).Return(tt.want, nil)
+			}
+			opts = append(opts, Switchover(switchover))
+		}
+		uc := New(repo, cfg.GetShortURL(), opts...)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.PromoteStorage(ctx)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_useCase_BatchURL(t *testing.T) {
 	type args struct {
 		userID        string
@@ -378,6 +530,7 @@ func Test_useCase_BatchURL(t *testing.T) {
 		assert.NoError(t, err)
 		repo := repoMock.NewMockURL(ctl)
 		uc := New(repo, cfg.GetShortURL())
+		repo.EXPECT().IsBanned(anyMock, anyMock).Return(false, nil).AnyTimes()
 		repo.EXPECT().Batch(anyMock, anyMock).Return(tt.fields.repoErr).AnyTimes()
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := uc.BatchURL(ctx, tt.args.correlationID, tt.args.rawURL, tt.args.userID)
@@ -399,6 +552,74 @@ func Test_useCase_BatchURL(t *testing.T) {
 	}
 }
 
+func Test_useCase_BatchURL_existing(t *testing.T) {
+	existingID := uuid.MustParse("3f4a5b6c-7d8e-4f9a-8b0c-2d3e4f5a6b7c")
+	tests := []struct {
+		name          string
+		rawURL        []string
+		correlationID []string
+		existing      bool
+		wantStored    int
+		wantURLs      int
+		wantErr       error
+	}{
+		{
+			name:          "positive batch url (repeated long url)",
+			rawURL:        []string{"https://ya.ru", "https://go.dev", "https://ya.ru"},
+			correlationID: []string{"1", "2", "3"},
+			wantStored:    2,
+			wantURLs:      3,
+		},
+		{
+			name:          "negative batch url (existing short url)",
+			rawURL:        []string{"https://ya.ru", "https://go.dev"},
+			correlationID: []string{"1", "2"},
+			existing:      true,
+			wantStored:    2,
+			wantURLs:      1,
+			wantErr:       url.ErrAlreadyExist,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.NewConfig()
+			assert.NoError(t, err)
+			repo := repoMock.NewMockURL(ctl)
+			uc := New(repo, cfg.GetShortURL())
+			repo.EXPECT().IsBanned(anyMock, anyMock).Return(false, nil)
+			repo.EXPECT().Batch(anyMock, anyMock).DoAndReturn(func(_ context.Context, urls []url.URL) error {
+				assert.Len(t, urls, tt.wantStored)
+				if tt.existing {
+					// the first long URL is already shortened by the user
+					return url.NewBatchErr(map[uuid.UUID]uuid.UUID{urls[0].ID(): existingID}, url.ErrAlreadyExist)
+				}
+				return nil
+			})
+
+			got, err := uc.BatchURL(ctx, tt.correlationID, tt.rawURL, "035f67d8-626b-48f2-b436-8509954fc452")
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Len(t, got, tt.wantURLs)
+
+			if tt.existing {
+				assert.Equal(t, existingID, got[0].ID())
+				assert.Equal(t, "1", got[0].CorrelationID())
+				assert.Equal(t, "https://ya.ru", got[0].LongURL())
+				assert.Equal(t, cfg.GetShortURL().GetBaseURL().String()+"/"+encodeUUID(existingID), got[0].ShortURL())
+				return
+			}
+
+			assert.Equal(t, got[0].ShortURL(), got[2].ShortURL())
+			assert.NotEqual(t, got[0].ShortURL(), got[1].ShortURL())
+			assert.Equal(t, "3", got[2].CorrelationID())
+		})
+	}
+}
+
 func Test_useCase_DeleteURL(t *testing.T) {
 	type args struct {
 		userID string
@@ -449,9 +670,11 @@ func Test_useCase_DeleteURL(t *testing.T) {
 		cfg, err := config.NewConfig()
 		assert.NoError(t, err)
 		repo := repoMock.NewMockURL(ctl)
-		uc := New(repo, cfg.GetShortURL())
+		queue := repoMock.NewMockQueue(ctl)
+		queue.EXPECT().AddTask(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		uc := New(repo, cfg.GetShortURL(), Queue(queue))
 		t.Run(tt.name, func(t *testing.T) {
-			err = uc.DeleteURL(ctx, tt.args.userID, tt.args.urlID)
+			_, err = uc.DeleteURL(ctx, tt.args.userID, tt.args.urlID)
 			if !tt.wantErr(t, err, fmt.Sprintf("DeleteURL(%v)", tt.args.urlID)) {
 				return
 			}
@@ -0,0 +1,174 @@
+package shortener
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/webhook"
+)
+
+// webhookSecretSize defines the number of random bytes of the webhook secret.
+const webhookSecretSize = 32
+
+// CreateWebhook implements the registration of the webhook endpoint of the user for the event types.
+// Endpoints resolving to loopback, private or link-local addresses are rejected.
+// The generated secret is returned once, it signs every delivered payload.
+func (uc *useCase) CreateWebhook(ctx context.Context, userID, rawURL string,
+	events []string,
+) (webhook.Webhook, error) {
+	if uc.webhooks == nil {
+		return nil, ErrWebhooksNotSupported
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+		return nil, ErrParseUUID
+	}
+
+	endpoint, err := url.ParseRequestURI(rawURL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || len(endpoint.Host) == 0 {
+		uc.logger.Error("parse webhook url", err, slog.String("url", rawURL))
+		return nil, ErrParseURL
+	}
+
+	if uc.notifier != nil {
+		if err = uc.notifier.CheckEndpoint(ctx, endpoint.Hostname()); err != nil {
+			uc.logger.Error("failed check webhook endpoint", err, slog.String("url", rawURL))
+			if errors.Is(err, webhook.ErrForbiddenEndpoint) {
+				return nil, err
+			}
+			return nil, ErrParseURL
+		}
+	}
+
+	if len(events) == 0 {
+		return nil, ErrEmptyWebhookEvents
+	}
+
+	eventTypes := make([]webhook.EventType, 0, len(events))
+	for _, v := range events {
+		event, err := webhook.ParseEventType(v)
+		if err != nil {
+			return nil, err
+		}
+		eventTypes = append(eventTypes, event)
+	}
+
+	secret := make([]byte, webhookSecretSize)
+	if _, err = rand.Read(secret); err != nil {
+		uc.logger.Error("failed generate webhook secret", err)
+		return nil, err
+	}
+
+	item := webhook.NewWebhook(uuid.New(), parsedUserID)
+	item.SetURL(endpoint.String())
+	item.SetSecret(hex.EncodeToString(secret))
+	item.SetEvents(eventTypes)
+
+	if err = uc.webhooks.AddWebhook(ctx, item); err != nil {
+		uc.logger.Error("failed add webhook", err, slog.String("userID", userID))
+		return nil, err
+	}
+
+	if uc.notifier != nil {
+		uc.notifier.Invalidate(parsedUserID)
+	}
+
+	return item, nil
+}
+
+// GetWebhooks implements getting webhooks of the user.
+func (uc *useCase) GetWebhooks(ctx context.Context, userID string) ([]webhook.Webhook, error) {
+	if uc.webhooks == nil {
+		return nil, ErrWebhooksNotSupported
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+		return nil, ErrParseUUID
+	}
+
+	webhooks, err := uc.webhooks.GetWebhooks(ctx, parsedUserID)
+	if err != nil {
+		uc.logger.Error("failed get webhooks", err, slog.String("userID", userID))
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook implements the removal of the webhook of the user.
+func (uc *useCase) DeleteWebhook(ctx context.Context, userID, webhookID string) error {
+	if uc.webhooks == nil {
+		return ErrWebhooksNotSupported
+	}
+
+	ids, err := uc.parseUUIDs(userID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.webhooks.DeleteWebhook(ctx, ids[1], ids[0]); err != nil {
+		uc.logger.Error("failed delete webhook", err, slog.String("webhookID", webhookID))
+		return err
+	}
+
+	if uc.notifier != nil {
+		uc.notifier.Invalidate(ids[0])
+	}
+
+	return nil
+}
+
+// GetDeadLetters implements getting deliveries to the webhooks of the user that exhausted their attempts.
+func (uc *useCase) GetDeadLetters(ctx context.Context, userID string) ([]webhook.Delivery, error) {
+	if uc.webhooks == nil {
+		return nil, ErrWebhooksNotSupported
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+		return nil, ErrParseUUID
+	}
+
+	deliveries, err := uc.webhooks.GetDeadLetters(ctx, parsedUserID)
+	if err != nil {
+		uc.logger.Error("failed get dead letters", err, slog.String("userID", userID))
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// notify implements publishing the short URL events to the webhooks of the short URL user.
+func (uc *useCase) notify(ctx context.Context, event webhook.EventType, urls ...entity.URL) {
+	if uc.notifier == nil || (uc.outboxEvents && event != webhook.EventURLClicked) {
+		return
+	}
+
+	createdAt := time.Now().UTC()
+	for _, u := range urls {
+		uc.notifier.Publish(ctx, webhook.Event{
+			ID:        uuid.New(),
+			Type:      event,
+			UserID:    u.UserID(),
+			CreatedAt: createdAt,
+			Data: webhook.URLData{
+				ID:          encodeUUID(u.ID()),
+				ShortURL:    u.ShortURL(),
+				OriginalURL: u.LongURL(),
+			},
+		})
+	}
+}
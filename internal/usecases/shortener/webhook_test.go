@@ -0,0 +1,144 @@
+package shortener
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/config"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/webhook"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+	usecasesMock "github.com/sreway/shorturl/internal/usecases/mock"
+)
+
+func Test_useCase_CreateWebhook(t *testing.T) {
+	type args struct {
+		rawURL string
+		events []string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		checkErr error
+		wantErr  error
+	}{
+		{
+			name: "positive create webhook",
+			args: args{
+				rawURL: "https://crm.example.com/hooks/shorturl",
+				events: []string{"url.created", "url.clicked"},
+			},
+		},
+		{
+			name: "negative create webhook (invalid scheme)",
+			args: args{
+				rawURL: "ftp://crm.example.com/hooks",
+				events: []string{"url.created"},
+			},
+			wantErr: ErrParseURL,
+		},
+		{
+			name: "negative create webhook (private endpoint)",
+			args: args{
+				rawURL: "http://169.254.169.254/latest/meta-data",
+				events: []string{"url.created"},
+			},
+			checkErr: webhook.ErrForbiddenEndpoint,
+			wantErr:  webhook.ErrForbiddenEndpoint,
+		},
+		{
+			name: "negative create webhook (unresolved endpoint)",
+			args: args{
+				rawURL: "https://crm.invalid/hooks",
+				events: []string{"url.created"},
+			},
+			checkErr: errors.New("no such host"),
+			wantErr:  ErrParseURL,
+		},
+		{
+			name: "negative create webhook (empty events)",
+			args: args{
+				rawURL: "https://crm.example.com/hooks/shorturl",
+			},
+			wantErr: ErrEmptyWebhookEvents,
+		},
+		{
+			name: "negative create webhook (invalid event)",
+			args: args{
+				rawURL: "https://crm.example.com/hooks/shorturl",
+				events: []string{"url.updated"},
+			},
+			wantErr: webhook.ErrInvalidEventType,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		webhooks := repoMock.NewMockWebhook(ctl)
+		n := usecasesMock.NewMockNotifier(ctl)
+		uc := New(repo, cfg.GetShortURL(), Webhooks(webhooks), Notifier(n))
+
+		n.EXPECT().CheckEndpoint(anyMock, anyMock).Return(tt.checkErr).AnyTimes()
+		if tt.wantErr == nil {
+			webhooks.EXPECT().AddWebhook(anyMock, anyMock).Return(nil)
+			n.EXPECT().Invalidate(userID)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.CreateWebhook(ctx, userID.String(), tt.args.rawURL, tt.args.events)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr, fmt.Sprintf("CreateWebhook(%v)", tt.args.rawURL))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, userID, got.UserID())
+			assert.Equal(t, tt.args.rawURL, got.URL())
+			assert.Len(t, got.Secret(), 2*webhookSecretSize)
+			assert.True(t, got.Subscribed(webhook.EventURLClicked))
+			assert.False(t, got.Subscribed(webhook.EventURLDeleted))
+		})
+	}
+}
+
+func Test_useCase_GetURL_notify(t *testing.T) {
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cfg, err := config.NewConfig()
+	assert.NoError(t, err)
+	repo := repoMock.NewMockURL(ctl)
+	n := usecasesMock.NewMockNotifier(ctl)
+	uc := New(repo, cfg.GetShortURL(), Notifier(n))
+
+	ownerID := uuid.New()
+	id := uuid.MustParse("c9f7c7a6-2a44-4d6a-9e18-bc0e1b37e5a1")
+	u := entity.NewURL(id, ownerID)
+	u.SetLongURL(url.URL{Scheme: "https", Host: "ya.ru"})
+	repo.EXPECT().Get(anyMock, id).Return(u, nil)
+	n.EXPECT().Publish(anyMock, anyMock).Do(func(_ context.Context, event webhook.Event) {
+		assert.Equal(t, webhook.EventURLClicked, event.Type)
+		assert.Equal(t, ownerID, event.UserID)
+		assert.Equal(t, webhook.URLData{
+			ID:          encodeUUID(id),
+			ShortURL:    u.ShortURL(),
+			OriginalURL: "https://ya.ru",
+		}, event.Data)
+	})
+
+	_, err = uc.GetURL(context.Background(), encodeUUID(id))
+	assert.NoError(t, err)
+}
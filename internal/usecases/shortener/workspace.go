@@ -0,0 +1,176 @@
+package shortener
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/workspace"
+)
+
+// CreateWorkspace implements the creation of a workspace administered by the user.
+func (uc *useCase) CreateWorkspace(ctx context.Context, userID, name string) (workspace.Workspace, error) {
+	if uc.workspaces == nil {
+		return nil, ErrWorkspacesNotSupported
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+		return nil, ErrParseUUID
+	}
+
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return nil, ErrEmptyWorkspaceName
+	}
+
+	w := workspace.NewWorkspace(uuid.New(), name)
+	if err = uc.workspaces.AddWorkspace(ctx, w, parsedUserID); err != nil {
+		uc.logger.Error("failed add workspace", err, slog.String("userID", userID))
+		return nil, err
+	}
+
+	w.SetRole(workspace.RoleAdmin)
+	return w, nil
+}
+
+// GetWorkspaces implements getting workspaces the user is a member of.
+func (uc *useCase) GetWorkspaces(ctx context.Context, userID string) ([]workspace.Workspace, error) {
+	if uc.workspaces == nil {
+		return nil, ErrWorkspacesNotSupported
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		uc.logger.Error("failed parse RFC 4122 uuid from user id", err, slog.String("userID", userID))
+		return nil, ErrParseUUID
+	}
+
+	workspaces, err := uc.workspaces.GetWorkspaces(ctx, parsedUserID)
+	if err != nil {
+		uc.logger.Error("failed get workspaces", err, slog.String("userID", userID))
+		return nil, err
+	}
+
+	return workspaces, nil
+}
+
+// SetWorkspaceMember implements adding a workspace member or changing the member role,
+// only workspace administrators are allowed to manage members.
+func (uc *useCase) SetWorkspaceMember(ctx context.Context, userID, workspaceID, memberID, role string) error {
+	ids, err := uc.parseUUIDs(userID, workspaceID, memberID)
+	if err != nil {
+		return err
+	}
+
+	parsedRole, err := workspace.ParseRole(role)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.authorize(ctx, ids[1], ids[0], workspace.RoleAdmin); err != nil {
+		return err
+	}
+
+	if err = uc.workspaces.SetMember(ctx, ids[1], ids[2], parsedRole); err != nil {
+		uc.logger.Error("failed set workspace member", err, slog.String("workspaceID", workspaceID),
+			slog.String("memberID", memberID))
+		return err
+	}
+
+	return nil
+}
+
+// DeleteWorkspaceMember implements removing a workspace member,
+// only workspace administrators are allowed to manage members.
+func (uc *useCase) DeleteWorkspaceMember(ctx context.Context, userID, workspaceID, memberID string) error {
+	ids, err := uc.parseUUIDs(userID, workspaceID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.authorize(ctx, ids[1], ids[0], workspace.RoleAdmin); err != nil {
+		return err
+	}
+
+	if err = uc.workspaces.DeleteMember(ctx, ids[1], ids[2]); err != nil {
+		uc.logger.Error("failed delete workspace member", err, slog.String("workspaceID", workspaceID),
+			slog.String("memberID", memberID))
+		return err
+	}
+
+	return nil
+}
+
+// GetWorkspaceURLs implements getting short URLs of the workspace for its viewers.
+func (uc *useCase) GetWorkspaceURLs(ctx context.Context, userID, workspaceID string) ([]entity.URL, error) {
+	ids, err := uc.parseUUIDs(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = uc.authorize(ctx, ids[1], ids[0], workspace.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	urls, err := uc.storage.GetByWorkspaceID(ctx, ids[1], ids[0])
+	if err != nil {
+		uc.logger.Error("failed get url for workspace id", err, slog.String("workspaceID", workspaceID))
+		return nil, err
+	}
+
+	for idx, i := range urls {
+		shortURL := url.URL{
+			Scheme: uc.baseURL.Scheme,
+			Host:   uc.baseURL.Host,
+		}
+		shortURL.Path = encodeUUID(i.ID())
+		urls[idx].SetShortURL(shortURL)
+	}
+
+	return urls, nil
+}
+
+// authorize implements checking that the user has the required role in the workspace.
+// The personal workspace of the user is always allowed.
+func (uc *useCase) authorize(ctx context.Context, workspaceID, userID uuid.UUID, required workspace.Role) error {
+	if workspace.Personal(workspaceID, userID) {
+		return nil
+	}
+
+	if uc.workspaces == nil {
+		return ErrWorkspacesNotSupported
+	}
+
+	role, err := uc.workspaces.GetRole(ctx, workspaceID, userID)
+	if err != nil {
+		uc.logger.Error("failed get workspace role", err, slog.String("workspaceID", workspaceID.String()),
+			slog.String("userID", userID.String()))
+		return err
+	}
+
+	if !role.Allows(required) {
+		return workspace.ErrForbidden
+	}
+
+	return nil
+}
+
+// parseUUIDs implements parsing several RFC 4122 uuids in the order they are passed.
+func (uc *useCase) parseUUIDs(values ...string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(values))
+	for idx, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			uc.logger.Error("failed parse RFC 4122 uuid", err, slog.String("value", value))
+			return nil, ErrParseUUID
+		}
+		ids[idx] = id
+	}
+	return ids, nil
+}
@@ -0,0 +1,230 @@
+package shortener
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sreway/shorturl/internal/config"
+	entity "github.com/sreway/shorturl/internal/domain/url"
+	"github.com/sreway/shorturl/internal/domain/workspace"
+	repoMock "github.com/sreway/shorturl/internal/usecases/adapters/storage/mock"
+)
+
+func Test_useCase_UpdateURL(t *testing.T) {
+	type args struct {
+		rawURL      string
+		workspaceID string
+	}
+	type fields struct {
+		sourceRole workspace.Role
+		targetRole workspace.Role
+		personal   bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		fields  fields
+		wantErr error
+	}{
+		{
+			name: "positive update url (personal workspace)",
+			args: args{
+				rawURL: "https://ya.ru/new",
+			},
+			fields: fields{
+				personal: true,
+			},
+		},
+		{
+			name: "positive update url (workspace editor)",
+			args: args{
+				rawURL: "https://ya.ru/new",
+			},
+			fields: fields{
+				sourceRole: workspace.RoleEditor,
+			},
+		},
+		{
+			name: "positive move url (editor of both workspaces)",
+			args: args{
+				workspaceID: "1ebf6d5e-5bd9-4d27-9d0b-bb6a2d2f2d4e",
+			},
+			fields: fields{
+				sourceRole: workspace.RoleAdmin,
+				targetRole: workspace.RoleEditor,
+			},
+		},
+		{
+			name: "negative update url (workspace viewer)",
+			args: args{
+				rawURL: "https://ya.ru/new",
+			},
+			fields: fields{
+				sourceRole: workspace.RoleViewer,
+			},
+			wantErr: workspace.ErrForbidden,
+		},
+		{
+			name: "negative move url (viewer of target workspace)",
+			args: args{
+				workspaceID: "1ebf6d5e-5bd9-4d27-9d0b-bb6a2d2f2d4e",
+			},
+			fields: fields{
+				sourceRole: workspace.RoleEditor,
+				targetRole: workspace.RoleViewer,
+			},
+			wantErr: workspace.ErrForbidden,
+		},
+		{
+			name: "negative update url (invalid url)",
+			args: args{
+				rawURL: "invalid",
+			},
+			fields: fields{
+				personal: true,
+			},
+			wantErr: ErrParseURL,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.MustParse("624708fa-d258-4b99-b09a-49d95f294626")
+	sourceID := uuid.MustParse("7b7c8e62-3c3a-4a7f-8f0a-0d5f1c1c9c11")
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		workspaces := repoMock.NewMockWorkspace(ctl)
+		uc := New(repo, cfg.GetShortURL(), Workspaces(workspaces))
+
+		id := uuid.New()
+		current := entity.NewURL(id, uuid.New())
+		current.SetLongURL(url.URL{Scheme: "https", Host: "ya.ru"})
+		if tt.fields.personal {
+			current.SetWorkspaceID(userID)
+		} else {
+			current.SetWorkspaceID(sourceID)
+		}
+
+		repo.EXPECT().Get(anyMock, id).Return(current, nil).AnyTimes()
+		repo.EXPECT().Update(anyMock, anyMock).Return(nil).AnyTimes()
+		workspaces.EXPECT().GetRole(anyMock, sourceID, userID).Return(tt.fields.sourceRole, nil).AnyTimes()
+		if len(tt.args.workspaceID) > 0 {
+			workspaces.EXPECT().GetRole(anyMock, uuid.MustParse(tt.args.workspaceID), userID).
+				Return(tt.fields.targetRole, nil).AnyTimes()
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.UpdateURL(ctx, userID.String(), encodeUUID(id), tt.args.rawURL, tt.args.workspaceID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr, fmt.Sprintf("UpdateURL(%v)", id))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, userID, got.UserID())
+			if len(tt.args.rawURL) > 0 {
+				assert.Equal(t, tt.args.rawURL, got.LongURL())
+			} else {
+				assert.Equal(t, current.LongURL(), got.LongURL())
+			}
+			if len(tt.args.workspaceID) > 0 {
+				assert.Equal(t, tt.args.workspaceID, got.WorkspaceID().String())
+			} else {
+				assert.Equal(t, current.WorkspaceID(), got.WorkspaceID())
+			}
+		})
+	}
+}
+
+func Test_useCase_SetWorkspaceMember(t *testing.T) {
+	type args struct {
+		role string
+	}
+	type fields struct {
+		role    workspace.Role
+		roleErr error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		fields  fields
+		wantErr error
+	}{
+		{
+			name: "positive set member (admin)",
+			args: args{
+				role: "editor",
+			},
+			fields: fields{
+				role: workspace.RoleAdmin,
+			},
+		},
+		{
+			name: "negative set member (editor)",
+			args: args{
+				role: "editor",
+			},
+			fields: fields{
+				role: workspace.RoleEditor,
+			},
+			wantErr: workspace.ErrForbidden,
+		},
+		{
+			name: "negative set member (not a member)",
+			args: args{
+				role: "viewer",
+			},
+			fields: fields{
+				roleErr: workspace.ErrForbidden,
+			},
+			wantErr: workspace.ErrForbidden,
+		},
+		{
+			name: "negative set member (invalid role)",
+			args: args{
+				role: "owner",
+			},
+			fields: fields{
+				role: workspace.RoleAdmin,
+			},
+			wantErr: workspace.ErrInvalidRole,
+		},
+	}
+	anyMock := gomock.Any()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	workspaceID := uuid.New()
+	memberID := uuid.New()
+	for _, tt := range tests {
+		cfg, err := config.NewConfig()
+		assert.NoError(t, err)
+		repo := repoMock.NewMockURL(ctl)
+		workspaces := repoMock.NewMockWorkspace(ctl)
+		uc := New(repo, cfg.GetShortURL(), Workspaces(workspaces))
+
+		workspaces.EXPECT().GetRole(anyMock, workspaceID, userID).
+			Return(tt.fields.role, tt.fields.roleErr).AnyTimes()
+		if tt.wantErr == nil {
+			workspaces.EXPECT().SetMember(anyMock, workspaceID, memberID, workspace.Role(tt.args.role)).Return(nil)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			err := uc.SetWorkspaceMember(ctx, userID.String(), workspaceID.String(), memberID.String(), tt.args.role)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr, fmt.Sprintf("SetWorkspaceMember(%v)", tt.args.role))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS accounts
+(
+    id uuid PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    password_hash BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT uniq_email UNIQUE (email)
+    );
+
+COMMIT;
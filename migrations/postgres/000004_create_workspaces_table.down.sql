@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_urls_workspace_id;
+
+ALTER TABLE urls
+DROP COLUMN workspace_id;
+
+DROP TABLE workspace_members;
+
+DROP TABLE workspaces;
+
+COMMIT;
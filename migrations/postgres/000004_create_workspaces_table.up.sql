@@ -0,0 +1,29 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS workspaces
+(
+    id uuid PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    );
+
+CREATE TABLE IF NOT EXISTS workspace_members
+(
+    workspace_id uuid NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    CONSTRAINT pk_workspace_members PRIMARY KEY (workspace_id, user_id),
+    CONSTRAINT check_role CHECK (role IN ('viewer', 'editor', 'admin'))
+    );
+
+ALTER TABLE urls
+ADD COLUMN workspace_id uuid;
+
+UPDATE urls SET workspace_id = user_id;
+
+ALTER TABLE urls
+ALTER COLUMN workspace_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_urls_workspace_id ON urls (workspace_id);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE banned_users;
+
+ALTER TABLE urls
+DROP COLUMN disabled;
+
+COMMIT;
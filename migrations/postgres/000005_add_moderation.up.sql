@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE urls
+ADD COLUMN disabled BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS banned_users
+(
+    user_id uuid PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    );
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS reports
+(
+    id uuid PRIMARY KEY,
+    url_id uuid NOT NULL REFERENCES urls (id) ON DELETE CASCADE,
+    reporter_id uuid NOT NULL,
+    reason VARCHAR(500) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved BOOLEAN NOT NULL DEFAULT false
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_open_report ON reports (url_id, reporter_id) WHERE NOT resolved;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE webhook_dead_letters;
+
+DROP TABLE webhooks;
+
+COMMIT;
@@ -0,0 +1,29 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS webhooks
+(
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    events TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    );
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_user_id ON webhooks (user_id);
+
+CREATE TABLE IF NOT EXISTS webhook_dead_letters
+(
+    id uuid PRIMARY KEY,
+    webhook_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    event VARCHAR(32) NOT NULL,
+    payload BYTEA NOT NULL,
+    attempts INTEGER NOT NULL,
+    last_error TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    );
+
+CREATE INDEX IF NOT EXISTS idx_webhook_dead_letters_user_id ON webhook_dead_letters (user_id);
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id uuid PRIMARY KEY,
+    type VARCHAR(32) NOT NULL,
+    url_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    dispatched_at TIMESTAMPTZ
+    );
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (created_at) WHERE dispatched_at IS NULL;
+
+COMMIT;
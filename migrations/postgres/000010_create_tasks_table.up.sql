@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS tasks
+(
+    id uuid PRIMARY KEY,
+    action VARCHAR(32) NOT NULL,
+    user_id uuid NOT NULL,
+    url_ids uuid[] NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    run_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    );
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due ON tasks (run_at) WHERE status IN ('pending', 'running');
+
+COMMIT;
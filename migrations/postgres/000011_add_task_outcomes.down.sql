@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_tasks_user_id;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS outcomes;
+
+COMMIT;
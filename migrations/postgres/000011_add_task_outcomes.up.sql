@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS outcomes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_user_id ON tasks (user_id);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_dispatched ON outbox (dispatched_at) WHERE dispatched_at IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE audit_events DROP COLUMN IF EXISTS actor;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE audit_events ADD COLUMN IF NOT EXISTS actor VARCHAR(64) NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS urls
+(
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    workspace_id TEXT NOT NULL,
+    original_url VARCHAR(255) NOT NULL,
+    deleted BOOLEAN NOT NULL DEFAULT false,
+    disabled BOOLEAN NOT NULL DEFAULT false,
+    CONSTRAINT uniq_original_url UNIQUE (user_id, original_url)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_urls_workspace_id ON urls (workspace_id);
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS banned_users
+(
+    user_id TEXT PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
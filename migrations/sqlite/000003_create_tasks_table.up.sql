@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS tasks
+(
+    id TEXT PRIMARY KEY,
+    action VARCHAR(32) NOT NULL,
+    user_id TEXT NOT NULL,
+    url_ids TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    outcomes TEXT NOT NULL DEFAULT '{}',
+    -- the times are unix nanoseconds, so they are compared as numbers
+    run_at INTEGER NOT NULL,
+    created_at INTEGER NOT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_tasks_status_run_at ON tasks (status, run_at);
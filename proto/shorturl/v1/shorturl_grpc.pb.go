@@ -24,7 +24,9 @@ const (
 	ShortURLService_GetURL_FullMethodName       = "/shorturl.ShortURLService/GetURL"
 	ShortURLService_GetUserURLs_FullMethodName  = "/shorturl.ShortURLService/GetUserURLs"
 	ShortURLService_DeleteURL_FullMethodName    = "/shorturl.ShortURLService/DeleteURL"
+	ShortURLService_GetJob_FullMethodName       = "/shorturl.ShortURLService/GetJob"
 	ShortURLService_StorageCheck_FullMethodName = "/shorturl.ShortURLService/StorageCheck"
+	ShortURLService_Health_FullMethodName       = "/shorturl.ShortURLService/Health"
 )
 
 // ShortURLServiceClient is the client API for ShortURLService service.
@@ -36,7 +38,9 @@ type ShortURLServiceClient interface {
 	GetURL(ctx context.Context, in *GetURLRequest, opts ...grpc.CallOption) (*GetURLResponse, error)
 	GetUserURLs(ctx context.Context, in *GetUserURLRequest, opts ...grpc.CallOption) (*GetUserURLResponse, error)
 	DeleteURL(ctx context.Context, in *DeleteURLRequest, opts ...grpc.CallOption) (*DeleteURLResponse, error)
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
 	StorageCheck(ctx context.Context, in *StorageCheckRequest, opts ...grpc.CallOption) (*StorageCheckResponse, error)
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
 }
 
 type shortURLServiceClient struct {
@@ -92,6 +96,15 @@ func (c *shortURLServiceClient) DeleteURL(ctx context.Context, in *DeleteURLRequ
 	return out, nil
 }
 
+func (c *shortURLServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error) {
+	out := new(GetJobResponse)
+	err := c.cc.Invoke(ctx, ShortURLService_GetJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *shortURLServiceClient) StorageCheck(ctx context.Context, in *StorageCheckRequest, opts ...grpc.CallOption) (*StorageCheckResponse, error) {
 	out := new(StorageCheckResponse)
 	err := c.cc.Invoke(ctx, ShortURLService_StorageCheck_FullMethodName, in, out, opts...)
@@ -101,6 +114,15 @@ func (c *shortURLServiceClient) StorageCheck(ctx context.Context, in *StorageChe
 	return out, nil
 }
 
+func (c *shortURLServiceClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, ShortURLService_Health_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortURLServiceServer is the server API for ShortURLService service.
 // All implementations must embed UnimplementedShortURLServiceServer
 // for forward compatibility
@@ -110,7 +132,9 @@ type ShortURLServiceServer interface {
 	GetURL(context.Context, *GetURLRequest) (*GetURLResponse, error)
 	GetUserURLs(context.Context, *GetUserURLRequest) (*GetUserURLResponse, error)
 	DeleteURL(context.Context, *DeleteURLRequest) (*DeleteURLResponse, error)
+	GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error)
 	StorageCheck(context.Context, *StorageCheckRequest) (*StorageCheckResponse, error)
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
 	mustEmbedUnimplementedShortURLServiceServer()
 }
 
@@ -133,9 +157,15 @@ func (UnimplementedShortURLServiceServer) GetUserURLs(context.Context, *GetUserU
 func (UnimplementedShortURLServiceServer) DeleteURL(context.Context, *DeleteURLRequest) (*DeleteURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteURL not implemented")
 }
+func (UnimplementedShortURLServiceServer) GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
 func (UnimplementedShortURLServiceServer) StorageCheck(context.Context, *StorageCheckRequest) (*StorageCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StorageCheck not implemented")
 }
+func (UnimplementedShortURLServiceServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
 func (UnimplementedShortURLServiceServer) mustEmbedUnimplementedShortURLServiceServer() {}
 
 // UnsafeShortURLServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -239,6 +269,24 @@ func _ShortURLService_DeleteURL_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortURLService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortURLServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortURLService_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortURLServiceServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ShortURLService_StorageCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StorageCheckRequest)
 	if err := dec(in); err != nil {
@@ -257,6 +305,24 @@ func _ShortURLService_StorageCheck_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortURLService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortURLServiceServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortURLService_Health_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortURLServiceServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ShortURLService_ServiceDesc is the grpc.ServiceDesc for ShortURLService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -284,10 +350,330 @@ var ShortURLService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteURL",
 			Handler:    _ShortURLService_DeleteURL_Handler,
 		},
+		{
+			MethodName: "GetJob",
+			Handler:    _ShortURLService_GetJob_Handler,
+		},
 		{
 			MethodName: "StorageCheck",
 			Handler:    _ShortURLService_StorageCheck_Handler,
 		},
+		{
+			MethodName: "Health",
+			Handler:    _ShortURLService_Health_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/shorturl/v1/shorturl.proto",
+}
+
+const (
+	AdminService_SearchURLs_FullMethodName     = "/shorturl.AdminService/SearchURLs"
+	AdminService_DisableURL_FullMethodName     = "/shorturl.AdminService/DisableURL"
+	AdminService_BanUser_FullMethodName        = "/shorturl.AdminService/BanUser"
+	AdminService_ForceDeleteURL_FullMethodName = "/shorturl.AdminService/ForceDeleteURL"
+	AdminService_GetReportQueue_FullMethodName = "/shorturl.AdminService/GetReportQueue"
+	AdminService_ResolveReports_FullMethodName = "/shorturl.AdminService/ResolveReports"
+	AdminService_PromoteStorage_FullMethodName = "/shorturl.AdminService/PromoteStorage"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	SearchURLs(ctx context.Context, in *SearchURLRequest, opts ...grpc.CallOption) (*SearchURLResponse, error)
+	DisableURL(ctx context.Context, in *DisableURLRequest, opts ...grpc.CallOption) (*DisableURLResponse, error)
+	BanUser(ctx context.Context, in *BanUserRequest, opts ...grpc.CallOption) (*BanUserResponse, error)
+	ForceDeleteURL(ctx context.Context, in *ForceDeleteURLRequest, opts ...grpc.CallOption) (*ForceDeleteURLResponse, error)
+	GetReportQueue(ctx context.Context, in *GetReportQueueRequest, opts ...grpc.CallOption) (*GetReportQueueResponse, error)
+	ResolveReports(ctx context.Context, in *ResolveReportsRequest, opts ...grpc.CallOption) (*ResolveReportsResponse, error)
+	PromoteStorage(ctx context.Context, in *PromoteStorageRequest, opts ...grpc.CallOption) (*PromoteStorageResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) SearchURLs(ctx context.Context, in *SearchURLRequest, opts ...grpc.CallOption) (*SearchURLResponse, error) {
+	out := new(SearchURLResponse)
+	err := c.cc.Invoke(ctx, AdminService_SearchURLs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DisableURL(ctx context.Context, in *DisableURLRequest, opts ...grpc.CallOption) (*DisableURLResponse, error) {
+	out := new(DisableURLResponse)
+	err := c.cc.Invoke(ctx, AdminService_DisableURL_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) BanUser(ctx context.Context, in *BanUserRequest, opts ...grpc.CallOption) (*BanUserResponse, error) {
+	out := new(BanUserResponse)
+	err := c.cc.Invoke(ctx, AdminService_BanUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ForceDeleteURL(ctx context.Context, in *ForceDeleteURLRequest, opts ...grpc.CallOption) (*ForceDeleteURLResponse, error) {
+	out := new(ForceDeleteURLResponse)
+	err := c.cc.Invoke(ctx, AdminService_ForceDeleteURL_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetReportQueue(ctx context.Context, in *GetReportQueueRequest, opts ...grpc.CallOption) (*GetReportQueueResponse, error) {
+	out := new(GetReportQueueResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetReportQueue_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ResolveReports(ctx context.Context, in *ResolveReportsRequest, opts ...grpc.CallOption) (*ResolveReportsResponse, error) {
+	out := new(ResolveReportsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ResolveReports_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PromoteStorage(ctx context.Context, in *PromoteStorageRequest, opts ...grpc.CallOption) (*PromoteStorageResponse, error) {
+	out := new(PromoteStorageResponse)
+	err := c.cc.Invoke(ctx, AdminService_PromoteStorage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility
+type AdminServiceServer interface {
+	SearchURLs(context.Context, *SearchURLRequest) (*SearchURLResponse, error)
+	DisableURL(context.Context, *DisableURLRequest) (*DisableURLResponse, error)
+	BanUser(context.Context, *BanUserRequest) (*BanUserResponse, error)
+	ForceDeleteURL(context.Context, *ForceDeleteURLRequest) (*ForceDeleteURLResponse, error)
+	GetReportQueue(context.Context, *GetReportQueueRequest) (*GetReportQueueResponse, error)
+	ResolveReports(context.Context, *ResolveReportsRequest) (*ResolveReportsResponse, error)
+	PromoteStorage(context.Context, *PromoteStorageRequest) (*PromoteStorageResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (UnimplementedAdminServiceServer) SearchURLs(context.Context, *SearchURLRequest) (*SearchURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchURLs not implemented")
+}
+func (UnimplementedAdminServiceServer) DisableURL(context.Context, *DisableURLRequest) (*DisableURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableURL not implemented")
+}
+func (UnimplementedAdminServiceServer) BanUser(context.Context, *BanUserRequest) (*BanUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanUser not implemented")
+}
+func (UnimplementedAdminServiceServer) ForceDeleteURL(context.Context, *ForceDeleteURLRequest) (*ForceDeleteURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceDeleteURL not implemented")
+}
+func (UnimplementedAdminServiceServer) GetReportQueue(context.Context, *GetReportQueueRequest) (*GetReportQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReportQueue not implemented")
+}
+func (UnimplementedAdminServiceServer) ResolveReports(context.Context, *ResolveReportsRequest) (*ResolveReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveReports not implemented")
+}
+func (UnimplementedAdminServiceServer) PromoteStorage(context.Context, *PromoteStorageRequest) (*PromoteStorageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteStorage not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_SearchURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SearchURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SearchURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SearchURLs(ctx, req.(*SearchURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DisableURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DisableURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DisableURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DisableURL(ctx, req.(*DisableURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_BanUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).BanUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_BanUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).BanUser(ctx, req.(*BanUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ForceDeleteURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceDeleteURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ForceDeleteURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ForceDeleteURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ForceDeleteURL(ctx, req.(*ForceDeleteURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetReportQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetReportQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetReportQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetReportQueue(ctx, req.(*GetReportQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ResolveReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ResolveReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ResolveReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ResolveReports(ctx, req.(*ResolveReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PromoteStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteStorageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PromoteStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_PromoteStorage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PromoteStorage(ctx, req.(*PromoteStorageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "shorturl.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchURLs",
+			Handler:    _AdminService_SearchURLs_Handler,
+		},
+		{
+			MethodName: "DisableURL",
+			Handler:    _AdminService_DisableURL_Handler,
+		},
+		{
+			MethodName: "BanUser",
+			Handler:    _AdminService_BanUser_Handler,
+		},
+		{
+			MethodName: "ForceDeleteURL",
+			Handler:    _AdminService_ForceDeleteURL_Handler,
+		},
+		{
+			MethodName: "GetReportQueue",
+			Handler:    _AdminService_GetReportQueue_Handler,
+		},
+		{
+			MethodName: "ResolveReports",
+			Handler:    _AdminService_ResolveReports_Handler,
+		},
+		{
+			MethodName: "PromoteStorage",
+			Handler:    _AdminService_PromoteStorage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/shorturl/v1/shorturl.proto",